@@ -41,25 +41,43 @@ type BrokerSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	DefaultCustomDomains []string `json:"defaultCustomDomains,omitempty"`
 
-	// GlobalCIDR supernet range for allocating GlobalCIDRs to each cluster.
+	// GlobalCIDR supernet range for allocating GlobalCIDRs to each cluster. An IPv4 and an IPv6 range may be specified,
+	// comma-separated, to allocate a GlobalCIDR per IP family.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Globalnet CIDR Range"
 	//nolint:lll // Markers can't be wrapped
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:fieldDependency:globalnetEnabled:true","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	GlobalnetCIDRRange string `json:"globalnetCIDRRange,omitempty"`
 
-	// ClustersetIP supernet range for allocating ClustersetIPCIDRs to each cluster.
+	// ClustersetIP supernet range for allocating ClustersetIPCIDRs to each cluster. An IPv4 and an IPv6 range may be
+	// specified, comma-separated, to allocate a ClustersetIPCIDR per IP family.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="ClustersetIP CIDR Range"
 	//nolint:lll // Markers can't be wrapped
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	// +optional
 	ClustersetIPCIDRRange string `json:"clustersetIPCIDRRange,omitempty"`
 
+	// How long the Globalnet and ClustersetIP CIDRs allocated to a cluster are kept after its Cluster resource is removed
+	// from the broker, 24 hours by default.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Departed Cluster Grace Period"
+	//nolint:lll // Markers can't be wrapped
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	DepartedClusterGracePeriod *metav1.Duration `json:"departedClusterGracePeriod,omitempty"`
+
 	// Default cluster size for GlobalCIDR allocated to each cluster (amount of global IPs).
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Default Globalnet Cluster Size"
 	//nolint:lll // Markers can't be wrapped
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number","urn:alm:descriptor:com.tectonic.ui:fieldDependency:globalnetEnabled:true","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	DefaultGlobalnetClusterSize uint `json:"defaultGlobalnetClusterSize,omitempty"`
 
+	// Default cluster size for the GlobalCIDR allocated to each cluster from the IPv6 Globalnet CIDR range (amount of
+	// global IPs), 65536 by default.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Default Globalnet IPv6 Cluster Size"
+	//nolint:lll // Markers can't be wrapped
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number","urn:alm:descriptor:com.tectonic.ui:fieldDependency:globalnetEnabled:true","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	DefaultGlobalnetIPv6ClusterSize uint `json:"defaultGlobalnetIPv6ClusterSize,omitempty"`
+
 	// Enable support for Overlapping CIDRs in connecting clusters.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enable Globalnet"
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
@@ -76,6 +94,118 @@ type BrokerSpec struct {
 type BrokerStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
+
+	// The clusters which joined the broker, as registered by their Cluster resources in the broker namespace.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Clusters"
+	// +optional
+	Clusters []BrokerClusterStatus `json:"clusters,omitempty"`
+
+	// The Globalnet CIDR range and the per-cluster allocations made from it.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Globalnet Allocations"
+	// +optional
+	Globalnet *CIDRAllocationStatus `json:"globalnet,omitempty"`
+
+	// The ClustersetIP CIDR range and the per-cluster allocations made from it.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="ClustersetIP Allocations"
+	// +optional
+	ClustersetIP *CIDRAllocationStatus `json:"clustersetIP,omitempty"`
+
+	// The clusters which still have CIDR allocations but no longer have a Cluster resource; their allocations are released
+	// once the grace period expires.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Departed Clusters"
+	// +optional
+	DepartedClusters []DepartedCluster `json:"departedClusters,omitempty"`
+
+	// The generation of the Broker resource most recently observed by the operator.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// BrokerClusterStatus describes a cluster registered with the broker.
+type BrokerClusterStatus struct {
+	// The ID of the cluster.
+	ClusterID string `json:"clusterID"`
+
+	// The service CIDRs of the cluster.
+	// +optional
+	ServiceCIDRs []string `json:"serviceCIDRs,omitempty"`
+
+	// The pod CIDRs of the cluster.
+	// +optional
+	ClusterCIDRs []string `json:"clusterCIDRs,omitempty"`
+
+	// The global CIDRs of the cluster, if Globalnet is enabled.
+	// +optional
+	GlobalCIDRs []string `json:"globalCIDRs,omitempty"`
+}
+
+// DepartedCluster describes a cluster whose Cluster resource was removed from the broker.
+type DepartedCluster struct {
+	// The time at which the cluster was first found to have departed.
+	DepartedSince metav1.Time `json:"departedSince"`
+
+	// The ID of the cluster.
+	ClusterID string `json:"clusterID"`
+}
+
+// CIDRAllocationStatus describes a CIDR range managed by the broker and the allocations made from it.
+type CIDRAllocationStatus struct {
+	// The usage of the CIDR range, the IPv4 one if it's dual-stack.
+	CIDRRangeStatus `json:",inline"`
+
+	// The usage of the IPv6 CIDR range, if the range is dual-stack.
+	// +optional
+	IPv6 *CIDRRangeStatus `json:"ipv6,omitempty"`
+
+	// The CIDRs allocated to each cluster.
+	// +optional
+	Allocations []ClusterCIDRAllocation `json:"allocations,omitempty"`
+
+	// Whether allocation from this CIDR range is enabled.
+	Enabled bool `json:"enabled"`
+}
+
+// CIDRRangeStatus describes the usage of the CIDR range of an IP family.
+type CIDRRangeStatus struct {
+	// The CIDR range from which the cluster CIDRs are allocated.
+	// +optional
+	CIDR string `json:"cidr,omitempty"`
+
+	// The default number of addresses allocated to each cluster.
+	// +optional
+	AllocationSize uint `json:"allocationSize,omitempty"`
+
+	// The number of addresses in the CIDR range which aren't allocated to any cluster. It saturates at the largest
+	// int64, which large IPv6 ranges exceed.
+	// +optional
+	FreeAddresses uint64 `json:"freeAddresses,omitempty"`
+
+	// The number of additional clusters which can be allocated the default number of addresses. It saturates like
+	// FreeAddresses.
+	// +optional
+	FreeAllocations uint64 `json:"freeAllocations,omitempty"`
+
+	// The number of unallocated parts of the CIDR range, which shows how fragmented it is.
+	// +optional
+	FreeBlockCount uint `json:"freeBlockCount,omitempty"`
+
+	// The first unallocated parts of the CIDR range, as the largest aligned CIDRs; at most 16 are listed.
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	FreeBlocks []string `json:"freeBlocks,omitempty"`
+
+	// The largest unallocated CIDR in the range, which bounds the size of the next allocation.
+	// +optional
+	LargestFreeBlock string `json:"largestFreeBlock,omitempty"`
+}
+
+// ClusterCIDRAllocation describes the CIDRs allocated to a cluster.
+type ClusterCIDRAllocation struct {
+	// The ID of the cluster.
+	ClusterID string `json:"clusterID"`
+
+	// The CIDRs allocated to the cluster.
+	CIDRs []string `json:"cidrs"`
 }
 
 //+kubebuilder:object:root=true
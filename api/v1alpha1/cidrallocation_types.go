@@ -0,0 +1,80 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CIDRAllocationPool identifies the broker CIDR range an allocation is made from.
+type CIDRAllocationPool string
+
+const (
+	GlobalnetPool    CIDRAllocationPool = "Globalnet"
+	ClustersetIPPool CIDRAllocationPool = "ClustersetIP"
+)
+
+// CIDRAllocationPoolLabel is the label identifying the pool of a CIDRAllocation.
+const CIDRAllocationPoolLabel = "submariner.io/cidr-pool"
+
+// CIDRAllocationSpec defines the CIDRs allocated to a cluster from a pool.
+type CIDRAllocationSpec struct {
+	// The ID of the cluster.
+	// +kubebuilder:validation:MinLength=1
+	ClusterID string `json:"clusterID"`
+
+	// The pool the CIDRs are allocated from.
+	// +kubebuilder:validation:Enum=Globalnet;ClustersetIP
+	Pool CIDRAllocationPool `json:"pool"`
+
+	// The CIDRs allocated to the cluster. The first CIDR of each IP family is the cluster's CIDR, any further CIDRs of the
+	// same family expand it.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	CIDRs []string `json:"cidrs"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:path=cidrallocations,scope=Namespaced
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterID"
+//+kubebuilder:printcolumn:name="Pool",type="string",JSONPath=".spec.pool"
+//+kubebuilder:printcolumn:name="CIDRs",type="string",JSONPath=".spec.cidrs"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// CIDRAllocation records the CIDRs allocated to a cluster from one of the broker's CIDR ranges. There is one per cluster
+// per pool, in the broker namespace.
+type CIDRAllocation struct { //nolint:govet // we want to keep the traditional order
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CIDRAllocationSpec `json:"spec"`
+}
+
+//+kubebuilder:object:root=true
+
+// CIDRAllocationList contains a list of CIDRAllocation.
+type CIDRAllocationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CIDRAllocation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CIDRAllocation{}, &CIDRAllocationList{})
+}
@@ -0,0 +1,49 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "strings"
+
+// GetClusterCIDRs returns the configured cluster CIDRs: the ClusterCIDRs or, if they're empty, the deprecated
+// comma-separated ClusterCIDR.
+func (s *SubmarinerSpec) GetClusterCIDRs() []string {
+	return cidrsOrDeprecated(s.ClusterCIDRs, s.ClusterCIDR)
+}
+
+// GetServiceCIDRs returns the configured service CIDRs: the ServiceCIDRs or, if they're empty, the deprecated
+// comma-separated ServiceCIDR.
+func (s *SubmarinerSpec) GetServiceCIDRs() []string {
+	return cidrsOrDeprecated(s.ServiceCIDRs, s.ServiceCIDR)
+}
+
+func cidrsOrDeprecated(cidrs []string, deprecated string) []string {
+	if len(cidrs) > 0 {
+		return cidrs
+	}
+
+	var split []string
+
+	for _, cidr := range strings.Split(deprecated, ",") {
+		if cidr = strings.TrimSpace(cidr); cidr != "" {
+			split = append(split, cidr)
+		}
+	}
+
+	return split
+}
@@ -0,0 +1,39 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// CorrectsDrift returns whether the resources managed for this Submariner are re-applied when they drift.
+func (s *Submariner) CorrectsDrift() bool {
+	return s.Spec.DriftPolicy != DriftReportOnly
+}
+
+// DriftDetected records a drifted resource in the status.
+func (s *Submariner) DriftDetected(resource DriftedResource) {
+	s.Status.DriftDetected = append(s.Status.DriftDetected, resource)
+}
+
+// CorrectsDrift returns whether the resources managed for this ServiceDiscovery are re-applied when they drift.
+func (s *ServiceDiscovery) CorrectsDrift() bool {
+	return s.Spec.DriftPolicy != DriftReportOnly
+}
+
+// DriftDetected records a drifted resource in the status.
+func (s *ServiceDiscovery) DriftDetected(resource DriftedResource) {
+	s.Status.DriftDetected = append(s.Status.DriftDetected, resource)
+}
@@ -0,0 +1,29 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// IsPaused returns whether the reconciliation of this Submariner is paused, by its spec or by the PausedAnnotation.
+func (s *Submariner) IsPaused() bool {
+	return s.Spec.Paused || s.Annotations[PausedAnnotation] == "true"
+}
+
+// IsPaused returns whether the reconciliation of this ServiceDiscovery is paused, by its spec or by the PausedAnnotation.
+func (s *ServiceDiscovery) IsPaused() bool {
+	return s.Spec.Paused || s.Annotations[PausedAnnotation] == "true"
+}
@@ -48,7 +48,9 @@ type ServiceDiscoverySpec struct {
 	BrokerK8sInsecure      bool   `json:"brokerK8sInsecure,omitempty"`
 	HaltOnCertificateError bool   `json:"haltOnCertificateError,omitempty"`
 	// +optional
-	ClustersetIPEnabled bool                 `json:"clustersetIPEnabled,omitempty"`
+	ClustersetIPEnabled bool `json:"clustersetIPEnabled,omitempty"`
+	// +optional
+	Paused              bool                 `json:"paused,omitempty"`
 	CoreDNSCustomConfig *CoreDNSCustomConfig `json:"coreDNSCustomConfig,omitempty"`
 	// +listType=set
 	CustomDomains  []string          `json:"customDomains,omitempty"`
@@ -57,6 +59,19 @@ type ServiceDiscoverySpec struct {
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 	// +optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// +optional
+	RegistryMirrors []RegistryMirror `json:"registryMirrors,omitempty"`
+	// +optional
+	ImageDigests map[string]string `json:"imageDigests,omitempty"`
+	// +optional
+	Components map[string]ComponentSpec `json:"components,omitempty"`
+	// +kubebuilder:validation:Enum=AutoCorrect;ReportOnly
+	// +optional
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+	// +optional
+	Uninstall *UninstallSpec `json:"uninstall,omitempty"`
 }
 
 // ServiceDiscoveryStatus defines the observed state of ServiceDiscovery.
@@ -65,11 +80,20 @@ type ServiceDiscoveryStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
 
 	DeploymentInfo DeploymentInfo `json:"deploymentInfo,omitempty"`
+	// +optional
+	DriftDetected []DriftedResource `json:"driftDetected,omitempty"`
+	// +optional
+	Uninstall *UninstallStatus `json:"uninstall,omitempty"`
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:path=servicediscoveries,scope=Namespaced
+//+kubebuilder:storageversion
 
 // ServiceDiscovery is the Schema for the servicediscoveries API.
 type ServiceDiscovery struct {
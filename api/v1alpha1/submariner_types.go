@@ -75,11 +75,16 @@ type SubmarinerSpec struct {
 
 	CeIPSecPSKSecret string `json:"ceIPSecPSKSecret,omitempty"`
 
-	// The cluster CIDR.
+	// The cluster CIDRs, comma-separated. Deprecated: use clusterCIDRs, which take precedence.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cluster CIDR"
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	ClusterCIDR string `json:"clusterCIDR"`
 
+	// The cluster CIDRs. They're discovered when neither these nor clusterCIDR are set.
+	// +listType=atomic
+	// +optional
+	ClusterCIDRs []string `json:"clusterCIDRs,omitempty"`
+
 	// The cluster ID used to identify the tunnels.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cluster ID"
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
@@ -93,18 +98,32 @@ type SubmarinerSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	Repository string `json:"repository,omitempty"`
 
-	// The service CIDR.
+	// The service CIDRs, comma-separated. Deprecated: use serviceCIDRs, which take precedence.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Service CIDR"
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	ServiceCIDR string `json:"serviceCIDR"`
 
-	// The Global CIDR super-net range for allocating GlobalCIDRs to each cluster.
+	// The service CIDRs. They're discovered when neither these nor serviceCIDR are set.
+	// +listType=atomic
+	// +optional
+	ServiceCIDRs []string `json:"serviceCIDRs,omitempty"`
+
+	// The Global CIDR super-net range for allocating GlobalCIDRs to each cluster. On dual-stack clusters, an IPv4 and an
+	// IPv6 CIDR may be specified, comma-separated.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Global CIDR"
 	//nolint:lll // Markers can't be wrapped
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	GlobalCIDR string `json:"globalCIDR,omitempty"`
 
-	// ClustersetIP CIDR for allocating ClustersetIPs to exported services.
+	// The number of additional blocks, of the broker's default Globalnet cluster size, allocated to the cluster per IP
+	// family to expand the GlobalCIDR when it runs out of global IPs. The operator allocates them from the broker's
+	// Globalnet range; they aren't released if the number is decreased.
+	// +kubebuilder:validation:Maximum=15
+	// +optional
+	GlobalCIDRExpansions uint `json:"globalCIDRExpansions,omitempty"`
+
+	// ClustersetIP CIDR for allocating ClustersetIPs to exported services. On dual-stack clusters, an IPv4 and an IPv6
+	// CIDR may be specified, comma-separated.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="ClustersetIP CIDR"
 	//nolint:lll // Markers can't be wrapped
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
@@ -191,6 +210,13 @@ type SubmarinerSpec struct {
 	// +optional
 	ClustersetIPEnabled bool `json:"clustersetIPEnabled,omitempty"`
 
+	// Pause the reconciliation: the status is still refreshed but the managed resources and the DNS configuration are
+	// left as they are. The ServiceDiscovery resource is paused along with the Submariner resource.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Paused"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
 	// Name of the custom CoreDNS configmap to configure forwarding to Lighthouse.
 	// It should be in <namespace>/<name> format where <namespace> is optional and defaults to kube-system.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="CoreDNS Custom Config"
@@ -218,6 +244,44 @@ type SubmarinerSpec struct {
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 	// +optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Secrets used to pull the component images, attached to every pod managed by the operator.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image Pull Secrets"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// Registry mirror rules applied to the image paths, after the image overrides.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Registry Mirrors"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	RegistryMirrors []RegistryMirror `json:"registryMirrors,omitempty"`
+
+	// Pin the component images to digests (sha256:...) instead of tags, keyed by component name.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image Digests"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	ImageDigests map[string]string `json:"imageDigests,omitempty"`
+
+	// Override the resources and scheduling of the component pods, keyed by component name (e.g. submariner-gateway).
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Component Overrides"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	Components map[string]ComponentSpec `json:"components,omitempty"`
+
+	// How changes made outside the operator to the resources it manages are handled: AutoCorrect (the default)
+	// re-applies the desired state, ReportOnly only reports the drifted resources in the status.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Drift Policy"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +kubebuilder:validation:Enum=AutoCorrect;ReportOnly
+	// +optional
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// How the components are uninstalled when the Submariner resource is deleted.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Uninstall"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	Uninstall *UninstallSpec `json:"uninstall,omitempty"`
 }
 
 // SubmarinerStatus defines the observed state of Submariner.
@@ -244,21 +308,33 @@ type SubmarinerStatus struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	ClusterID string `json:"clusterID"`
 
-	// The current service CIDR.
+	// The current service CIDRs, comma-separated.
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Service CIDR"
 	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	ServiceCIDR string `json:"serviceCIDR,omitempty"`
 
-	// The current cluster CIDR.
+	// The current service CIDRs.
+	// +optional
+	ServiceCIDRs []string `json:"serviceCIDRs,omitempty"`
+
+	// The current cluster CIDRs, comma-separated.
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Cluster CIDR"
 	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	ClusterCIDR string `json:"clusterCIDR,omitempty"`
 
+	// The current cluster CIDRs.
+	// +optional
+	ClusterCIDRs []string `json:"clusterCIDRs,omitempty"`
+
 	// The current global CIDR.
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Global CIDR"
 	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	GlobalCIDR string `json:"globalCIDR,omitempty"`
 
+	// All the global CIDRs assigned to the cluster: the GlobalCIDR and the blocks it was expanded with.
+	// +optional
+	GlobalCIDRs []string `json:"globalCIDRs,omitempty"`
+
 	// The current clustersetIP CIDR.
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="ClustersetIP CIDR"
 	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
@@ -296,11 +372,34 @@ type SubmarinerStatus struct {
 	// The image version in use by the various Submariner DaemonSets and Deployments.
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Version"
 	Version string `json:"version,omitempty"`
+
+	// The generation of the Submariner resource most recently observed by the operator.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// The latest available observations of the Submariner deployment's state.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Conditions"
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// The managed resources whose live state differed from their desired state during the last reconcile.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Drift Detected"
+	// +optional
+	DriftDetected []DriftedResource `json:"driftDetected,omitempty"`
+
+	// The progress of the uninstall of the components, while the Submariner resource is being deleted.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Uninstall"
+	// +optional
+	Uninstall *UninstallStatus `json:"uninstall,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:path=submariners,scope=Namespaced
+//+kubebuilder:storageversion
 
 // Submariner is the Schema for the submariners API.
 // +operator-sdk:csv:customresourcedefinitions:displayName="Submariner",resources={{Deployment,v1,submariner-operator}}
@@ -362,6 +461,206 @@ type HealthCheckSpec struct {
 	MaxPacketLossCount uint64 `json:"maxPacketLossCount,omitempty"`
 }
 
+// RegistryMirror redirects the images under a registry or repository to a mirror.
+type RegistryMirror struct {
+	// The registry or repository prefix to replace, e.g. quay.io/submariner.
+	Source string `json:"source"`
+
+	// The replacement prefix, e.g. registry.corp/mirror.
+	Mirror string `json:"mirror"`
+}
+
+// ComponentSpec overrides the pod template of a component. The node selector, labels and annotations are merged into
+// the component's defaults, the other fields replace them when set.
+type ComponentSpec struct {
+	// The compute resources of the component's containers.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Additional node labels the component's pods must be scheduled on.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// The tolerations of the component's pods.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// The scheduling constraints of the component's pods, each affinity type replaces the default one.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// The priority class of the component's pods.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// Additional labels for the component's pods.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Additional annotations for the component's pods.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// DriftPolicy defines how changes made outside the operator to the resources it manages are handled.
+type DriftPolicy string
+
+const (
+	// DriftAutoCorrect re-applies the desired state of the drifted resources.
+	DriftAutoCorrect DriftPolicy = "AutoCorrect"
+	// DriftReportOnly leaves the drifted resources as they are and only reports them.
+	DriftReportOnly DriftPolicy = "ReportOnly"
+)
+
+// DriftedResource identifies a managed resource whose live state differs from its desired state.
+type DriftedResource struct {
+	Kind string `json:"kind"`
+
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	Name string `json:"name"`
+
+	// The paths of the fields which differ from the desired state.
+	// +optional
+	Fields []string `json:"fields,omitempty"`
+
+	// Whether the desired state was re-applied.
+	// +optional
+	Corrected bool `json:"corrected,omitempty"`
+}
+
+// UninstallTimeoutPolicy defines what happens when the uninstall of the components doesn't complete in time.
+type UninstallTimeoutPolicy string
+
+const (
+	// UninstallForceRemoveFinalizer gives up on the uninstall and removes the finalizer, leaving any remaining state behind.
+	UninstallForceRemoveFinalizer UninstallTimeoutPolicy = "ForceRemoveFinalizer"
+	// UninstallBlockUntilClean keeps waiting for the uninstall to complete successfully, the resource isn't deleted until then.
+	// The failed uninstall Jobs are kept; deleting them retries them.
+	UninstallBlockUntilClean UninstallTimeoutPolicy = "BlockUntilClean"
+)
+
+// UninstallMethod defines how the uninstall of the components is run.
+type UninstallMethod string
+
+const (
+	// UninstallWithDaemonSets runs the uninstall in single-use DaemonSets and Deployments.
+	UninstallWithDaemonSets UninstallMethod = "DaemonSet"
+	// UninstallWithJobs runs the uninstall in Jobs, one per node for the components deployed as DaemonSets.
+	UninstallWithJobs UninstallMethod = "Job"
+)
+
+// UninstallSpec defines how the components are uninstalled.
+type UninstallSpec struct {
+	// How the uninstall of the components is run: DaemonSet (the default) or Job.
+	// +kubebuilder:validation:Enum=DaemonSet;Job
+	// +optional
+	Method UninstallMethod `json:"method,omitempty"`
+
+	// How long to wait for the uninstall of the components to complete, 2 minutes by default.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// What to do when the timeout is reached or the uninstall of a component fails: ForceRemoveFinalizer (the default) or
+	// BlockUntilClean.
+	// +kubebuilder:validation:Enum=ForceRemoveFinalizer;BlockUntilClean
+	// +optional
+	TimeoutPolicy UninstallTimeoutPolicy `json:"timeoutPolicy,omitempty"`
+}
+
+// UninstallState is the uninstall step a component reached.
+type UninstallState string
+
+const (
+	UninstallDeletingComponent          UninstallState = "DeletingComponent"
+	UninstallAwaitingPodsDeleted        UninstallState = "AwaitingPodsDeleted"
+	UninstallCreatingUninstallComponent UninstallState = "CreatingUninstallComponent"
+	UninstallAwaitingUninstallComplete  UninstallState = "AwaitingUninstallComplete"
+	UninstallComplete                   UninstallState = "Complete"
+	UninstallFailed                     UninstallState = "Failed"
+)
+
+// UninstallJobState is the state of an uninstall Job.
+type UninstallJobState string
+
+const (
+	UninstallJobRunning   UninstallJobState = "Running"
+	UninstallJobSucceeded UninstallJobState = "Succeeded"
+	UninstallJobFailed    UninstallJobState = "Failed"
+)
+
+// UninstallStatus reports the progress of the uninstall of the components.
+type UninstallStatus struct {
+	// +optional
+	Components []ComponentUninstallStatus `json:"components,omitempty"`
+
+	// Whether the uninstall didn't complete in time.
+	// +optional
+	TimedOut bool `json:"timedOut,omitempty"`
+}
+
+// ComponentUninstallStatus reports the progress of the uninstall of a component.
+type ComponentUninstallStatus struct {
+	Name string `json:"name"`
+
+	State UninstallState `json:"state"`
+
+	// The nodes on which the component's uninstall pods aren't ready.
+	// +optional
+	PendingNodes []string `json:"pendingNodes,omitempty"`
+
+	// The uninstall Jobs, with the Job uninstall method.
+	// +optional
+	Jobs []UninstallJobStatus `json:"jobs,omitempty"`
+}
+
+// UninstallJobStatus reports the state of an uninstall Job.
+type UninstallJobStatus struct {
+	Name string `json:"name"`
+
+	// The node the Job is pinned to, if any.
+	// +optional
+	Node string `json:"node,omitempty"`
+
+	State UninstallJobState `json:"state"`
+
+	// The number of pods which ran to completion or failed.
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// The end of the log of the last failed pod, if the Job failed.
+	// +optional
+	Log string `json:"log,omitempty"`
+}
+
+// Condition types reported in the Submariner status.
+const (
+	// ReadyCondition is true when all the enabled Submariner components are deployed and ready.
+	ReadyCondition = "Ready"
+	// GatewayReadyCondition reflects the state of the gateway DaemonSet and its load balancer, if enabled.
+	GatewayReadyCondition = "GatewayReady"
+	// RouteAgentReadyCondition reflects the state of the route agent DaemonSet.
+	RouteAgentReadyCondition = "RouteAgentReady"
+	// GlobalnetReadyCondition reflects the state of the Globalnet DaemonSet. It is only present when Globalnet is enabled.
+	GlobalnetReadyCondition = "GlobalnetReady"
+	// BrokerConnectedCondition reflects whether the operator was able to connect to the broker.
+	BrokerConnectedCondition = "BrokerConnected"
+	// NetworkDiscoveredCondition reflects whether the cluster network was discovered.
+	NetworkDiscoveredCondition = "NetworkDiscovered"
+	// CIDRsDistinctCondition is true when the cluster's pod and service CIDRs don't overlap with the subnets of the other
+	// clusters connected to the broker. It is only present when Globalnet is disabled.
+	CIDRsDistinctCondition = "CIDRsDistinct"
+	// GlobalCIDRsExpandedCondition reflects whether the additional global CIDR blocks were allocated. It is only present
+	// when GlobalCIDRExpansions is set; until the blocks are allocated, the cluster keeps using its current global CIDRs.
+	GlobalCIDRsExpandedCondition = "GlobalCIDRsExpanded"
+	// PausedCondition is true when the reconciliation is paused. It is only present while paused.
+	PausedCondition = "Paused"
+)
+
+// PausedAnnotation pauses the reconciliation of the Submariner or ServiceDiscovery resource it is set on, when "true".
+const PausedAnnotation = "submariner.io/paused"
+
 type (
 	KubernetesType string
 	CloudProvider  string
@@ -379,6 +678,8 @@ const (
 	GCP                                  = "gcp"
 	Azure                                = "azure"
 	Openstack                            = "openstack"
+	IBMCloud                             = "ibm"
+	KubeVirt                             = "kubevirt"
 )
 
 func (s *Submariner) UnmarshalJSON(data []byte) error {
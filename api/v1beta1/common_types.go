@@ -0,0 +1,107 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// BrokerConnection defines how to connect to the broker.
+type BrokerConnection struct {
+	// The broker API URL.
+	APIServer string `json:"apiServer"`
+
+	// The broker API Token.
+	// +optional
+	APIServerToken string `json:"apiServerToken,omitempty"`
+
+	// The broker certificate authority.
+	// +optional
+	CA string `json:"ca,omitempty"`
+
+	// The name of the secret holding the broker credentials, if any.
+	// +optional
+	Secret string `json:"secret,omitempty"`
+
+	// The Broker namespace.
+	RemoteNamespace string `json:"remoteNamespace"`
+
+	// Skip the verification of the broker API server certificate.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+// SchedulingSpec defines where the components are scheduled.
+type SchedulingSpec struct {
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+type CoreDNSCustomConfig struct {
+	// Name of the custom CoreDNS configmap.
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// Namespace of the custom CoreDNS configmap.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ImagesSpec defines where the component images are pulled from.
+type ImagesSpec struct {
+	// Secrets used to pull the component images, attached to every pod managed by the operator.
+	// +optional
+	PullSecrets []corev1.LocalObjectReference `json:"pullSecrets,omitempty"`
+
+	// Registry mirror rules applied to the image paths, after the image overrides.
+	// +optional
+	RegistryMirrors []RegistryMirror `json:"registryMirrors,omitempty"`
+
+	// Pin the component images to digests (sha256:...) instead of tags, keyed by component name.
+	// +optional
+	Digests map[string]string `json:"digests,omitempty"`
+}
+
+// RegistryMirror redirects the images under a registry or repository to a mirror.
+type RegistryMirror struct {
+	// The registry or repository prefix to replace, e.g. quay.io/submariner.
+	Source string `json:"source"`
+
+	// The replacement prefix, e.g. registry.corp/mirror.
+	Mirror string `json:"mirror"`
+}
+
+// ComponentSpec overrides the pod template of a component. The node selector, labels and annotations are merged into
+// the component's defaults, the other fields replace them when set.
+type ComponentSpec struct {
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
@@ -0,0 +1,192 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Submariner conversion", func() {
+	hub := &v1alpha1.Submariner{
+		ObjectMeta: metav1.ObjectMeta{Name: "submariner", Namespace: "submariner-operator"},
+		Spec: v1alpha1.SubmarinerSpec{
+			Broker:                   "k8s",
+			BrokerK8sApiServer:       "https://broker:6443",
+			BrokerK8sApiServerToken:  "token",
+			BrokerK8sCA:              "ca",
+			BrokerK8sRemoteNamespace: "submariner-k8s-broker",
+			BrokerK8sInsecure:        true,
+			CableDriver:              "libreswan",
+			CeIPSecPSK:               "psk",
+			CeIPSecIKEPort:           500,
+			CeIPSecNATTPort:          4500,
+			CeIPSecDebug:             true,
+			CeIPSecForceUDPEncaps:    true,
+			ClusterCIDRs:             []string{"10.244.0.0/16", "10.245.0.0/16"},
+			ServiceCIDRs:             []string{"10.96.0.0/16"},
+			GlobalCIDR:               "242.0.0.0/16",
+			GlobalCIDRExpansions:     2,
+			ClusterID:                "east",
+			Namespace:                "submariner-operator",
+			Repository:               "quay.io/submariner",
+			Version:                  "devel",
+			NatEnabled:               true,
+			LoadBalancerEnabled:      true,
+			ServiceDiscoveryEnabled:  true,
+			ClustersetIPEnabled:      true,
+			ClustersetIPCIDR:         "243.0.0.0/20",
+			CoreDNSCustomConfig:      &v1alpha1.CoreDNSCustomConfig{ConfigMapName: "custom-coredns", Namespace: "kube-system"},
+			CustomDomains:            []string{"supercluster.local"},
+			ImageOverrides:           map[string]string{"submariner-gateway": "quay.io/custom/submariner-gateway:v1"},
+			ConnectionHealthCheck:    &v1alpha1.HealthCheckSpec{Enabled: true, IntervalSeconds: 1, MaxPacketLossCount: 5},
+			NodeSelector:             map[string]string{"node-role": "gateway"},
+			Tolerations:              []corev1.Toleration{{Key: "node-role", Operator: corev1.TolerationOpExists}},
+			ImagePullSecrets:         []corev1.LocalObjectReference{{Name: "corp-registry"}},
+			RegistryMirrors:          []v1alpha1.RegistryMirror{{Source: "quay.io/submariner", Mirror: "registry.corp/mirror"}},
+			ImageDigests:             map[string]string{"submariner-gateway": "sha256:abcd"},
+			Components: map[string]v1alpha1.ComponentSpec{
+				"submariner-gateway": {PriorityClassName: "system-node-critical", Labels: map[string]string{"team": "networking"}},
+			},
+			DriftPolicy: v1alpha1.DriftReportOnly,
+			Paused:      true,
+			Uninstall:   &v1alpha1.UninstallSpec{TimeoutPolicy: v1alpha1.UninstallBlockUntilClean},
+		},
+		Status: v1alpha1.SubmarinerStatus{ClusterID: "east", NetworkPlugin: "OVNKubernetes"},
+	}
+
+	When("a v1alpha1 Submariner is converted to v1beta1", func() {
+		It("should group the fields into the sub-objects", func() {
+			spoke := &v1beta1.Submariner{}
+			Expect(spoke.ConvertFrom(hub.DeepCopy())).To(Succeed())
+
+			Expect(spoke.Name).To(Equal(hub.Name))
+			Expect(spoke.Status).To(Equal(hub.Status))
+			Expect(spoke.Spec.Broker).To(Equal(v1beta1.BrokerConnection{
+				APIServer:       "https://broker:6443",
+				APIServerToken:  "token",
+				CA:              "ca",
+				RemoteNamespace: "submariner-k8s-broker",
+				Insecure:        true,
+			}))
+			Expect(spoke.Spec.Cable.Driver).To(Equal("libreswan"))
+			Expect(spoke.Spec.Cable.IPSec.NATTPort).To(Equal(4500))
+			Expect(spoke.Spec.Cable.ConnectionHealthCheck).To(Equal(&v1beta1.HealthCheckSpec{
+				Enabled: true, IntervalSeconds: 1, MaxPacketLossCount: 5,
+			}))
+			Expect(spoke.Spec.Networking.ClusterCIDRs).To(Equal([]string{"10.244.0.0/16", "10.245.0.0/16"}))
+			Expect(spoke.Spec.Networking.ServiceCIDRs).To(Equal([]string{"10.96.0.0/16"}))
+			Expect(spoke.Spec.Networking.GlobalCIDR).To(Equal("242.0.0.0/16"))
+			Expect(spoke.Spec.Networking.GlobalCIDRExpansions).To(Equal(uint(2)))
+			Expect(spoke.Spec.ServiceDiscovery.ClustersetIPCIDR).To(Equal("243.0.0.0/20"))
+			Expect(spoke.Spec.Scheduling.NodeSelector).To(Equal(hub.Spec.NodeSelector))
+		})
+
+		It("should convert back to the same v1alpha1 Submariner", func() {
+			spoke := &v1beta1.Submariner{}
+			Expect(spoke.ConvertFrom(hub.DeepCopy())).To(Succeed())
+
+			converted := &v1alpha1.Submariner{}
+			Expect(spoke.ConvertTo(converted)).To(Succeed())
+			Expect(converted).To(Equal(hub))
+		})
+	})
+
+	When("a v1alpha1 Submariner with the deprecated comma-separated CIDRs is converted to v1beta1", func() {
+		It("should convert them to the CIDR lists", func() {
+			deprecated := hub.DeepCopy()
+			deprecated.Spec.ClusterCIDRs = nil
+			deprecated.Spec.ClusterCIDR = "10.244.0.0/16, 10.245.0.0/16"
+
+			spoke := &v1beta1.Submariner{}
+			Expect(spoke.ConvertFrom(deprecated)).To(Succeed())
+			Expect(spoke.Spec.Networking.ClusterCIDRs).To(Equal([]string{"10.244.0.0/16", "10.245.0.0/16"}))
+		})
+	})
+
+	When("a v1alpha1 Submariner with another broker type is converted to v1beta1 and back", func() {
+		It("should preserve the broker type", func() {
+			for _, broker := range []string{"", "custom"} {
+				withBroker := hub.DeepCopy()
+				withBroker.Spec.Broker = broker
+
+				spoke := &v1beta1.Submariner{}
+				Expect(spoke.ConvertFrom(withBroker.DeepCopy())).To(Succeed())
+
+				converted := &v1alpha1.Submariner{}
+				Expect(spoke.ConvertTo(converted)).To(Succeed())
+				Expect(converted).To(Equal(withBroker))
+			}
+		})
+	})
+
+	When("a v1beta1 Submariner without a health check is converted to v1alpha1", func() {
+		It("should set the broker type and leave the health check unset", func() {
+			spoke := &v1beta1.Submariner{Spec: v1beta1.SubmarinerSpec{ClusterID: "west"}}
+
+			converted := &v1alpha1.Submariner{}
+			Expect(spoke.ConvertTo(converted)).To(Succeed())
+			Expect(converted.Spec.Broker).To(Equal("k8s"))
+			Expect(converted.Spec.ClusterID).To(Equal("west"))
+			Expect(converted.Spec.ConnectionHealthCheck).To(BeNil())
+			Expect(converted.Spec.CoreDNSCustomConfig).To(BeNil())
+		})
+	})
+})
+
+var _ = Describe("ServiceDiscovery conversion", func() {
+	hub := &v1alpha1.ServiceDiscovery{
+		ObjectMeta: metav1.ObjectMeta{Name: "service-discovery", Namespace: "submariner-operator"},
+		Spec: v1alpha1.ServiceDiscoverySpec{
+			BrokerK8sApiServer:       "https://broker:6443",
+			BrokerK8sApiServerToken:  "token",
+			BrokerK8sCA:              "ca",
+			BrokerK8sSecret:          "broker-secret",
+			BrokerK8sRemoteNamespace: "submariner-k8s-broker",
+			ClusterID:                "east",
+			Namespace:                "submariner-operator",
+			Repository:               "quay.io/submariner",
+			Version:                  "devel",
+			ClustersetIPCIDR:         "243.0.0.0/20",
+			GlobalnetEnabled:         true,
+			ClustersetIPEnabled:      true,
+			CoreDNSCustomConfig:      &v1alpha1.CoreDNSCustomConfig{ConfigMapName: "custom-coredns"},
+			CustomDomains:            []string{"supercluster.local"},
+			Tolerations:              []corev1.Toleration{{Key: "node-role", Operator: corev1.TolerationOpExists}},
+		},
+		Status: v1alpha1.ServiceDiscoveryStatus{DeploymentInfo: v1alpha1.DeploymentInfo{KubernetesType: v1alpha1.K8s}},
+	}
+
+	When("a v1alpha1 ServiceDiscovery is converted to v1beta1 and back", func() {
+		It("should move the broker fields and preserve all the fields", func() {
+			spoke := &v1beta1.ServiceDiscovery{}
+			Expect(spoke.ConvertFrom(hub.DeepCopy())).To(Succeed())
+			Expect(spoke.Spec.Broker.Secret).To(Equal("broker-secret"))
+			Expect(spoke.Spec.Scheduling.Tolerations).To(Equal(hub.Spec.Tolerations))
+
+			converted := &v1alpha1.ServiceDiscovery{}
+			Expect(spoke.ConvertTo(converted)).To(Succeed())
+			Expect(converted).To(Equal(hub))
+		})
+	})
+})
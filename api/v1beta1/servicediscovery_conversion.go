@@ -0,0 +1,111 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts this ServiceDiscovery to the v1alpha1 hub version.
+func (sd *ServiceDiscovery) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.ServiceDiscovery) //nolint:forcetypeassert // The hub type is fixed
+	src := sd.DeepCopy()
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Status = src.Status
+
+	dst.Spec = v1alpha1.ServiceDiscoverySpec{
+		BrokerK8sApiServer:       src.Spec.Broker.APIServer,
+		BrokerK8sApiServerToken:  src.Spec.Broker.APIServerToken,
+		BrokerK8sCA:              src.Spec.Broker.CA,
+		BrokerK8sSecret:          src.Spec.Broker.Secret,
+		BrokerK8sRemoteNamespace: src.Spec.Broker.RemoteNamespace,
+		BrokerK8sInsecure:        src.Spec.Broker.Insecure,
+		ClusterID:                src.Spec.ClusterID,
+		Namespace:                src.Spec.Namespace,
+		Repository:               src.Spec.Repository,
+		Version:                  src.Spec.Version,
+		ClustersetIPCIDR:         src.Spec.ClustersetIPCIDR,
+		Debug:                    src.Spec.Debug,
+		GlobalnetEnabled:         src.Spec.GlobalnetEnabled,
+		HaltOnCertificateError:   src.Spec.HaltOnCertificateError,
+		ClustersetIPEnabled:      src.Spec.ClustersetIPEnabled,
+		CoreDNSCustomConfig:      coreDNSCustomConfigToHub(src.Spec.CoreDNSCustomConfig),
+		CustomDomains:            src.Spec.CustomDomains,
+		ImageOverrides:           src.Spec.ImageOverrides,
+		ImagePullSecrets:         src.Spec.Images.PullSecrets,
+		RegistryMirrors:          registryMirrorsToHub(src.Spec.Images.RegistryMirrors),
+		ImageDigests:             src.Spec.Images.Digests,
+		NodeSelector:             src.Spec.Scheduling.NodeSelector,
+		Tolerations:              src.Spec.Scheduling.Tolerations,
+		Components:               componentsToHub(src.Spec.Components),
+		DriftPolicy:              src.Spec.DriftPolicy,
+		Uninstall:                src.Spec.Uninstall,
+		Paused:                   src.Spec.Paused,
+	}
+
+	return nil
+}
+
+// ConvertFrom converts the v1alpha1 hub version to this ServiceDiscovery.
+func (sd *ServiceDiscovery) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.ServiceDiscovery).DeepCopy() //nolint:forcetypeassert // The hub type is fixed
+
+	sd.ObjectMeta = src.ObjectMeta
+	sd.Status = src.Status
+
+	sd.Spec = ServiceDiscoverySpec{
+		ClusterID:      src.Spec.ClusterID,
+		Namespace:      src.Spec.Namespace,
+		Repository:     src.Spec.Repository,
+		Version:        src.Spec.Version,
+		ImageOverrides: src.Spec.ImageOverrides,
+		Images: ImagesSpec{
+			PullSecrets:     src.Spec.ImagePullSecrets,
+			RegistryMirrors: registryMirrorsFromHub(src.Spec.RegistryMirrors),
+			Digests:         src.Spec.ImageDigests,
+		},
+		Broker: BrokerConnection{
+			APIServer:       src.Spec.BrokerK8sApiServer,
+			APIServerToken:  src.Spec.BrokerK8sApiServerToken,
+			CA:              src.Spec.BrokerK8sCA,
+			Secret:          src.Spec.BrokerK8sSecret,
+			RemoteNamespace: src.Spec.BrokerK8sRemoteNamespace,
+			Insecure:        src.Spec.BrokerK8sInsecure,
+		},
+		ClustersetIPCIDR:    src.Spec.ClustersetIPCIDR,
+		CoreDNSCustomConfig: coreDNSCustomConfigFromHub(src.Spec.CoreDNSCustomConfig),
+		CustomDomains:       src.Spec.CustomDomains,
+		Scheduling: SchedulingSpec{
+			NodeSelector: src.Spec.NodeSelector,
+			Tolerations:  src.Spec.Tolerations,
+		},
+		Components:             componentsFromHub(src.Spec.Components),
+		DriftPolicy:            src.Spec.DriftPolicy,
+		Uninstall:              src.Spec.Uninstall,
+		Paused:                 src.Spec.Paused,
+		Debug:                  src.Spec.Debug,
+		GlobalnetEnabled:       src.Spec.GlobalnetEnabled,
+		HaltOnCertificateError: src.Spec.HaltOnCertificateError,
+		ClustersetIPEnabled:    src.Spec.ClustersetIPEnabled,
+	}
+
+	return nil
+}
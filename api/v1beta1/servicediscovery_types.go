@@ -0,0 +1,93 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServiceDiscoverySpec defines the desired state of ServiceDiscovery.
+type ServiceDiscoverySpec struct {
+	ClusterID string `json:"clusterID"`
+	Namespace string `json:"namespace"`
+	// +optional
+	Repository string `json:"repository,omitempty"`
+	// +optional
+	Version string `json:"version,omitempty"`
+	// +optional
+	ImageOverrides map[string]string `json:"imageOverrides,omitempty"`
+	// +optional
+	Images ImagesSpec `json:"images,omitempty"`
+	// The connection to the broker, shared with the Submariner resource.
+	Broker BrokerConnection `json:"broker"`
+	// +optional
+	ClustersetIPCIDR string `json:"clustersetIPCIDR,omitempty"`
+	// +optional
+	CoreDNSCustomConfig *CoreDNSCustomConfig `json:"coreDNSCustomConfig,omitempty"`
+	// +listType=set
+	// +optional
+	CustomDomains []string `json:"customDomains,omitempty"`
+	// +optional
+	Scheduling SchedulingSpec `json:"scheduling,omitempty"`
+	// +optional
+	Components map[string]ComponentSpec `json:"components,omitempty"`
+	// +kubebuilder:validation:Enum=AutoCorrect;ReportOnly
+	// +optional
+	DriftPolicy v1alpha1.DriftPolicy `json:"driftPolicy,omitempty"`
+	// +optional
+	Uninstall *v1alpha1.UninstallSpec `json:"uninstall,omitempty"`
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+	// +optional
+	Debug bool `json:"debug,omitempty"`
+	// +optional
+	GlobalnetEnabled bool `json:"globalnetEnabled,omitempty"`
+	// +optional
+	HaltOnCertificateError bool `json:"haltOnCertificateError,omitempty"`
+	// +optional
+	ClustersetIPEnabled bool `json:"clustersetIPEnabled,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:path=servicediscoveries,scope=Namespaced
+//+kubebuilder:unservedversion
+
+// ServiceDiscovery is the Schema for the servicediscoveries API. The status is unchanged from v1alpha1.
+type ServiceDiscovery struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ServiceDiscoverySpec            `json:"spec,omitempty"`
+	Status v1alpha1.ServiceDiscoveryStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ServiceDiscoveryList contains a list of ServiceDiscovery.
+type ServiceDiscoveryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServiceDiscovery `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ServiceDiscovery{}, &ServiceDiscoveryList{})
+}
@@ -0,0 +1,271 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+const (
+	// brokerType is the default broker type of v1alpha1; v1beta1 has no broker type field.
+	brokerType = "k8s"
+
+	// brokerTypeAnnotation records a v1alpha1 broker type other than the default so that it survives a round trip.
+	brokerTypeAnnotation = "submariner.io/v1alpha1-broker"
+)
+
+// ConvertTo converts this Submariner to the v1alpha1 hub version.
+func (s *Submariner) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.Submariner) //nolint:forcetypeassert // The hub type is fixed
+	src := s.DeepCopy()
+
+	broker := brokerType
+	if value, ok := src.Annotations[brokerTypeAnnotation]; ok {
+		broker = value
+
+		delete(src.Annotations, brokerTypeAnnotation)
+
+		if len(src.Annotations) == 0 {
+			src.Annotations = nil
+		}
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Status = src.Status
+
+	dst.Spec = v1alpha1.SubmarinerSpec{
+		Broker:                   broker,
+		BrokerK8sApiServer:       src.Spec.Broker.APIServer,
+		BrokerK8sApiServerToken:  src.Spec.Broker.APIServerToken,
+		BrokerK8sCA:              src.Spec.Broker.CA,
+		BrokerK8sSecret:          src.Spec.Broker.Secret,
+		BrokerK8sRemoteNamespace: src.Spec.Broker.RemoteNamespace,
+		BrokerK8sInsecure:        src.Spec.Broker.Insecure,
+		CableDriver:              src.Spec.Cable.Driver,
+		CeIPSecPSK:               src.Spec.Cable.IPSec.PSK,
+		CeIPSecPSKSecret:         src.Spec.Cable.IPSec.PSKSecret,
+		CeIPSecIKEPort:           src.Spec.Cable.IPSec.IKEPort,
+		CeIPSecNATTPort:          src.Spec.Cable.IPSec.NATTPort,
+		CeIPSecDebug:             src.Spec.Cable.IPSec.Debug,
+		CeIPSecPreferredServer:   src.Spec.Cable.IPSec.PreferredServer,
+		CeIPSecForceUDPEncaps:    src.Spec.Cable.IPSec.ForceUDPEncaps,
+		NatEnabled:               src.Spec.Cable.NATEnabled,
+		LoadBalancerEnabled:      src.Spec.Cable.LoadBalancerEnabled,
+		ClusterCIDRs:             src.Spec.Networking.ClusterCIDRs,
+		ServiceCIDRs:             src.Spec.Networking.ServiceCIDRs,
+		GlobalCIDR:               src.Spec.Networking.GlobalCIDR,
+		GlobalCIDRExpansions:     src.Spec.Networking.GlobalCIDRExpansions,
+		ServiceDiscoveryEnabled:  src.Spec.ServiceDiscovery.Enabled,
+		ClustersetIPEnabled:      src.Spec.ServiceDiscovery.ClustersetIPEnabled,
+		ClustersetIPCIDR:         src.Spec.ServiceDiscovery.ClustersetIPCIDR,
+		CoreDNSCustomConfig:      coreDNSCustomConfigToHub(src.Spec.ServiceDiscovery.CoreDNSCustomConfig),
+		CustomDomains:            src.Spec.ServiceDiscovery.CustomDomains,
+		NodeSelector:             src.Spec.Scheduling.NodeSelector,
+		Tolerations:              src.Spec.Scheduling.Tolerations,
+		Components:               componentsToHub(src.Spec.Components),
+		DriftPolicy:              src.Spec.DriftPolicy,
+		Uninstall:                src.Spec.Uninstall,
+		Paused:                   src.Spec.Paused,
+		ClusterID:                src.Spec.ClusterID,
+		Namespace:                src.Spec.Namespace,
+		Repository:               src.Spec.Repository,
+		Version:                  src.Spec.Version,
+		ColorCodes:               src.Spec.ColorCodes,
+		ImageOverrides:           src.Spec.ImageOverrides,
+		ImagePullSecrets:         src.Spec.Images.PullSecrets,
+		RegistryMirrors:          registryMirrorsToHub(src.Spec.Images.RegistryMirrors),
+		ImageDigests:             src.Spec.Images.Digests,
+		Debug:                    src.Spec.Debug,
+		AirGappedDeployment:      src.Spec.AirGappedDeployment,
+		HostedCluster:            src.Spec.HostedCluster,
+		HaltOnCertificateError:   src.Spec.HaltOnCertificateError,
+	}
+
+	if src.Spec.Cable.ConnectionHealthCheck != nil {
+		dst.Spec.ConnectionHealthCheck = &v1alpha1.HealthCheckSpec{
+			Enabled:            src.Spec.Cable.ConnectionHealthCheck.Enabled,
+			IntervalSeconds:    src.Spec.Cable.ConnectionHealthCheck.IntervalSeconds,
+			MaxPacketLossCount: src.Spec.Cable.ConnectionHealthCheck.MaxPacketLossCount,
+		}
+	}
+
+	return nil
+}
+
+// ConvertFrom converts the v1alpha1 hub version to this Submariner.
+func (s *Submariner) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.Submariner).DeepCopy() //nolint:forcetypeassert // The hub type is fixed
+
+	s.ObjectMeta = src.ObjectMeta
+	s.Status = src.Status
+
+	if src.Spec.Broker != brokerType {
+		if s.Annotations == nil {
+			s.Annotations = map[string]string{}
+		}
+
+		s.Annotations[brokerTypeAnnotation] = src.Spec.Broker
+	}
+
+	s.Spec = SubmarinerSpec{
+		ClusterID:      src.Spec.ClusterID,
+		Namespace:      src.Spec.Namespace,
+		Repository:     src.Spec.Repository,
+		Version:        src.Spec.Version,
+		ColorCodes:     src.Spec.ColorCodes,
+		ImageOverrides: src.Spec.ImageOverrides,
+		Images: ImagesSpec{
+			PullSecrets:     src.Spec.ImagePullSecrets,
+			RegistryMirrors: registryMirrorsFromHub(src.Spec.RegistryMirrors),
+			Digests:         src.Spec.ImageDigests,
+		},
+		Broker: BrokerConnection{
+			APIServer:       src.Spec.BrokerK8sApiServer,
+			APIServerToken:  src.Spec.BrokerK8sApiServerToken,
+			CA:              src.Spec.BrokerK8sCA,
+			Secret:          src.Spec.BrokerK8sSecret,
+			RemoteNamespace: src.Spec.BrokerK8sRemoteNamespace,
+			Insecure:        src.Spec.BrokerK8sInsecure,
+		},
+		Cable: CableSpec{
+			Driver: src.Spec.CableDriver,
+			IPSec: IPSecSpec{
+				PSK:             src.Spec.CeIPSecPSK,
+				PSKSecret:       src.Spec.CeIPSecPSKSecret,
+				IKEPort:         src.Spec.CeIPSecIKEPort,
+				NATTPort:        src.Spec.CeIPSecNATTPort,
+				Debug:           src.Spec.CeIPSecDebug,
+				PreferredServer: src.Spec.CeIPSecPreferredServer,
+				ForceUDPEncaps:  src.Spec.CeIPSecForceUDPEncaps,
+			},
+			NATEnabled:          src.Spec.NatEnabled,
+			LoadBalancerEnabled: src.Spec.LoadBalancerEnabled,
+		},
+		Networking: NetworkingSpec{
+			ClusterCIDRs:         src.Spec.GetClusterCIDRs(),
+			ServiceCIDRs:         src.Spec.GetServiceCIDRs(),
+			GlobalCIDR:           src.Spec.GlobalCIDR,
+			GlobalCIDRExpansions: src.Spec.GlobalCIDRExpansions,
+		},
+		ServiceDiscovery: ServiceDiscoveryConfig{
+			Enabled:             src.Spec.ServiceDiscoveryEnabled,
+			ClustersetIPEnabled: src.Spec.ClustersetIPEnabled,
+			ClustersetIPCIDR:    src.Spec.ClustersetIPCIDR,
+			CoreDNSCustomConfig: coreDNSCustomConfigFromHub(src.Spec.CoreDNSCustomConfig),
+			CustomDomains:       src.Spec.CustomDomains,
+		},
+		Scheduling: SchedulingSpec{
+			NodeSelector: src.Spec.NodeSelector,
+			Tolerations:  src.Spec.Tolerations,
+		},
+		Components:             componentsFromHub(src.Spec.Components),
+		DriftPolicy:            src.Spec.DriftPolicy,
+		Uninstall:              src.Spec.Uninstall,
+		Paused:                 src.Spec.Paused,
+		Debug:                  src.Spec.Debug,
+		AirGappedDeployment:    src.Spec.AirGappedDeployment,
+		HostedCluster:          src.Spec.HostedCluster,
+		HaltOnCertificateError: src.Spec.HaltOnCertificateError,
+	}
+
+	if src.Spec.ConnectionHealthCheck != nil {
+		s.Spec.Cable.ConnectionHealthCheck = &HealthCheckSpec{
+			Enabled:            src.Spec.ConnectionHealthCheck.Enabled,
+			IntervalSeconds:    src.Spec.ConnectionHealthCheck.IntervalSeconds,
+			MaxPacketLossCount: src.Spec.ConnectionHealthCheck.MaxPacketLossCount,
+		}
+	}
+
+	return nil
+}
+
+func coreDNSCustomConfigToHub(config *CoreDNSCustomConfig) *v1alpha1.CoreDNSCustomConfig {
+	if config == nil {
+		return nil
+	}
+
+	return &v1alpha1.CoreDNSCustomConfig{
+		ConfigMapName: config.ConfigMapName,
+		Namespace:     config.Namespace,
+	}
+}
+
+func coreDNSCustomConfigFromHub(config *v1alpha1.CoreDNSCustomConfig) *CoreDNSCustomConfig {
+	if config == nil {
+		return nil
+	}
+
+	return &CoreDNSCustomConfig{
+		ConfigMapName: config.ConfigMapName,
+		Namespace:     config.Namespace,
+	}
+}
+
+func componentsToHub(components map[string]ComponentSpec) map[string]v1alpha1.ComponentSpec {
+	if components == nil {
+		return nil
+	}
+
+	converted := make(map[string]v1alpha1.ComponentSpec, len(components))
+	for name, component := range components {
+		converted[name] = v1alpha1.ComponentSpec(component)
+	}
+
+	return converted
+}
+
+func componentsFromHub(components map[string]v1alpha1.ComponentSpec) map[string]ComponentSpec {
+	if components == nil {
+		return nil
+	}
+
+	converted := make(map[string]ComponentSpec, len(components))
+	for name, component := range components {
+		converted[name] = ComponentSpec(component)
+	}
+
+	return converted
+}
+
+func registryMirrorsToHub(mirrors []RegistryMirror) []v1alpha1.RegistryMirror {
+	if mirrors == nil {
+		return nil
+	}
+
+	converted := make([]v1alpha1.RegistryMirror, len(mirrors))
+	for i := range mirrors {
+		converted[i] = v1alpha1.RegistryMirror(mirrors[i])
+	}
+
+	return converted
+}
+
+func registryMirrorsFromHub(mirrors []v1alpha1.RegistryMirror) []RegistryMirror {
+	if mirrors == nil {
+		return nil
+	}
+
+	converted := make([]RegistryMirror, len(mirrors))
+	for i := range mirrors {
+		converted[i] = RegistryMirror(mirrors[i])
+	}
+
+	return converted
+}
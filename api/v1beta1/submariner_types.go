@@ -0,0 +1,243 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SubmarinerSpec defines the desired state of Submariner.
+type SubmarinerSpec struct {
+	// The cluster ID used to identify the tunnels.
+	ClusterID string `json:"clusterID"`
+
+	// The namespace in which to deploy the submariner operator.
+	Namespace string `json:"namespace"`
+
+	// The image repository.
+	// +optional
+	Repository string `json:"repository,omitempty"`
+
+	// The image tag.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// +optional
+	ColorCodes string `json:"colorCodes,omitempty"`
+
+	// Override component images.
+	// +optional
+	ImageOverrides map[string]string `json:"imageOverrides,omitempty"`
+
+	// Where the component images are pulled from.
+	// +optional
+	Images ImagesSpec `json:"images,omitempty"`
+
+	// The connection to the broker.
+	Broker BrokerConnection `json:"broker"`
+
+	// The configuration of the inter-cluster data path.
+	// +optional
+	Cable CableSpec `json:"cable,omitempty"`
+
+	// The cluster networks.
+	// +optional
+	Networking NetworkingSpec `json:"networking,omitempty"`
+
+	// The configuration of Service Discovery (Lighthouse).
+	// +optional
+	ServiceDiscovery ServiceDiscoveryConfig `json:"serviceDiscovery,omitempty"`
+
+	// Where the Submariner components are scheduled.
+	// +optional
+	Scheduling SchedulingSpec `json:"scheduling,omitempty"`
+
+	// Override the resources and scheduling of the component pods, keyed by component name (e.g. submariner-gateway).
+	// +optional
+	Components map[string]ComponentSpec `json:"components,omitempty"`
+
+	// How changes made outside the operator to the resources it manages are handled: AutoCorrect (the default)
+	// re-applies the desired state, ReportOnly only reports the drifted resources in the status.
+	// +kubebuilder:validation:Enum=AutoCorrect;ReportOnly
+	// +optional
+	DriftPolicy v1alpha1.DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// How the components are uninstalled when the resource is deleted.
+	// +optional
+	Uninstall *v1alpha1.UninstallSpec `json:"uninstall,omitempty"`
+
+	// Pause the reconciliation: the status is still refreshed but the managed resources and the DNS configuration are
+	// left as they are.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// Enable operator debugging.
+	// +optional
+	Debug bool `json:"debug,omitempty"`
+
+	// +optional
+	AirGappedDeployment bool `json:"airGappedDeployment,omitempty"`
+
+	// Is the cluster a hosted cluster.
+	// +optional
+	HostedCluster bool `json:"hostedCluster,omitempty"`
+
+	// Halt on certificate error (so the pod gets restarted).
+	// +optional
+	HaltOnCertificateError bool `json:"haltOnCertificateError,omitempty"`
+}
+
+// CableSpec defines the inter-cluster connections established by the gateways.
+type CableSpec struct {
+	// Cable driver implementation - any of [libreswan, wireguard, vxlan].
+	// +optional
+	Driver string `json:"driver,omitempty"`
+
+	// The IPsec settings, used by the libreswan cable driver.
+	// +optional
+	IPSec IPSecSpec `json:"ipsec,omitempty"`
+
+	// The gateway connection health check.
+	// +optional
+	ConnectionHealthCheck *HealthCheckSpec `json:"connectionHealthCheck,omitempty"`
+
+	// Enable NAT between clusters.
+	// +optional
+	NATEnabled bool `json:"natEnabled,omitempty"`
+
+	// Enable automatic Load Balancer in front of the gateways.
+	// +optional
+	LoadBalancerEnabled bool `json:"loadBalancerEnabled,omitempty"`
+}
+
+type IPSecSpec struct {
+	// The IPsec Pre-Shared Key which must be identical in all route agents across the cluster.
+	// +optional
+	PSK string `json:"psk,omitempty"`
+
+	// The name of the secret holding the IPsec Pre-Shared Key, if any.
+	// +optional
+	PSKSecret string `json:"pskSecret,omitempty"`
+
+	// The IPsec IKE port (500 usually).
+	// +optional
+	IKEPort int `json:"ikePort,omitempty"`
+
+	// The IPsec NAT traversal port (4500 usually).
+	// +optional
+	NATTPort int `json:"nattPort,omitempty"`
+
+	// Enable logging IPsec debugging information.
+	// +optional
+	Debug bool `json:"debug,omitempty"`
+
+	// Enable this cluster as a preferred server for data-plane connections.
+	// +optional
+	PreferredServer bool `json:"preferredServer,omitempty"`
+
+	// Force UDP encapsulation for IPsec.
+	// +optional
+	ForceUDPEncaps bool `json:"forceUDPEncaps,omitempty"`
+}
+
+type HealthCheckSpec struct {
+	// Enable the connection health check.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// The interval at which health check pings are sent.
+	IntervalSeconds uint64 `json:"intervalSeconds,omitempty"`
+
+	// The maximum number of packets lost at which the health checker will mark the connection as down.
+	MaxPacketLossCount uint64 `json:"maxPacketLossCount,omitempty"`
+}
+
+// NetworkingSpec defines the cluster networks. The CIDRs are discovered when left empty.
+type NetworkingSpec struct {
+	// The cluster CIDRs, e.g. the pod networks or IP pools of the CNI.
+	// +optional
+	ClusterCIDRs []string `json:"clusterCIDRs,omitempty"`
+
+	// The service CIDRs.
+	// +optional
+	ServiceCIDRs []string `json:"serviceCIDRs,omitempty"`
+
+	// The Global CIDR super-net range for allocating GlobalCIDRs to each cluster. On dual-stack clusters, an IPv4 and an
+	// IPv6 CIDR may be specified, comma-separated.
+	// +optional
+	GlobalCIDR string `json:"globalCIDR,omitempty"`
+
+	// The number of additional blocks, of the broker's default Globalnet cluster size, allocated to the cluster per IP
+	// family to expand the GlobalCIDR when it runs out of global IPs. They aren't released if the number is decreased.
+	// +kubebuilder:validation:Maximum=15
+	// +optional
+	GlobalCIDRExpansions uint `json:"globalCIDRExpansions,omitempty"`
+}
+
+// ServiceDiscoveryConfig defines the Service Discovery settings of a Submariner deployment.
+type ServiceDiscoveryConfig struct {
+	// Enable support for Service Discovery (Lighthouse).
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Enable ClustersetIP default for services exported on this cluster.
+	// +optional
+	ClustersetIPEnabled bool `json:"clustersetIPEnabled,omitempty"`
+
+	// ClustersetIP CIDR for allocating ClustersetIPs to exported services. On dual-stack clusters, an IPv4 and an IPv6
+	// CIDR may be specified, comma-separated.
+	// +optional
+	ClustersetIPCIDR string `json:"clustersetIPCIDR,omitempty"`
+
+	// Name of the custom CoreDNS configmap to configure forwarding to Lighthouse.
+	// +optional
+	CoreDNSCustomConfig *CoreDNSCustomConfig `json:"coreDNSCustomConfig,omitempty"`
+
+	// List of domains to use for multi-cluster service discovery.
+	// +listType=set
+	// +optional
+	CustomDomains []string `json:"customDomains,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:path=submariners,scope=Namespaced
+//+kubebuilder:unservedversion
+
+// Submariner is the Schema for the submariners API. The status is unchanged from v1alpha1.
+type Submariner struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SubmarinerSpec            `json:"spec,omitempty"`
+	Status v1alpha1.SubmarinerStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// SubmarinerList contains a list of Submariner.
+type SubmarinerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Submariner `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Submariner{}, &SubmarinerList{})
+}
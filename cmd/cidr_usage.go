@@ -0,0 +1,141 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/pkg/cidr"
+	"github.com/submariner-io/submariner-operator/pkg/discovery/clustersetip"
+	"github.com/submariner-io/submariner-operator/pkg/discovery/globalnet"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const cidrUsageCommand = "cidr-usage"
+
+type cidrUsageOptions struct {
+	namespace      string
+	allocationSize uint
+}
+
+// runCIDRUsage prints how the broker's Globalnet and ClustersetIP ranges are used: the allocated and free blocks, the
+// largest free block and how many more clusters would fit. The broker is accessed using the current kubeconfig.
+func runCIDRUsage(ctx context.Context, args []string, out io.Writer) error {
+	options := cidrUsageOptions{}
+
+	flags := flag.NewFlagSet(cidrUsageCommand, flag.ContinueOnError)
+	flags.StringVar(&options.namespace, "namespace", "submariner-k8s-broker", "The namespace of the broker")
+	flags.UintVar(&options.allocationSize, "allocation-size", 0,
+		"The number of addresses of the additional allocations to count, the broker's default if not set")
+
+	if err := flags.Parse(args); err != nil {
+		return err //nolint:wrapcheck // No need to wrap
+	}
+
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return errors.Wrap(err, "error retrieving the kubeconfig")
+	}
+
+	brokerClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return errors.Wrap(err, "error creating the broker client")
+	}
+
+	globalnetInfo, _, err := globalnet.GetGlobalNetworks(ctx, brokerClient, options.namespace)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err //nolint:wrapcheck // Errors are already wrapped
+	}
+
+	if globalnetInfo != nil {
+		err = writeCIDRUsage(out, "Globalnet", globalnetInfo.Enabled, &globalnetInfo.Info, globalnetInfo.IPv6CIDR,
+			globalnetInfo.IPv6AllocationSize, &options)
+		if err != nil {
+			return err
+		}
+	}
+
+	clustersetIPInfo, _, err := clustersetip.GetClustersetIPNetworks(ctx, brokerClient, options.namespace)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err //nolint:wrapcheck // Errors are already wrapped
+	}
+
+	if clustersetIPInfo != nil {
+		return writeCIDRUsage(out, "ClustersetIP", clustersetIPInfo.Enabled, &clustersetIPInfo.Info, clustersetIPInfo.IPv6CIDR,
+			clustersetIPInfo.IPv6AllocationSize, &options)
+	}
+
+	return nil
+}
+
+func writeCIDRUsage(out io.Writer, pool string, enabled bool, info *cidr.Info, ipv6CIDR string, ipv6AllocationSize uint,
+	options *cidrUsageOptions,
+) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(w, "%s (enabled: %t)\n", pool, enabled)
+
+	for _, familyInfo := range []*cidr.Info{
+		{CIDR: info.CIDR, AllocationSize: info.AllocationSize, Clusters: info.Clusters},
+		{CIDR: ipv6CIDR, AllocationSize: ipv6AllocationSize, Clusters: info.Clusters},
+	} {
+		if familyInfo.CIDR == "" {
+			continue
+		}
+
+		if options.allocationSize != 0 {
+			familyInfo.AllocationSize = options.allocationSize
+		}
+
+		usage, err := cidr.Usage(familyInfo)
+		if err != nil {
+			return errors.Wrapf(err, "error computing the %s usage of %q", pool, familyInfo.CIDR)
+		}
+
+		freeAddresses, freeAllocations, err := cidr.Capacity(familyInfo)
+		if err != nil {
+			return errors.Wrapf(err, "error computing the %s capacity of %q", pool, familyInfo.CIDR)
+		}
+
+		fmt.Fprintf(w, "  Range:\t%s\n", familyInfo.CIDR)
+		fmt.Fprintf(w, "  Allocated blocks:\t%s\n", orNone(strings.Join(usage.Allocated, ", ")))
+		fmt.Fprintf(w, "  Free blocks:\t%s\n", orNone(strings.Join(usage.Free, ", ")))
+		fmt.Fprintf(w, "  Largest free block:\t%s\n", orNone(usage.LargestFree))
+		fmt.Fprintf(w, "  Free addresses:\t%d\n", freeAddresses)
+		fmt.Fprintf(w, "  Free allocations of %d addresses:\t%d\n", familyInfo.AllocationSize, freeAllocations)
+	}
+
+	return errors.Wrap(w.Flush(), "error writing the CIDR usage")
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+
+	return value
+}
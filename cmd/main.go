@@ -19,6 +19,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
@@ -29,9 +30,12 @@ import (
 	"github.com/submariner-io/admiral/pkg/names"
 	admversion "github.com/submariner-io/admiral/pkg/version"
 	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/api/v1beta1"
 	"github.com/submariner-io/submariner-operator/internal/controllers/metrics"
 	"github.com/submariner-io/submariner-operator/internal/controllers/servicediscovery"
 	"github.com/submariner-io/submariner-operator/internal/controllers/submariner"
+	"github.com/submariner-io/submariner-operator/internal/controllers/uninstall"
+	"github.com/submariner-io/submariner-operator/internal/webhook"
 	"github.com/submariner-io/submariner-operator/pkg/crd"
 	"github.com/submariner-io/submariner-operator/pkg/gateway"
 	"github.com/submariner-io/submariner-operator/pkg/lighthouse"
@@ -73,10 +77,40 @@ func printVersion() {
 func init() {
 	flag.BoolVar(&help, "help", help, "Print usage options")
 	flag.BoolVar(&showVersion, "version", showVersion, "Show version")
+
+	// Setup Scheme for all resources
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	utilruntime.Must(v1beta1.AddToScheme(scheme))
+	// These are required so that we can manipulate CRDs
+	utilruntime.Must(apiextensions.AddToScheme(scheme))
+	// These are required so that we can retrieve Gateway objects using the dynamic client
+	utilruntime.Must(submv1.AddToScheme(scheme))
+	// These are required so that we can retrieve OCP infrastructure objects using the dynamic client
+	utilruntime.Must(configv1.Install(scheme))
+	// +kubebuilder:scaffold:scheme
 }
 
 //nolint:gocyclo // No further refactors necessary
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == renderCommand {
+		if err := runRender(os.Args[2:], os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == cidrUsageCommand {
+		if err := runCIDRUsage(context.Background(), os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
 	var enableLeaderElection bool
 	var probeAddr string
 	var pprofAddr string
@@ -140,17 +174,6 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Setup Scheme for all resources
-	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
-	utilruntime.Must(v1alpha1.AddToScheme(scheme))
-	// These are required so that we can manipulate CRDs
-	utilruntime.Must(apiextensions.AddToScheme(scheme))
-	// These are required so that we can retrieve Gateway objects using the dynamic client
-	utilruntime.Must(submv1.AddToScheme(scheme))
-	// These are required so that we can retrieve OCP infrastructure objects using the dynamic client
-	utilruntime.Must(configv1.Install(scheme))
-	// +kubebuilder:scaffold:scheme
-
 	// Create a new Cmd to provide shared dependencies and start components
 	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
 		Scheme: scheme,
@@ -200,8 +223,9 @@ func main() {
 
 	// Setup all Controllers
 	if err = (&submariner.BrokerReconciler{
-		Client: mgr.GetClient(),
-		Config: mgr.GetConfig(),
+		Client:   mgr.GetClient(),
+		Config:   mgr.GetConfig(),
+		Recorder: mgr.GetEventRecorderFor("broker-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		log.Error(err, "unable to create controller", "controller", "Broker")
 		os.Exit(1)
@@ -211,12 +235,20 @@ func main() {
 		Scheme: scheme,
 	})
 
+	podLogs, err := uninstall.NewPodLogReader(mgr.GetConfig())
+	if err != nil {
+		log.Error(err, "Error creating the pod log reader")
+		os.Exit(1)
+	}
+
 	if err = submariner.NewReconciler(&submariner.Config{
 		ScopedClient:  mgr.GetClient(),
 		GeneralClient: generalClient,
 		RestConfig:    mgr.GetConfig(),
 		Scheme:        mgr.GetScheme(),
 		DynClient:     dynamic.NewForConfigOrDie(mgr.GetConfig()),
+		EventRecorder: mgr.GetEventRecorderFor("submariner-controller"),
+		PodLogs:       podLogs,
 	}).SetupWithManager(mgr); err != nil {
 		log.Error(err, "unable to create controller", "controller", "Submariner")
 		os.Exit(1)
@@ -227,11 +259,21 @@ func main() {
 		GeneralClient: generalClient,
 		Scheme:        mgr.GetScheme(),
 		RestConfig:    mgr.GetConfig(),
+		EventRecorder: mgr.GetEventRecorderFor("servicediscovery-controller"),
+		PodLogs:       podLogs,
 	}).SetupWithManager(mgr); err != nil {
 		log.Error(err, "unable to create controller", "controller", "ServiceDiscovery")
 		os.Exit(1)
 	}
 
+	// The webhooks require a serving certificate so they're only enabled on request, see config/default/manager_webhook_patch.yaml
+	if os.Getenv("ENABLE_WEBHOOKS") == "true" {
+		if err = webhook.SetupWithManager(mgr); err != nil {
+			log.Error(err, "unable to create the webhooks")
+			os.Exit(1)
+		}
+	}
+
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -0,0 +1,266 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/log/kzerolog"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/api/v1beta1"
+	"github.com/submariner-io/submariner-operator/internal/controllers/servicediscovery"
+	"github.com/submariner-io/submariner-operator/internal/controllers/submariner"
+	"github.com/submariner-io/submariner-operator/pkg/discovery/network"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+)
+
+const renderCommand = "render"
+
+type renderOptions struct {
+	file           string
+	namespace      string
+	clusterCIDRs   string
+	serviceCIDRs   string
+	networkPlugin  string
+	cloudProvider  string
+	kubernetesType string
+}
+
+// runRender reads Submariner and ServiceDiscovery resources and prints the resources the operator deploys for them as
+// multi-document YAML, without contacting an apiserver. The network details and the deployment info recorded in the
+// status of a Submariner resource are used unless explicitly supplied.
+func runRender(args []string, stdin io.Reader, out io.Writer) error {
+	options := renderOptions{}
+
+	flags := flag.NewFlagSet(renderCommand, flag.ContinueOnError)
+	flags.StringVar(&options.file, "f", "-", "The file containing the Submariner and ServiceDiscovery resources, - for stdin")
+	flags.StringVar(&options.namespace, "namespace", "submariner-operator", "The namespace of resources which don't specify one")
+	flags.StringVar(&options.clusterCIDRs, "cluster-cidrs", "", "Comma-separated pod CIDRs detected in the cluster")
+	flags.StringVar(&options.serviceCIDRs, "service-cidrs", "", "Comma-separated service CIDRs detected in the cluster")
+	flags.StringVar(&options.networkPlugin, "network-plugin", "", "The network plugin detected in the cluster")
+	flags.StringVar(&options.cloudProvider, "cloud-provider", "", "The cloud provider the cluster runs on")
+	flags.StringVar(&options.kubernetesType, "kubernetes-type", "", "The Kubernetes distribution of the cluster")
+
+	if err := flags.Parse(args); err != nil {
+		return err //nolint:wrapcheck // No need to wrap
+	}
+
+	kzerolog.InitK8sLogging()
+
+	input := stdin
+
+	if options.file != "-" {
+		file, err := os.Open(options.file)
+		if err != nil {
+			return errors.Wrapf(err, "error opening %q", options.file)
+		}
+
+		defer file.Close()
+
+		input = file
+	}
+
+	instances, err := readResources(input)
+	if err != nil {
+		return err
+	}
+
+	for _, instance := range instances {
+		if instance.GetNamespace() == "" {
+			instance.SetNamespace(options.namespace)
+		}
+
+		objs, err := renderResource(instance, &options)
+		if err != nil {
+			return err
+		}
+
+		if err := writeResources(out, objs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readResources decodes the Submariner and ServiceDiscovery resources in the given multi-document YAML, converting
+// them to v1alpha1 if necessary.
+func readResources(input io.Reader) ([]client.Object, error) {
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(input))
+
+	var instances []client.Object
+
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return instances, nil
+		}
+
+		if err != nil {
+			return nil, errors.Wrap(err, "error reading the resources")
+		}
+
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		obj, _, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "error decoding the resource")
+		}
+
+		var instance client.Object
+
+		switch o := obj.(type) {
+		case *v1alpha1.Submariner, *v1alpha1.ServiceDiscovery:
+			instance = o.(client.Object)
+		case *v1beta1.Submariner:
+			hub := &v1alpha1.Submariner{}
+			err = o.ConvertTo(hub)
+			instance = hub
+		case *v1beta1.ServiceDiscovery:
+			hub := &v1alpha1.ServiceDiscovery{}
+			err = o.ConvertTo(hub)
+			instance = hub
+		default:
+			return nil, fmt.Errorf("unsupported resource %s", obj.GetObjectKind().GroupVersionKind())
+		}
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "error converting %s", obj.GetObjectKind().GroupVersionKind())
+		}
+
+		instances = append(instances, instance)
+	}
+}
+
+func renderResource(instance client.Object, options *renderOptions) ([]client.Object, error) {
+	sd, ok := instance.(*v1alpha1.ServiceDiscovery)
+	if ok {
+		return servicediscovery.Render(sd, scheme) //nolint:wrapcheck // No need to wrap
+	}
+
+	s := instance.(*v1alpha1.Submariner)
+
+	deploymentInfo := s.Status.DeploymentInfo
+	if options.cloudProvider != "" {
+		deploymentInfo.CloudProvider = v1alpha1.CloudProvider(options.cloudProvider)
+	}
+
+	if options.kubernetesType != "" {
+		deploymentInfo.KubernetesType = v1alpha1.KubernetesType(options.kubernetesType)
+	}
+
+	objs, err := submariner.Render(s, clusterNetworkFor(s, options), &deploymentInfo, scheme)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // No need to wrap
+	}
+
+	// The ServiceDiscovery resource created for the Submariner resource results in further resources
+	for _, obj := range objs {
+		if sd, ok := obj.(*v1alpha1.ServiceDiscovery); ok {
+			sdObjs, err := servicediscovery.Render(sd, scheme)
+			if err != nil {
+				return nil, err //nolint:wrapcheck // No need to wrap
+			}
+
+			objs = append(objs, sdObjs...)
+		}
+	}
+
+	return objs, nil
+}
+
+// clusterNetworkFor returns the cluster network supplied in the options, falling back to the network details recorded
+// in the status of the given Submariner resource.
+func clusterNetworkFor(s *v1alpha1.Submariner, options *renderOptions) *network.ClusterNetwork {
+	clusterNetwork := &network.ClusterNetwork{
+		NetworkPlugin: s.Status.NetworkPlugin,
+		PodCIDRs:      s.Status.ClusterCIDRs,
+		ServiceCIDRs:  s.Status.ServiceCIDRs,
+	}
+
+	if len(clusterNetwork.PodCIDRs) == 0 {
+		clusterNetwork.PodCIDRs = splitCIDRs(s.Status.ClusterCIDR)
+	}
+
+	if len(clusterNetwork.ServiceCIDRs) == 0 {
+		clusterNetwork.ServiceCIDRs = splitCIDRs(s.Status.ServiceCIDR)
+	}
+
+	if options.networkPlugin != "" {
+		clusterNetwork.NetworkPlugin = options.networkPlugin
+	}
+
+	if options.clusterCIDRs != "" {
+		clusterNetwork.PodCIDRs = splitCIDRs(options.clusterCIDRs)
+	}
+
+	if options.serviceCIDRs != "" {
+		clusterNetwork.ServiceCIDRs = splitCIDRs(options.serviceCIDRs)
+	}
+
+	return clusterNetwork
+}
+
+func splitCIDRs(cidrs string) []string {
+	var result []string
+
+	for _, cidr := range strings.Split(cidrs, ",") {
+		if cidr = strings.TrimSpace(cidr); cidr != "" {
+			result = append(result, cidr)
+		}
+	}
+
+	return result
+}
+
+func writeResources(out io.Writer, objs []client.Object) error {
+	for _, obj := range objs {
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil {
+			return errors.Wrapf(err, "error determining the kind of %T", obj)
+		}
+
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return errors.Wrapf(err, "error marshalling %s %s", gvk.Kind, obj.GetName())
+		}
+
+		if _, err := fmt.Fprintf(out, "---\n%s", data); err != nil {
+			return errors.Wrap(err, "error writing the resources")
+		}
+	}
+
+	return nil
+}
@@ -16,12 +16,12 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-//nolint:dupl // These functions are similar but not duplicated.
 package apply
 
 import (
 	"context"
 	goerrors "errors"
+	"fmt"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -31,227 +31,312 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/csaupgrade"
 	"k8s.io/client-go/util/retry"
 	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-func DaemonSet(ctx context.Context, owner metav1.Object, daemonSet *appsv1.DaemonSet, reqLogger logr.Logger,
-	client controllerClient.Client, scheme *runtime.Scheme,
-) (*appsv1.DaemonSet, error) {
-	var err error
+// FieldManager is the field manager the operator uses when applying resources.
+const FieldManager = "submariner-operator"
 
-	// Set the owner and controller.
-	if err := controllerutil.SetControllerReference(owner, daemonSet, scheme); err != nil {
-		return nil, errors.Wrapf(err, "error setting owner reference for DaemonSet %s/%s", daemonSet.Namespace, daemonSet.Name)
-	}
+const (
+	reasonRecreated     = "Recreated"
+	reasonFieldConflict = "FieldConflict"
+)
 
-	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		toUpdate := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{
-			Name:      daemonSet.Name,
-			Namespace: daemonSet.Namespace,
-			Labels:    map[string]string{},
-		}}
-
-		result, err := controllerutil.CreateOrUpdate(ctx, client, toUpdate, func() error {
-			toUpdate.Spec = daemonSet.Spec
-			copyLabels(daemonSet, toUpdate)
-
-			// Set the owner and controller.
-			return controllerutil.SetControllerReference(owner, toUpdate, scheme)
-		})
-		if err != nil {
-			if isImmutableError(err) {
-				reqLogger.Info("Re-creating a DaemonSet because it has immutable fields", "DaemonSet.Namespace",
-					daemonSet.Namespace, "DaemonSet.Name", daemonSet.Name)
-
-				if err := client.Delete(ctx, toUpdate); err != nil {
-					return err //nolint:wrapcheck // No need to wrap here
-				}
-
-				if err := client.Create(ctx, daemonSet); err != nil {
-					return err //nolint:wrapcheck // No need to wrap here
-				}
-
-				return nil
-			}
+// csaFieldManagers are the field managers which set the fields of the resources with client-side updates in previous
+// versions. Those didn't set a field manager, so the API server derived it from the default user agent, which starts
+// with the operator's binary name.
+var csaFieldManagers = sets.New(FieldManager)
+
+// recreatableKinds are the kinds which are deleted and re-created when an immutable field changes. Others, such as
+// Services whose re-creation would release their load balancer address, fail to apply instead.
+var recreatableKinds = map[schema.GroupKind]bool{
+	appsv1.SchemeGroupVersion.WithKind("DaemonSet").GroupKind(): true,
+}
+
+// Apply creates or updates the given object with server-side apply, reporting drift and field conflicts to the owner
+// and leaving the object as it is if the owner is paused. On success, the object is updated from the server.
+func Apply[T controllerClient.Object](ctx context.Context, owner metav1.Object, obj T, reqLogger logr.Logger,
+	client controllerClient.Client, scheme *runtime.Scheme, recorder record.EventRecorder,
+) (T, error) {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return obj, errors.Wrapf(err, "error determining the kind of %T", obj)
+	}
 
-			return err //nolint:wrapcheck // No need to wrap here
+	if owner != nil {
+		// Set the owner and controller.
+		if err := controllerutil.SetControllerReference(owner, obj, scheme); err != nil {
+			return obj, errors.Wrapf(err, "error setting owner reference for %s %s/%s", gvk.Kind, obj.GetNamespace(), obj.GetName())
 		}
+	}
 
-		if result == controllerutil.OperationResultCreated {
-			reqLogger.Info("Created a new DaemonSet", "DaemonSet.Namespace", daemonSet.Namespace, "DaemonSet.Name", daemonSet.Name)
-		} else if result == controllerutil.OperationResultUpdated {
-			reqLogger.Info("Updated existing DaemonSet", "DaemonSet.Namespace", daemonSet.Namespace, "DaemonSet.Name", daemonSet.Name)
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+
+	if IsPaused(owner) {
+		reqLogger.Info(fmt.Sprintf("Not applying %s, its owner is paused", gvk.Kind), "Namespace", obj.GetNamespace(),
+			"Name", obj.GetName())
+
+		err := client.Get(ctx, controllerClient.ObjectKeyFromObject(obj), obj)
+		if apierrors.IsNotFound(err) {
+			return obj, nil
 		}
 
-		return nil
-	})
+		return obj, errors.Wrapf(err, "error retrieving %s %s/%s", gvk.Kind, obj.GetNamespace(), obj.GetName())
+	}
+
+	if err := setDesiredStateHash(obj); err != nil {
+		return obj, err
+	}
+
+	a := &applier{
+		owner:     owner,
+		desired:   obj.DeepCopyObject().(controllerClient.Object),
+		kind:      gvk.Kind,
+		reqLogger: reqLogger,
+		client:    client,
+		scheme:    scheme,
+		recorder:  recorder,
+	}
+
+	err = a.getLive(ctx)
+
+	leaveDrifted := false
+	if handler, ok := owner.(DriftHandler); ok && err == nil && a.live != nil {
+		leaveDrifted, err = a.checkDrift(handler)
+	}
+
+	if err == nil && !leaveDrifted {
+		err = a.apply(ctx)
+		if isImmutableError(err) && recreatableKinds[gvk.GroupKind()] {
+			err = a.recreate(ctx)
+		}
+	}
 
 	// Update the status from the server
 	if err == nil {
-		err = awaitResource(ctx, client, daemonSet)
+		err = awaitResource(ctx, client, obj)
 	}
 
-	return daemonSet, errors.WithMessagef(err, "error creating or updating DaemonSet %s/%s", daemonSet.Namespace, daemonSet.Name)
+	return obj, errors.WithMessagef(err, "error applying %s %s/%s", gvk.Kind, obj.GetNamespace(), obj.GetName())
+}
+
+// Pausable is implemented by owners whose reconciliation can be paused.
+type Pausable interface {
+	IsPaused() bool
 }
 
-func Deployment(ctx context.Context, owner metav1.Object, deployment *appsv1.Deployment, reqLogger logr.Logger,
-	client controllerClient.Client, scheme *runtime.Scheme,
-) (*appsv1.Deployment, error) {
-	var err error
+// IsPaused returns whether the given owner is paused, in which case the resources applied on its behalf are left as
+// they are.
+func IsPaused(owner metav1.Object) bool {
+	pausable, ok := owner.(Pausable)
 
-	// Set the owner and controller
-	if err := controllerutil.SetControllerReference(owner, deployment, scheme); err != nil {
-		return nil, errors.Wrapf(err, "error setting owner reference for Deployment %s/%s", deployment.Namespace, deployment.Name)
-	}
+	return ok && pausable.IsPaused()
+}
 
-	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		toUpdate := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
-			Name:      deployment.Name,
-			Namespace: deployment.Namespace,
-			Labels:    map[string]string{},
-		}}
-
-		result, err := controllerutil.CreateOrUpdate(ctx, client, toUpdate, func() error {
-			toUpdate.Spec = deployment.Spec
-			copyLabels(deployment, toUpdate)
-
-			// Set the owner and controller
-			return controllerutil.SetControllerReference(owner, toUpdate, scheme)
-		})
-		if err != nil {
-			return err //nolint:wrapcheck // No need to wrap here
-		}
+type applier struct {
+	owner   metav1.Object
+	desired controllerClient.Object
+	live    controllerClient.Object
+	// correctedFields are the paths of the drifted fields which are re-applied.
+	correctedFields []string
+	kind            string
+	reqLogger       logr.Logger
+	client          controllerClient.Client
+	scheme          *runtime.Scheme
+	recorder        record.EventRecorder
+}
 
-		if result == controllerutil.OperationResultCreated {
-			reqLogger.Info("Created a new Deployment", "Deployment.Namespace", deployment.Namespace, "Deployment.Name", deployment.Name)
-		} else if result == controllerutil.OperationResultUpdated {
-			reqLogger.Info("Updated existing Deployment", "Deployment.Namespace", deployment.Namespace, "Deployment.Name", deployment.Name)
-		}
+// getLive retrieves the live object, if it exists.
+func (a *applier) getLive(ctx context.Context) error {
+	newObj, err := a.scheme.New(a.desired.GetObjectKind().GroupVersionKind())
+	if err != nil {
+		return errors.Wrapf(err, "error creating a %s", a.kind)
+	}
+
+	live := newObj.(controllerClient.Object) //nolint:forcetypeassert // The desired object has the same type
 
+	err = a.client.Get(ctx, controllerClient.ObjectKeyFromObject(a.desired), live)
+	if apierrors.IsNotFound(err) {
 		return nil
-	})
+	}
 
-	// Update the status from the server
-	if err == nil {
-		err = awaitResource(ctx, client, deployment)
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving %s %s/%s", a.kind, a.desired.GetNamespace(), a.desired.GetName())
 	}
 
-	return deployment, errors.WithMessagef(err, "error creating or updating Deployment %s/%s", deployment.Namespace, deployment.Name)
+	a.live = live
+
+	return nil
 }
 
-func ConfigMap(ctx context.Context, owner metav1.Object, configMap *corev1.ConfigMap, reqLogger logr.Logger,
-	client controllerClient.Client, scheme *runtime.Scheme,
-) (*corev1.ConfigMap, error) {
-	var err error
+func (a *applier) apply(ctx context.Context) error {
+	err := a.upgradeManagedFields(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = a.patch(ctx, false)
+
+	conflicts := fieldConflicts(err)
+	if len(conflicts) == 0 {
+		return err
+	}
+
+	if !a.ownedPreviously(conflicts) {
+		a.reqLogger.Info(fmt.Sprintf("Not applying %s, fields are managed by others", a.kind), "Namespace",
+			a.desired.GetNamespace(), "Name", a.desired.GetName(), "Conflicts", conflicts)
+		a.event(corev1.EventTypeWarning, reasonFieldConflict, "Didn't apply %s %s/%s, fields are managed by others: %s",
+			a.kind, a.desired.GetNamespace(), a.desired.GetName(), formatConflicts(conflicts))
 
-	// Set the owner and controller
-	if err := controllerutil.SetControllerReference(owner, configMap, scheme); err != nil {
-		return nil, errors.Wrapf(err, "error setting owner reference for ConfigMap %s/%s", configMap.Namespace, configMap.Name)
+		return nil
 	}
 
-	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		toUpdate := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
-			Name:      configMap.Name,
-			Namespace: configMap.Namespace,
-			Labels:    map[string]string{},
-		}}
-
-		result, err := controllerutil.CreateOrUpdate(ctx, client, toUpdate, func() error {
-			toUpdate.Data = configMap.Data
-			copyLabels(configMap, toUpdate)
-
-			// Set the owner and controller
-			return controllerutil.SetControllerReference(owner, toUpdate, scheme)
-		})
-		if err != nil {
-			return err //nolint:wrapcheck // No need to wrap here
+	a.reqLogger.Info(fmt.Sprintf("Taking back ownership of drifted %s fields", a.kind), "Namespace",
+		a.desired.GetNamespace(), "Name", a.desired.GetName(), "Conflicts", conflicts)
+	a.event(corev1.EventTypeWarning, reasonFieldConflict, "Took back ownership of drifted fields of %s %s/%s: %s",
+		a.kind, a.desired.GetNamespace(), a.desired.GetName(), formatConflicts(conflicts))
+
+	return a.patch(ctx, true)
+}
+
+// ownedPreviously returns whether all the conflicting fields were previously applied by the operator, i.e. they're
+// among the fields which drifted from the desired state the operator last applied and which are corrected.
+func (a *applier) ownedPreviously(conflicts []metav1.StatusCause) bool {
+	for i := range conflicts {
+		field := strings.TrimPrefix(conflicts[i].Field, ".")
+
+		owned := false
+
+		for _, drifted := range a.correctedFields {
+			if field == drifted || strings.HasPrefix(field, drifted+".") || strings.HasPrefix(field, drifted+"[") {
+				owned = true
+				break
+			}
 		}
 
-		if result == controllerutil.OperationResultCreated {
-			reqLogger.Info("Created a new ConfigMap", "ConfigMap.Namespace", configMap.Namespace, "ConfigMap.Name", configMap.Name)
-		} else if result == controllerutil.OperationResultUpdated {
-			reqLogger.Info("Updated existing ConfigMap", "ConfigMap.Namespace", configMap.Namespace, "ConfigMap.Name", configMap.Name)
+		if !owned {
+			return false
 		}
+	}
+
+	return true
+}
 
+// upgradeManagedFields transfers the ownership of the fields set with client-side updates by previous versions to the
+// operator's server-side apply field manager, so that those which are no longer applied are removed.
+func (a *applier) upgradeManagedFields(ctx context.Context) error {
+	if a.live == nil {
 		return nil
-	})
+	}
 
-	// Update the status from the server
-	if err == nil {
-		err = awaitResource(ctx, client, configMap)
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(a.live, csaFieldManagers, FieldManager)
+	if err != nil {
+		return errors.Wrapf(err, "error computing the managed fields upgrade of %s %s/%s", a.kind, a.desired.GetNamespace(),
+			a.desired.GetName())
 	}
 
-	return configMap, errors.WithMessagef(err, "error creating or updating ConfigMap %s/%s", configMap.Namespace, configMap.Name)
+	if patch == nil {
+		return nil
+	}
+
+	a.reqLogger.Info(fmt.Sprintf("Upgrading the managed fields of %s to server-side apply", a.kind), "Namespace",
+		a.desired.GetNamespace(), "Name", a.desired.GetName())
+
+	return errors.Wrapf(a.client.Patch(ctx, a.live, controllerClient.RawPatch(types.JSONPatchType, patch)),
+		"error upgrading the managed fields of %s %s/%s", a.kind, a.desired.GetNamespace(), a.desired.GetName())
 }
 
-func Service(ctx context.Context, owner metav1.Object, service *corev1.Service, reqLogger logr.Logger,
-	client controllerClient.Client, scheme *runtime.Scheme,
-) (*corev1.Service, error) {
-	var err error
+func (a *applier) recreate(ctx context.Context) error {
+	a.reqLogger.Info(fmt.Sprintf("Re-creating a %s because it has immutable fields", a.kind), "Namespace",
+		a.desired.GetNamespace(), "Name", a.desired.GetName())
 
-	if owner != nil {
-		// Set the owner and controller
-		if err := controllerutil.SetControllerReference(owner, service, scheme); err != nil {
-			return nil, errors.Wrapf(err, "error setting owner reference for Service %s/%s", service.Namespace, service.Name)
-		}
+	if err := a.client.Delete(ctx, a.desired.DeepCopyObject().(controllerClient.Object)); err != nil &&
+		!apierrors.IsNotFound(err) {
+		return err //nolint:wrapcheck // No need to wrap here
 	}
 
-	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		toUpdate := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
-			Name:        service.Name,
-			Namespace:   service.Namespace,
-			Labels:      map[string]string{},
-			Annotations: map[string]string{},
-		}}
-
-		result, err := controllerutil.CreateOrUpdate(ctx, client, toUpdate, func() error {
-			if toUpdate.Spec.Type == corev1.ServiceTypeClusterIP || toUpdate.Spec.Type == corev1.ServiceTypeLoadBalancer {
-				// Make sure we don't lose the ClusterIP, see https://github.com/kubernetes/kubectl/issues/798
-				service.Spec.ClusterIP = toUpdate.Spec.ClusterIP
-				// We also need to copy the existing healthCheckNodePort which can't change
-				service.Spec.HealthCheckNodePort = toUpdate.Spec.HealthCheckNodePort
-			}
+	a.live = nil
 
-			toUpdate.Spec = service.Spec
-			copyLabels(service, toUpdate)
+	if err := a.patch(ctx, true); err != nil {
+		return err
+	}
 
-			if toUpdate.Annotations == nil {
-				toUpdate.Annotations = map[string]string{}
-			}
+	a.event(corev1.EventTypeNormal, a.kind+reasonRecreated, "Re-created %s %s/%s because an immutable field changed",
+		a.kind, a.desired.GetNamespace(), a.desired.GetName())
 
-			for k, v := range service.Annotations {
-				toUpdate.Annotations[k] = v
-			}
+	return nil
+}
 
-			if owner != nil {
-				// Set the owner and controller
-				return controllerutil.SetControllerReference(owner, toUpdate, scheme)
-			}
+func (a *applier) patch(ctx context.Context, force bool) error {
+	opts := []controllerClient.PatchOption{controllerClient.FieldOwner(FieldManager)}
+	if force {
+		opts = append(opts, controllerClient.ForceOwnership)
+	}
 
-			return nil
-		})
-		if err != nil {
-			return err //nolint:wrapcheck // No need to wrap here
-		}
+	applied := a.desired.DeepCopyObject().(controllerClient.Object)
 
-		if result == controllerutil.OperationResultCreated {
-			reqLogger.Info("Created a new Service", "Service.Namespace", service.Namespace, "Service.Name", service.Name)
-		} else if result == controllerutil.OperationResultUpdated {
-			reqLogger.Info("Updated existing Service", "Service.Namespace", service.Namespace, "Service.Name", service.Name)
-		}
+	err := a.client.Patch(ctx, applied, controllerClient.Apply, opts...)
+	if err != nil {
+		return err //nolint:wrapcheck // No need to wrap here
+	}
+
+	// Applying an unchanged state is a no-op on the server, which keeps the resource version
+	if a.live == nil {
+		a.reqLogger.Info("Created a new "+a.kind, "Namespace", a.desired.GetNamespace(), "Name", a.desired.GetName())
+	} else if applied.GetResourceVersion() != a.live.GetResourceVersion() {
+		a.reqLogger.Info("Updated existing "+a.kind, "Namespace", a.desired.GetNamespace(), "Name", a.desired.GetName())
+	}
 
+	return nil
+}
+
+func (a *applier) event(eventType, reason, messageFmt string, args ...interface{}) {
+	if a.recorder == nil {
+		return
+	}
+
+	if ownerObj, ok := a.owner.(runtime.Object); ok {
+		a.recorder.Eventf(ownerObj, eventType, reason, messageFmt, args...)
+	}
+}
+
+// fieldConflicts returns the field manager conflicts reported in the given server-side apply error, if any.
+func fieldConflicts(err error) []metav1.StatusCause {
+	if !apierrors.IsConflict(err) {
 		return nil
-	})
+	}
 
-	// Update the status from the server
-	if err == nil {
-		err = awaitResource(ctx, client, service)
+	status := apierrors.APIStatus(nil)
+	if !goerrors.As(err, &status) || status.Status().Details == nil {
+		return nil
+	}
+
+	var conflicts []metav1.StatusCause
+
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type == metav1.CauseTypeFieldManagerConflict {
+			conflicts = append(conflicts, cause)
+		}
+	}
+
+	return conflicts
+}
+
+func formatConflicts(conflicts []metav1.StatusCause) string {
+	formatted := make([]string, len(conflicts))
+	for i := range conflicts {
+		formatted[i] = fmt.Sprintf("%s (%s)", conflicts[i].Field, conflicts[i].Message)
 	}
 
-	return service, errors.WithMessagef(err, "error creating or updating Service %s/%s", service.Namespace, service.Name)
+	return strings.Join(formatted, ", ")
 }
 
 func awaitResource(ctx context.Context, client controllerClient.Client, resource controllerClient.Object) error {
@@ -273,13 +358,3 @@ func isImmutableError(err error) bool {
 
 	return false
 }
-
-func copyLabels(from, to controllerClient.Object) {
-	if to.GetLabels() == nil {
-		to.SetLabels(map[string]string{})
-	}
-
-	for k, v := range from.GetLabels() {
-		to.GetLabels()[k] = v
-	}
-}
@@ -25,10 +25,13 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/submariner-io/admiral/pkg/log/kzerolog"
 	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/internal/controllers/test"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -52,9 +55,11 @@ func TestApply(t *testing.T) {
 }
 
 type testDriver struct {
-	client         controllerClient.Client
-	initClientObjs []controllerClient.Object
-	owner          metav1.Object
+	client           controllerClient.Client
+	initClientObjs   []controllerClient.Object
+	interceptorFuncs interceptor.Funcs
+	owner            metav1.Object
+	recorder         *record.FakeRecorder
 }
 
 func newTestDriver() *testDriver {
@@ -62,6 +67,8 @@ func newTestDriver() *testDriver {
 
 	BeforeEach(func() {
 		t.initClientObjs = []controllerClient.Object{}
+		t.recorder = record.NewFakeRecorder(10)
+		t.interceptorFuncs = interceptor.Funcs{Patch: test.ServerSideApply}
 		t.owner = &v1alpha1.Submariner{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "submariner",
@@ -71,7 +78,8 @@ func newTestDriver() *testDriver {
 	})
 
 	JustBeforeEach(func() {
-		t.client = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(t.initClientObjs...).Build()
+		t.client = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(t.initClientObjs...).
+			WithInterceptorFuncs(t.interceptorFuncs).Build()
 	})
 
 	return t
@@ -19,18 +19,21 @@ limitations under the License.
 package apply_test
 
 import (
+	"context"
 	"net/http"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-	"github.com/submariner-io/admiral/pkg/fake"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
 	"github.com/submariner-io/submariner-operator/internal/controllers/apply"
+	"github.com/submariner-io/submariner-operator/internal/controllers/test"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("Apply", func() {
@@ -69,7 +72,7 @@ func testDaemonSet() {
 
 	When("the DaemonSet doesn't exist", func() {
 		It("should create it", func(ctx SpecContext) {
-			actual, err := apply.DaemonSet(ctx, t.owner, daemonSet, log, t.client, scheme.Scheme)
+			actual, err := apply.Apply(ctx, t.owner, daemonSet, log, t.client, scheme.Scheme, t.recorder)
 			Expect(err).To(Succeed())
 			Expect(actual).To(Equal(daemonSet))
 			t.verifyOwnerRef(actual)
@@ -90,26 +93,141 @@ func testDaemonSet() {
 		})
 
 		It("should update it", func(ctx SpecContext) {
-			actual, err := apply.DaemonSet(ctx, t.owner, daemonSet, log, t.client, scheme.Scheme)
+			actual, err := apply.Apply(ctx, t.owner, daemonSet, log, t.client, scheme.Scheme, t.recorder)
 			Expect(err).To(Succeed())
 			Expect(actual).To(Equal(daemonSet))
 		})
 
 		Context("and it's immutable", func() {
-			JustBeforeEach(func() {
-				t.client = fake.NewReactingClient(t.client).AddReactor(fake.Update, &appsv1.DaemonSet{},
-					fake.FailingReaction(&apierrors.StatusError{ErrStatus: metav1.Status{
-						Status:  metav1.StatusFailure,
-						Code:    http.StatusUnprocessableEntity,
-						Reason:  metav1.StatusReasonInvalid,
-						Message: "Object is immutable",
-					}}))
+			BeforeEach(func() {
+				t.interceptorFuncs.Patch = func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch,
+					opts ...client.PatchOption,
+				) error {
+					if err := c.Get(ctx, client.ObjectKeyFromObject(obj), &appsv1.DaemonSet{}); err == nil {
+						return &apierrors.StatusError{ErrStatus: metav1.Status{
+							Status:  metav1.StatusFailure,
+							Code:    http.StatusUnprocessableEntity,
+							Reason:  metav1.StatusReasonInvalid,
+							Message: "Object is immutable",
+						}}
+					}
+
+					return test.ServerSideApply(ctx, c, obj, patch, opts...)
+				}
 			})
 
 			It("should re-create it", func(ctx SpecContext) {
-				actual, err := apply.DaemonSet(ctx, t.owner, daemonSet, log, t.client, scheme.Scheme)
+				actual, err := apply.Apply(ctx, t.owner, daemonSet, log, t.client, scheme.Scheme, t.recorder)
 				Expect(err).To(Succeed())
 				Expect(actual).To(Equal(daemonSet))
+				Expect(t.recorder.Events).To(Receive(ContainSubstring("DaemonSetRecreated")))
+			})
+		})
+
+		Context("and its fields were set with client-side updates", func() {
+			var upgradePatch []byte
+
+			BeforeEach(func() {
+				upgradePatch = nil
+
+				t.initClientObjs[0].SetManagedFields([]metav1.ManagedFieldsEntry{{
+					Manager:    apply.FieldManager,
+					Operation:  metav1.ManagedFieldsOperationUpdate,
+					APIVersion: "apps/v1",
+					FieldsType: "FieldsV1",
+					FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:minReadySeconds":{}}}`)},
+				}})
+
+				t.interceptorFuncs.Patch = func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch,
+					opts ...client.PatchOption,
+				) error {
+					if patch.Type() == types.JSONPatchType {
+						var err error
+
+						upgradePatch, err = patch.Data(obj)
+						Expect(err).To(Succeed())
+
+						return nil
+					}
+
+					return test.ServerSideApply(ctx, c, obj, patch, opts...)
+				}
+			})
+
+			It("should transfer their ownership to the server-side apply field manager", func(ctx SpecContext) {
+				_, err := apply.Apply(ctx, t.owner, daemonSet, log, t.client, scheme.Scheme, t.recorder)
+				Expect(err).To(Succeed())
+				Expect(string(upgradePatch)).To(SatisfyAll(ContainSubstring(`"operation":"Apply"`),
+					ContainSubstring(`"manager":"`+apply.FieldManager+`"`), Not(ContainSubstring(`"operation":"Update"`))))
+			})
+		})
+
+		Context("and fields are owned by another field manager", func() {
+			var conflict, forced bool
+
+			BeforeEach(func() {
+				conflict = true
+				forced = false
+
+				t.interceptorFuncs.Patch = func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch,
+					opts ...client.PatchOption,
+				) error {
+					patchOptions := &client.PatchOptions{}
+					patchOptions.ApplyOptions(opts)
+
+					Expect(patchOptions.FieldManager).To(Equal(apply.FieldManager))
+
+					forced = patchOptions.Force != nil && *patchOptions.Force
+					if conflict && !forced {
+						return &apierrors.StatusError{ErrStatus: metav1.Status{
+							Status: metav1.StatusFailure,
+							Code:   http.StatusConflict,
+							Reason: metav1.StatusReasonConflict,
+							Details: &metav1.StatusDetails{Causes: []metav1.StatusCause{{
+								Type:    metav1.CauseTypeFieldManagerConflict,
+								Message: `conflict with "kubectl-edit"`,
+								Field:   ".spec.minReadySeconds",
+							}}},
+						}}
+					}
+
+					return test.ServerSideApply(ctx, c, obj, patch, opts...)
+				}
+			})
+
+			It("should report the conflict and leave it as is", func(ctx SpecContext) {
+				actual, err := apply.Apply(ctx, t.owner, daemonSet, log, t.client, scheme.Scheme, t.recorder)
+				Expect(err).To(Succeed())
+				Expect(forced).To(BeFalse())
+				Expect(actual.Spec.MinReadySeconds).To(BeEquivalentTo(10))
+				Expect(t.recorder.Events).To(Receive(SatisfyAll(ContainSubstring("FieldConflict"),
+					ContainSubstring(".spec.minReadySeconds"))))
+			})
+
+			Context("which drifted from the applied state", func() {
+				BeforeEach(func() {
+					conflict = false
+				})
+
+				It("should report the conflict and take back their ownership", func(ctx SpecContext) {
+					_, err := apply.Apply(ctx, t.owner, daemonSet.DeepCopy(), log, t.client, scheme.Scheme, t.recorder)
+					Expect(err).To(Succeed())
+
+					live := &appsv1.DaemonSet{}
+					Expect(t.client.Get(ctx, client.ObjectKeyFromObject(daemonSet), live)).To(Succeed())
+					live.Spec.MinReadySeconds = 30
+					Expect(t.client.Update(ctx, live)).To(Succeed())
+
+					conflict = true
+
+					actual, err := apply.Apply(ctx, t.owner, daemonSet, log, t.client, scheme.Scheme, t.recorder)
+					Expect(err).To(Succeed())
+					Expect(forced).To(BeTrue())
+					Expect(actual.Spec.MinReadySeconds).To(BeEquivalentTo(20))
+					Expect(t.recorder.Events).To(Receive(ContainSubstring("DriftDetected")))
+					Expect(t.recorder.Events).To(Receive(SatisfyAll(ContainSubstring("FieldConflict"),
+						ContainSubstring(".spec.minReadySeconds"))))
+				})
 			})
 		})
 	})
@@ -144,7 +262,7 @@ func testDeployment() {
 
 	When("the Deployment doesn't exist", func() {
 		It("should create it", func(ctx SpecContext) {
-			actual, err := apply.Deployment(ctx, t.owner, deployment, log, t.client, scheme.Scheme)
+			actual, err := apply.Apply(ctx, t.owner, deployment, log, t.client, scheme.Scheme, t.recorder)
 			Expect(err).To(Succeed())
 			Expect(actual).To(Equal(deployment))
 			t.verifyOwnerRef(actual)
@@ -164,7 +282,7 @@ func testDeployment() {
 		})
 
 		It("should update it", func(ctx SpecContext) {
-			actual, err := apply.Deployment(ctx, t.owner, deployment, log, t.client, scheme.Scheme)
+			actual, err := apply.Apply(ctx, t.owner, deployment, log, t.client, scheme.Scheme, t.recorder)
 			Expect(err).To(Succeed())
 			Expect(actual).To(Equal(deployment))
 		})
@@ -188,7 +306,7 @@ func testConfigMap() {
 
 	When("the ConfigMap doesn't exist", func() {
 		It("should create it", func(ctx SpecContext) {
-			actual, err := apply.ConfigMap(ctx, t.owner, configMap, log, t.client, scheme.Scheme)
+			actual, err := apply.Apply(ctx, t.owner, configMap, log, t.client, scheme.Scheme, t.recorder)
 			Expect(err).To(Succeed())
 			Expect(actual).To(Equal(configMap))
 			t.verifyOwnerRef(actual)
@@ -208,7 +326,7 @@ func testConfigMap() {
 		})
 
 		It("should update it", func(ctx SpecContext) {
-			actual, err := apply.ConfigMap(ctx, t.owner, configMap, log, t.client, scheme.Scheme)
+			actual, err := apply.Apply(ctx, t.owner, configMap, log, t.client, scheme.Scheme, t.recorder)
 			Expect(err).To(Succeed())
 			Expect(actual).To(Equal(configMap))
 		})
@@ -235,7 +353,7 @@ func testService() {
 
 	When("the Service doesn't exist", func() {
 		It("should create it", func(ctx SpecContext) {
-			actual, err := apply.Service(ctx, t.owner, service, log, t.client, scheme.Scheme)
+			actual, err := apply.Apply(ctx, t.owner, service, log, t.client, scheme.Scheme, t.recorder)
 			Expect(err).To(Succeed())
 			Expect(actual).To(Equal(service))
 			t.verifyOwnerRef(actual)
@@ -246,6 +364,18 @@ func testService() {
 		})
 	})
 
+	When("the Service doesn't exist and the owner is paused", func() {
+		BeforeEach(func() {
+			t.owner.(*v1alpha1.Submariner).Spec.Paused = true
+		})
+
+		It("should not create it", func(ctx SpecContext) {
+			_, err := apply.Apply(ctx, t.owner, service, log, t.client, scheme.Scheme, t.recorder)
+			Expect(err).To(Succeed())
+			Expect(t.client.Get(ctx, client.ObjectKeyFromObject(service), &corev1.Service{})).ToNot(Succeed())
+		})
+	})
+
 	When("the Service already exists", func() {
 		BeforeEach(func() {
 			t.initClientObjs = append(t.initClientObjs, service.DeepCopy())
@@ -253,10 +383,49 @@ func testService() {
 			service.Annotations = map[string]string{"foo1": "bar1"}
 		})
 
+		Context("and it's immutable", func() {
+			BeforeEach(func() {
+				t.interceptorFuncs.Patch = func(_ context.Context, _ client.WithWatch, _ client.Object, _ client.Patch,
+					_ ...client.PatchOption,
+				) error {
+					return &apierrors.StatusError{ErrStatus: metav1.Status{
+						Status:  metav1.StatusFailure,
+						Code:    http.StatusUnprocessableEntity,
+						Reason:  metav1.StatusReasonInvalid,
+						Message: "Object is immutable",
+					}}
+				}
+			})
+
+			It("should not re-create it", func(ctx SpecContext) {
+				_, err := apply.Apply(ctx, t.owner, service, log, t.client, scheme.Scheme, t.recorder)
+				Expect(err).To(HaveOccurred())
+				Expect(t.client.Get(ctx, client.ObjectKeyFromObject(service), &corev1.Service{})).To(Succeed())
+				Expect(t.recorder.Events).ToNot(Receive())
+			})
+		})
+
 		It("should update it", func(ctx SpecContext) {
-			actual, err := apply.Service(ctx, t.owner, service, log, t.client, scheme.Scheme)
+			actual, err := apply.Apply(ctx, t.owner, service, log, t.client, scheme.Scheme, t.recorder)
 			Expect(err).To(Succeed())
 			Expect(actual).To(Equal(service))
 		})
+
+		It("should leave it as is if the owner is paused", func(ctx SpecContext) {
+			t.owner.SetAnnotations(map[string]string{v1alpha1.PausedAnnotation: "true"})
+
+			actual, err := apply.Apply(ctx, t.owner, service, log, t.client, scheme.Scheme, t.recorder)
+			Expect(err).To(Succeed())
+			Expect(actual.Labels).To(BeEmpty())
+		})
+
+		It("should preserve the fields it doesn't set", func(ctx SpecContext) {
+			service.Spec.ClusterIP = ""
+
+			actual, err := apply.Apply(ctx, t.owner, service, log, t.client, scheme.Scheme, t.recorder)
+			Expect(err).To(Succeed())
+			Expect(actual.Spec.ClusterIP).To(Equal("1.2.3.4"))
+			Expect(actual.Labels).To(HaveKeyWithValue("foo", "bar"))
+		})
 	})
 }
@@ -0,0 +1,287 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// DesiredStateAnnotation holds the hash of the desired state last applied to a resource.
+const DesiredStateAnnotation = "submariner.io/desired-state-hash"
+
+const reasonDriftDetected = "DriftDetected"
+
+// DriftHandler is implemented by owners which track the drift of the resources applied on their behalf. A resource
+// has drifted when its desired state hasn't changed since it was last applied but its live state no longer matches.
+type DriftHandler interface {
+	// CorrectsDrift returns whether drifted resources are re-applied or left as they are.
+	CorrectsDrift() bool
+	// DriftDetected records a drifted resource.
+	DriftDetected(resource v1alpha1.DriftedResource)
+}
+
+var driftCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "submariner_operator_resource_drift_total",
+		Help: "Number of times a managed resource was found to differ from its desired state",
+	},
+	[]string{"kind", "namespace", "name"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(driftCounter)
+}
+
+// setDesiredStateHash records the hash of the given object, as it is about to be applied, in its annotations.
+func setDesiredStateHash(obj controllerClient.Object) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return errors.Wrap(err, "error marshalling the desired state")
+	}
+
+	hash := sha256.Sum256(data)
+
+	annotations := make(map[string]string, len(obj.GetAnnotations())+1)
+	for k, v := range obj.GetAnnotations() {
+		annotations[k] = v
+	}
+
+	annotations[DesiredStateAnnotation] = hex.EncodeToString(hash[:])
+	obj.SetAnnotations(annotations)
+
+	return nil
+}
+
+// checkDrift compares the live state of an existing resource with its desired state and records any drift with the
+// handler. It returns whether the drifted resource should be left as it is.
+func (a *applier) checkDrift(handler DriftHandler) (bool, error) {
+	// A different hash means the desired state changed since the resource was last applied, that's an update, not drift
+	if a.live.GetAnnotations()[DesiredStateAnnotation] != a.desired.GetAnnotations()[DesiredStateAnnotation] {
+		return false, nil
+	}
+
+	fields, err := driftedFields(a.desired, a.live)
+	if err != nil || len(fields) == 0 {
+		return false, err
+	}
+
+	correct := handler.CorrectsDrift()
+	if correct {
+		a.correctedFields = fields
+	}
+
+	handler.DriftDetected(v1alpha1.DriftedResource{
+		Kind:      a.kind,
+		Namespace: a.desired.GetNamespace(),
+		Name:      a.desired.GetName(),
+		Fields:    fields,
+		Corrected: correct,
+	})
+
+	driftCounter.WithLabelValues(a.kind, a.desired.GetNamespace(), a.desired.GetName()).Inc()
+
+	action := "re-applying the desired state"
+	if !correct {
+		action = "leaving it as is"
+	}
+
+	a.reqLogger.Info(fmt.Sprintf("%s drifted from its desired state, %s", a.kind, action), "Namespace",
+		a.desired.GetNamespace(), "Name", a.desired.GetName(), "Fields", fields)
+	a.event(corev1.EventTypeWarning, reasonDriftDetected, "%s %s/%s drifted from its desired state in %s, %s",
+		a.kind, a.desired.GetNamespace(), a.desired.GetName(), strings.Join(fields, ", "), action)
+
+	return !correct, nil
+}
+
+// driftedFields returns the paths of the fields set in the desired object which differ in the live object. Only the
+// labels and annotations of the metadata are compared, the type and status are ignored. Empty values in the desired
+// object are considered unset since they're typically defaulted by the API server. The entries of lists of objects are
+// matched by their merge key and entries only present in the live object are ignored.
+func driftedFields(desired, live runtime.Object) ([]string, error) {
+	desiredMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(desired)
+	if err != nil {
+		return nil, errors.Wrap(err, "error converting the desired state")
+	}
+
+	liveMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(live)
+	if err != nil {
+		return nil, errors.Wrap(err, "error converting the live state")
+	}
+
+	// The type isn't set in typed objects retrieved from the API server
+	delete(desiredMap, "apiVersion")
+	delete(desiredMap, "kind")
+	delete(desiredMap, "status")
+
+	if metadata, ok := desiredMap["metadata"].(map[string]interface{}); ok {
+		desiredMap["metadata"] = map[string]interface{}{
+			"labels":      metadata["labels"],
+			"annotations": metadata["annotations"],
+		}
+	}
+
+	return diffFields("", desiredMap, liveMap), nil
+}
+
+func diffFields(path string, desired, live interface{}) []string {
+	var fields []string
+
+	switch d := desired.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		l, _ := live.(map[string]interface{})
+
+		keys := make([]string, 0, len(d))
+		for key := range d {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			fields = append(fields, diffFields(strings.TrimPrefix(path+"."+key, "."), d[key], l[key])...)
+		}
+
+		return fields
+	case []interface{}:
+		if len(d) == 0 {
+			return nil
+		}
+
+		l, ok := live.([]interface{})
+		if !ok {
+			return []string{path}
+		}
+
+		if key := mergeKey(d); key != "" {
+			return diffKeyedList(path, key, d, l)
+		}
+
+		if _, isMap := d[0].(map[string]interface{}); isMap {
+			return diffUnkeyedList(path, d, l)
+		}
+	case string:
+		if d == "" {
+			return nil
+		}
+	case int64:
+		if d == 0 {
+			return nil
+		}
+	case float64:
+		if d == 0 {
+			return nil
+		}
+	}
+
+	if !reflect.DeepEqual(desired, live) {
+		return []string{path}
+	}
+
+	return nil
+}
+
+// mergeKeys are the fields identifying the entries of the lists of objects, in order of precedence, as in the
+// server-side apply list map keys (containers, env, volumes and volume mounts, ports...).
+var mergeKeys = []string{"mountPath", "devicePath", "containerPort", "port", "name", "ip"}
+
+// mergeKey returns the merge key set in all the entries of the given list, if any.
+func mergeKey(list []interface{}) string {
+	for _, key := range mergeKeys {
+		found := true
+
+		for _, entry := range list {
+			m, ok := entry.(map[string]interface{})
+			if !ok || m[key] == nil || m[key] == "" {
+				found = false
+				break
+			}
+		}
+
+		if found {
+			return key
+		}
+	}
+
+	return ""
+}
+
+// diffKeyedList compares the desired entries with the live entries with the same key, entries only present in the
+// live list (injected sidecars, environment variables or volumes) aren't drift. The paths use the server-side apply
+// format, e.g. containers[name="gateway"].image.
+func diffKeyedList(path, key string, desired, live []interface{}) []string {
+	var fields []string
+
+	for _, d := range desired {
+		keyValue := d.(map[string]interface{})[key] //nolint:forcetypeassert // Checked by mergeKey
+
+		entryPath := fmt.Sprintf("%s[%s=%v]", path, key, keyValue)
+		if s, ok := keyValue.(string); ok {
+			entryPath = fmt.Sprintf("%s[%s=%q]", path, key, s)
+		}
+
+		var liveEntry interface{}
+
+		for _, l := range live {
+			if m, ok := l.(map[string]interface{}); ok && reflect.DeepEqual(m[key], keyValue) {
+				liveEntry = m
+				break
+			}
+		}
+
+		if liveEntry == nil {
+			fields = append(fields, entryPath)
+			continue
+		}
+
+		fields = append(fields, diffFields(entryPath, d, liveEntry)...)
+	}
+
+	return fields
+}
+
+// diffUnkeyedList reports the list as drifted if any desired entry doesn't match a live entry; live entries added by
+// others aren't drift.
+func diffUnkeyedList(path string, desired, live []interface{}) []string {
+	for _, d := range desired {
+		if !slices.ContainsFunc(live, func(l interface{}) bool {
+			return len(diffFields(path, d, l)) == 0
+		}) {
+			return []string{path}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/internal/controllers/apply"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Drift", func() {
+	t := newTestDriver()
+
+	var service *corev1.Service
+
+	owner := func() *v1alpha1.Submariner {
+		return t.owner.(*v1alpha1.Submariner)
+	}
+
+	BeforeEach(func() {
+		service = &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-svc",
+				Namespace: submarinerNamespace,
+				Labels:    map[string]string{"app": "test"},
+			},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{Name: "metrics", Port: 8080}},
+			},
+		}
+	})
+
+	JustBeforeEach(func(ctx SpecContext) {
+		_, err := apply.Apply(ctx, t.owner, service.DeepCopy(), log, t.client, scheme.Scheme, t.recorder)
+		Expect(err).To(Succeed())
+		Expect(t.recorder.Events).ToNot(Receive())
+	})
+
+	modifyLive := func(ctx context.Context) {
+		live := &corev1.Service{}
+		Expect(t.client.Get(ctx, client.ObjectKeyFromObject(service), live)).To(Succeed())
+		live.Spec.Ports[0].Port = 9090
+		live.Labels["team"] = "networking"
+		Expect(t.client.Update(ctx, live)).To(Succeed())
+	}
+
+	When("the live resource matches the desired state", func() {
+		It("should not report drift", func(ctx SpecContext) {
+			actual, err := apply.Apply(ctx, t.owner, service, log, t.client, scheme.Scheme, t.recorder)
+			Expect(err).To(Succeed())
+			Expect(actual.Annotations).To(HaveKey(apply.DesiredStateAnnotation))
+			Expect(owner().Status.DriftDetected).To(BeEmpty())
+			Expect(t.recorder.Events).ToNot(Receive())
+		})
+	})
+
+	When("the live resource is retrieved without its type", func() {
+		BeforeEach(func() {
+			t.interceptorFuncs.Get = func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object,
+				opts ...client.GetOption,
+			) error {
+				err := c.Get(ctx, key, obj, opts...)
+				obj.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{})
+
+				return err
+			}
+		})
+
+		It("should not report drift", func(ctx SpecContext) {
+			_, err := apply.Apply(ctx, t.owner, service, log, t.client, scheme.Scheme, t.recorder)
+			Expect(err).To(Succeed())
+			Expect(owner().Status.DriftDetected).To(BeEmpty())
+			Expect(t.recorder.Events).ToNot(Receive())
+		})
+	})
+
+	When("entries were added to the lists of the live resource", func() {
+		JustBeforeEach(func(ctx SpecContext) {
+			live := &corev1.Service{}
+			Expect(t.client.Get(ctx, client.ObjectKeyFromObject(service), live)).To(Succeed())
+			live.Spec.Ports = append(live.Spec.Ports, corev1.ServicePort{Name: "injected", Port: 15090})
+			Expect(t.client.Update(ctx, live)).To(Succeed())
+		})
+
+		It("should not report drift", func(ctx SpecContext) {
+			_, err := apply.Apply(ctx, t.owner, service, log, t.client, scheme.Scheme, t.recorder)
+			Expect(err).To(Succeed())
+			Expect(owner().Status.DriftDetected).To(BeEmpty())
+			Expect(t.recorder.Events).ToNot(Receive())
+		})
+	})
+
+	When("a field of a list entry of the live resource was modified", func() {
+		JustBeforeEach(func(ctx SpecContext) {
+			live := &corev1.Service{}
+			Expect(t.client.Get(ctx, client.ObjectKeyFromObject(service), live)).To(Succeed())
+			live.Spec.Ports[0].Name = "other"
+			Expect(t.client.Update(ctx, live)).To(Succeed())
+		})
+
+		It("should report the field by the entry's merge key", func(ctx SpecContext) {
+			_, err := apply.Apply(ctx, t.owner, service, log, t.client, scheme.Scheme, t.recorder)
+			Expect(err).To(Succeed())
+			Expect(owner().Status.DriftDetected).To(HaveLen(1))
+			Expect(owner().Status.DriftDetected[0].Fields).To(Equal([]string{"spec.ports[port=8080].name"}))
+		})
+	})
+
+	When("the live resource was modified", func() {
+		JustBeforeEach(func(ctx SpecContext) {
+			modifyLive(ctx)
+		})
+
+		Context("and the drift policy is AutoCorrect", func() {
+			It("should report the drift and re-apply the desired state", func(ctx SpecContext) {
+				actual, err := apply.Apply(ctx, t.owner, service, log, t.client, scheme.Scheme, t.recorder)
+				Expect(err).To(Succeed())
+				Expect(actual.Spec.Ports[0].Port).To(BeEquivalentTo(8080))
+				Expect(owner().Status.DriftDetected).To(Equal([]v1alpha1.DriftedResource{{
+					Kind:      "Service",
+					Namespace: service.Namespace,
+					Name:      service.Name,
+					Fields:    []string{"spec.ports[port=8080]"},
+					Corrected: true,
+				}}))
+				Expect(t.recorder.Events).To(Receive(SatisfyAll(ContainSubstring("DriftDetected"),
+					ContainSubstring("spec.ports[port=8080]"))))
+			})
+		})
+
+		Context("and the drift policy is ReportOnly", func() {
+			BeforeEach(func() {
+				owner().Spec.DriftPolicy = v1alpha1.DriftReportOnly
+			})
+
+			It("should report the drift and leave the resource as is", func(ctx SpecContext) {
+				actual, err := apply.Apply(ctx, t.owner, service, log, t.client, scheme.Scheme, t.recorder)
+				Expect(err).To(Succeed())
+				Expect(actual.Spec.Ports[0].Port).To(BeEquivalentTo(9090))
+				Expect(owner().Status.DriftDetected).To(HaveLen(1))
+				Expect(owner().Status.DriftDetected[0].Corrected).To(BeFalse())
+				Expect(t.recorder.Events).To(Receive(ContainSubstring("DriftDetected")))
+			})
+		})
+
+		Context("and the desired state changed", func() {
+			BeforeEach(func() {
+				owner().Spec.DriftPolicy = v1alpha1.DriftReportOnly
+			})
+
+			It("should apply the new desired state without reporting drift", func(ctx SpecContext) {
+				service.Spec.Ports[0].Port = 8081
+
+				actual, err := apply.Apply(ctx, t.owner, service, log, t.client, scheme.Scheme, t.recorder)
+				Expect(err).To(Succeed())
+				Expect(actual.Spec.Ports[0].Port).To(BeEquivalentTo(8081))
+				Expect(owner().Status.DriftDetected).To(BeEmpty())
+			})
+		})
+	})
+})
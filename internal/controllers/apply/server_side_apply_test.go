@@ -0,0 +1,257 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/internal/controllers/apply"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+)
+
+// The user agent of previous versions of the operator, from which the API server derives the field manager of the
+// client-side updates made without an explicit field manager.
+const previousUserAgent = "submariner-operator/v0.19.0 (linux/amd64) kubernetes/$Format"
+
+// These tests run against a real API server, the fake client doesn't support server-side apply.
+var _ = Describe("Server-side apply", Ordered, func() {
+	var (
+		config   *rest.Config
+		client   controllerClient.Client
+		owner    *v1alpha1.Submariner
+		recorder *record.FakeRecorder
+	)
+
+	BeforeAll(func() {
+		if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+			Skip("KUBEBUILDER_ASSETS isn't set, the API server binaries aren't available")
+		}
+
+		testEnv := &envtest.Environment{}
+
+		var err error
+
+		config, err = testEnv.Start()
+		Expect(err).To(Succeed())
+		DeferCleanup(testEnv.Stop)
+
+		client, err = controllerClient.New(config, controllerClient.Options{Scheme: scheme.Scheme})
+		Expect(err).To(Succeed())
+
+		Expect(client.Create(context.Background(), &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: submarinerNamespace},
+		})).To(Succeed())
+	})
+
+	BeforeEach(func() {
+		recorder = record.NewFakeRecorder(10)
+		owner = &v1alpha1.Submariner{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "submariner",
+				Namespace: submarinerNamespace,
+				UID:       types.UID("a3b7c5d1-0f2e-4a6b-9c8d-7e6f5a4b3c2d"),
+			},
+		}
+	})
+
+	applyConfigMap := func(ctx context.Context, configMap *corev1.ConfigMap) *corev1.ConfigMap {
+		actual, err := apply.Apply(ctx, owner, configMap.DeepCopy(), log, client, scheme.Scheme, recorder)
+		Expect(err).To(Succeed())
+
+		return actual
+	}
+
+	newConfigMap := func(name string, data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: submarinerNamespace,
+			},
+			Data: data,
+		}
+	}
+
+	// updateAs updates the live ConfigMap as another field manager would, with a client-side update.
+	updateAs := func(ctx context.Context, manager, name string, mutate func(*corev1.ConfigMap)) {
+		live := &corev1.ConfigMap{}
+		Expect(client.Get(ctx, types.NamespacedName{Namespace: submarinerNamespace, Name: name}, live)).To(Succeed())
+		mutate(live)
+		Expect(client.Update(ctx, live, controllerClient.FieldOwner(manager))).To(Succeed())
+	}
+
+	When("fields are no longer applied", func() {
+		It("should remove them from a ConfigMap", func(ctx SpecContext) {
+			configMap := newConfigMap("removed-fields", map[string]string{"kept": "1", "removed": "2"})
+			configMap.Labels = map[string]string{"app": "test", "removed": "true"}
+			applyConfigMap(ctx, configMap)
+
+			configMap.Labels = map[string]string{"app": "test"}
+			configMap.Data = map[string]string{"kept": "1"}
+
+			actual := applyConfigMap(ctx, configMap)
+			Expect(actual.Data).To(Equal(configMap.Data))
+			Expect(actual.Labels).To(Equal(configMap.Labels))
+		})
+
+		It("should remove them from the list entries of a DaemonSet", func(ctx SpecContext) {
+			labels := map[string]string{"app": "removed-env"}
+			daemonSet := &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "removed-env",
+					Namespace: submarinerNamespace,
+				},
+				Spec: appsv1.DaemonSetSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: labels},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: labels},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{
+								Name:  "test-container",
+								Image: "test-image",
+								Env:   []corev1.EnvVar{{Name: "KEPT", Value: "1"}, {Name: "REMOVED", Value: "2"}},
+							}},
+						},
+					},
+				},
+			}
+
+			_, err := apply.Apply(ctx, owner, daemonSet.DeepCopy(), log, client, scheme.Scheme, recorder)
+			Expect(err).To(Succeed())
+
+			daemonSet.Spec.Template.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "KEPT", Value: "1"}}
+
+			actual, err := apply.Apply(ctx, owner, daemonSet.DeepCopy(), log, client, scheme.Scheme, recorder)
+			Expect(err).To(Succeed())
+			Expect(actual.Spec.Template.Spec.Containers[0].Env).To(Equal(daemonSet.Spec.Template.Spec.Containers[0].Env))
+		})
+	})
+
+	When("fields are managed by another field manager", func() {
+		It("should preserve those the operator doesn't apply", func(ctx SpecContext) {
+			configMap := newConfigMap("other-fields", map[string]string{"applied": "1"})
+			applyConfigMap(ctx, configMap)
+
+			updateAs(ctx, "kubectl-edit", configMap.Name, func(live *corev1.ConfigMap) {
+				live.Data["other"] = "2"
+			})
+
+			actual := applyConfigMap(ctx, configMap)
+			Expect(actual.Data).To(Equal(map[string]string{"applied": "1", "other": "2"}))
+			Expect(recorder.Events).ToNot(Receive())
+		})
+
+		It("should report the conflict and leave those the operator starts applying as they are", func(ctx SpecContext) {
+			configMap := newConfigMap("conflicting-fields", map[string]string{"applied": "1"})
+			applyConfigMap(ctx, configMap)
+
+			updateAs(ctx, "kubectl-edit", configMap.Name, func(live *corev1.ConfigMap) {
+				live.Data["conflicting"] = "edited"
+			})
+
+			configMap.Data["conflicting"] = "2"
+
+			actual := applyConfigMap(ctx, configMap)
+			Expect(actual.Data).To(HaveKeyWithValue("conflicting", "edited"))
+			Expect(recorder.Events).To(Receive(SatisfyAll(ContainSubstring("FieldConflict"),
+				ContainSubstring(".data.conflicting"), ContainSubstring("kubectl-edit"))))
+		})
+
+		It("should take back the ownership of those which drifted from the applied state", func(ctx SpecContext) {
+			configMap := newConfigMap("drifted-fields", map[string]string{"applied": "1"})
+			applyConfigMap(ctx, configMap)
+
+			updateAs(ctx, "kubectl-edit", configMap.Name, func(live *corev1.ConfigMap) {
+				live.Data["applied"] = "edited"
+			})
+
+			actual := applyConfigMap(ctx, configMap)
+			Expect(actual.Data).To(Equal(configMap.Data))
+			Expect(recorder.Events).To(Receive(ContainSubstring("DriftDetected")))
+			Expect(recorder.Events).To(Receive(SatisfyAll(ContainSubstring("FieldConflict"), ContainSubstring(".data.applied"))))
+
+			// The operator owns the field again, so further edits are drift rather than conflicts with a new owner
+			Expect(managerOf(actual.ManagedFields, "f:applied")).To(ConsistOf(apply.FieldManager))
+		})
+	})
+
+	When("a resource was updated client-side by a previous version of the operator", func() {
+		It("should transfer the ownership of its fields to server-side apply and remove those no longer applied",
+			func(ctx SpecContext) {
+				previousConfig := rest.CopyConfig(config)
+				previousConfig.UserAgent = previousUserAgent
+
+				previousClient, err := controllerClient.New(previousConfig, controllerClient.Options{Scheme: scheme.Scheme})
+				Expect(err).To(Succeed())
+
+				// Previous versions created the resources and then updated them, without a field manager
+				configMap := newConfigMap("upgraded-fields", map[string]string{"kept": "1"})
+				Expect(previousClient.Create(ctx, configMap.DeepCopy())).To(Succeed())
+
+				live := &corev1.ConfigMap{}
+				Expect(client.Get(ctx, controllerClient.ObjectKeyFromObject(configMap), live)).To(Succeed())
+				live.Data["removed"] = "2"
+				Expect(previousClient.Update(ctx, live)).To(Succeed())
+
+				Expect(client.Get(ctx, controllerClient.ObjectKeyFromObject(configMap), live)).To(Succeed())
+				Expect(live.ManagedFields).To(ConsistOf(SatisfyAll(
+					HaveField("Manager", apply.FieldManager),
+					HaveField("Operation", metav1.ManagedFieldsOperationUpdate))))
+
+				actual := applyConfigMap(ctx, configMap)
+				Expect(actual.Data).To(Equal(configMap.Data))
+				Expect(actual.ManagedFields).To(ConsistOf(SatisfyAll(
+					HaveField("Manager", apply.FieldManager),
+					HaveField("Operation", metav1.ManagedFieldsOperationApply))))
+				Expect(recorder.Events).ToNot(Receive())
+			})
+	})
+})
+
+// managerOf returns the managers of the given field of the ConfigMap data.
+func managerOf(managedFields []metav1.ManagedFieldsEntry, field string) []string {
+	var managers []string
+
+	for i := range managedFields {
+		if managedFields[i].FieldsV1 == nil {
+			continue
+		}
+
+		fields := map[string]map[string]interface{}{}
+		Expect(json.Unmarshal(managedFields[i].FieldsV1.Raw, &fields)).To(Succeed())
+
+		if _, ok := fields["f:data"][field]; ok {
+			managers = append(managers, managedFields[i].Manager)
+		}
+	}
+
+	return managers
+}
@@ -46,14 +46,12 @@ type ServiceInfo struct {
 func Setup(ctx context.Context, client controllerClient.Client, config *rest.Config, scheme *runtime.Scheme,
 	serviceInfo *ServiceInfo, reqLogger logr.Logger,
 ) error {
-	metricsService, err := apply.Service(ctx, serviceInfo.Owner,
-		newMetricsService(serviceInfo.Name, serviceInfo.Namespace, serviceInfo.ApplicationKey,
-			serviceInfo.ApplicationName, serviceInfo.Port), reqLogger, client, scheme)
+	metricsService, err := apply.Apply(ctx, serviceInfo.Owner, NewService(serviceInfo), reqLogger, client, scheme, nil)
 	if err != nil {
 		return err //nolint:wrapcheck // No need to wrap here
 	}
 
-	if config != nil {
+	if config != nil && !apply.IsPaused(serviceInfo.Owner) {
 		services := []*corev1.Service{metricsService}
 
 		_, err = metrics.CreateServiceMonitors(ctx, config, serviceInfo.Namespace, services)
@@ -71,6 +69,12 @@ func Setup(ctx context.Context, client controllerClient.Client, config *rest.Con
 	return nil
 }
 
+// NewService returns the metrics Service described by the given ServiceInfo, as created by Setup.
+func NewService(serviceInfo *ServiceInfo) *corev1.Service {
+	return newMetricsService(serviceInfo.Name, serviceInfo.Namespace, serviceInfo.ApplicationKey,
+		serviceInfo.ApplicationName, serviceInfo.Port)
+}
+
 // newMetricsService populates a Service providing access to metrics for the given application.
 // The Service is named after the application name, suffixed with "-metrics".
 func newMetricsService(name, namespace, appKey, appName string, port int32) *corev1.Service {
@@ -65,6 +65,12 @@ func (r *Reconciler) doCleanup(ctx context.Context, instance *operatorv1alpha1.S
 		return reconcile.Result{}, err
 	}
 
+	initialStatus := instance.Status.DeepCopy()
+
+	if instance.Status.Uninstall == nil {
+		instance.Status.Uninstall = &operatorv1alpha1.UninstallStatus{}
+	}
+
 	components := []*uninstall.Component{
 		{
 			Resource: &appsv1.Deployment{
@@ -78,10 +84,16 @@ func (r *Reconciler) doCleanup(ctx context.Context, instance *operatorv1alpha1.S
 	}
 
 	uninstallInfo := &uninstall.Info{
-		Client:     r.ScopedClient,
-		Components: components,
-		StartTime:  instance.DeletionTimestamp.Time,
-		Log:        log.Logger,
+		Client:        r.ScopedClient,
+		Components:    components,
+		StartTime:     instance.DeletionTimestamp.Time,
+		Log:           log.Logger,
+		Recorder:      r.EventRecorder,
+		Instance:      instance,
+		Spec:          instance.Spec.Uninstall,
+		Status:        instance.Status.Uninstall,
+		PodLogs:       r.PodLogs,
+		GeneralClient: r.GeneralClient,
 		GetImageInfo: func(imageName, componentName string) (string, corev1.PullPolicy) {
 			return getImagePath(instance, imageName, componentName),
 				images.GetPullPolicy(instance.Spec.Version, instance.Spec.ImageOverrides[componentName])
@@ -89,6 +101,11 @@ func (r *Reconciler) doCleanup(ctx context.Context, instance *operatorv1alpha1.S
 	}
 
 	requeue, _, err := uninstallInfo.Run(ctx)
+
+	if updateErr := r.updateStatus(ctx, instance, initialStatus); updateErr != nil {
+		log.Error(updateErr, "Error updating the uninstall status")
+	}
+
 	if err != nil {
 		return reconcile.Result{}, err //nolint:wrapcheck // No need to wrap
 	}
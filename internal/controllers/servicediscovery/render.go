@@ -0,0 +1,51 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicediscovery
+
+import (
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/names"
+	submarinerv1alpha1 "github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/internal/controllers/metrics"
+	"k8s.io/apimachinery/pkg/runtime"
+	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Render returns the resources the operator deploys for the given ServiceDiscovery resource, built without contacting
+// the apiserver. The changes made to the cluster's DNS configuration aren't included since they depend on the existing
+// DNS configuration and on the IP assigned to the lighthouse DNS Service.
+func Render(instance *submarinerv1alpha1.ServiceDiscovery, scheme *runtime.Scheme) ([]controllerClient.Object, error) {
+	objs := []controllerClient.Object{
+		newLighthouseAgent(instance, names.ServiceDiscoveryComponent),
+		metrics.NewService(lighthouseAgentMetricsServiceInfo(instance)),
+		newLighthouseDNSConfigMap(instance),
+		newLighthouseCoreDNSDeployment(instance),
+		metrics.NewService(lighthouseCoreDNSMetricsServiceInfo(instance)),
+		newLighthouseCoreDNSService(instance),
+	}
+
+	for _, obj := range objs {
+		if err := controllerutil.SetControllerReference(instance, obj, scheme); err != nil {
+			return nil, errors.Wrapf(err, "error setting owner reference for %T %s", obj, obj.GetName())
+		}
+	}
+
+	return objs, nil
+}
@@ -0,0 +1,54 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicediscovery_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/admiral/pkg/names"
+	"github.com/submariner-io/submariner-operator/internal/controllers/servicediscovery"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+var _ = Describe("Render", func() {
+	It("should return the lighthouse resources", func() {
+		serviceDiscovery := newServiceDiscovery()
+
+		objs, err := servicediscovery.Render(serviceDiscovery, scheme.Scheme)
+		Expect(err).To(Succeed())
+
+		objNames := make([]string, len(objs))
+
+		for i, obj := range objs {
+			Expect(obj.GetOwnerReferences()).To(HaveLen(1))
+			Expect(obj.GetOwnerReferences()[0].Name).To(Equal(serviceDiscoveryName))
+
+			objNames[i] = obj.GetName()
+
+			if configMap, ok := obj.(*corev1.ConfigMap); ok {
+				Expect(configMap.Data[servicediscovery.Corefile]).To(ContainSubstring("supercluster.local:53"))
+			}
+		}
+
+		Expect(objNames).To(ConsistOf(names.ServiceDiscoveryComponent, names.ServiceDiscoveryComponent+"-metrics",
+			names.LighthouseCoreDNSComponent, names.LighthouseCoreDNSComponent, names.LighthouseCoreDNSComponent+"-metrics",
+			names.LighthouseCoreDNSComponent))
+	})
+})
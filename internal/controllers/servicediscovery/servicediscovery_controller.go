@@ -40,17 +40,22 @@ import (
 	submarinerv1alpha1 "github.com/submariner-io/submariner-operator/api/v1alpha1"
 	"github.com/submariner-io/submariner-operator/internal/controllers/apply"
 	"github.com/submariner-io/submariner-operator/internal/controllers/metrics"
+	"github.com/submariner-io/submariner-operator/internal/controllers/uninstall"
+	"github.com/submariner-io/submariner-operator/pkg/discovery/deploymentinfo"
 	"github.com/submariner-io/submariner-operator/pkg/httpproxy"
 	"github.com/submariner-io/submariner-operator/pkg/images"
 	opnames "github.com/submariner-io/submariner-operator/pkg/names"
+	"github.com/submariner-io/submariner-operator/pkg/podtemplate"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -72,6 +77,8 @@ const (
 	MicroshiftDNSNamespace        = "openshift-dns"
 	MicroshiftDNSConfigMap        = "dns-default"
 	coreDNSDefaultPort            = "53"
+	reasonCoreDNSConfigUpdated    = "CoreDNSConfigUpdated"
+	reasonPaused                  = "Paused"
 )
 
 // Reconciler reconciles a ServiceDiscovery object.
@@ -83,6 +90,11 @@ type Reconciler struct {
 	GeneralClient controllerClient.Client
 	Scheme        *runtime.Scheme
 	RestConfig    *rest.Config
+	EventRecorder record.EventRecorder
+	// PodLogs reads the logs of the failed uninstall Job pods, it's optional.
+	PodLogs uninstall.PodLogReader
+
+	deploymentInfo *submarinerv1alpha1.DeploymentInfo
 }
 
 // blank assignment to verify that Reconciler implements reconcile.Reconciler.
@@ -123,14 +135,32 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		return r.doCleanup(ctx, instance)
 	}
 
+	initialStatus := instance.Status.DeepCopy()
+
+	r.updateDeploymentInfo(ctx, instance, reqLogger)
+	// Drifted resources are recorded afresh as they're applied
+	instance.Status.DriftDetected = nil
+
+	if instance.IsPaused() {
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:               submarinerv1alpha1.PausedCondition,
+			Status:             metav1.ConditionTrue,
+			Reason:             reasonPaused,
+			Message:            "The reconciliation is paused, the managed resources and the DNS configuration are left as they are",
+			ObservedGeneration: instance.Generation,
+		})
+	} else {
+		meta.RemoveStatusCondition(&instance.Status.Conditions, submarinerv1alpha1.PausedCondition)
+	}
+
 	err = r.ensureLightHouseAgent(ctx, instance, reqLogger)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
 	lighthouseDNSConfigMap := newLighthouseDNSConfigMap(instance)
-	if _, err = apply.ConfigMap(ctx, instance, lighthouseDNSConfigMap, reqLogger,
-		r.ScopedClient, r.Scheme); err != nil {
+	if _, err = apply.Apply(ctx, instance, lighthouseDNSConfigMap, reqLogger,
+		r.ScopedClient, r.Scheme, r.EventRecorder); err != nil {
 		log.Error(err, "Error creating the lighthouseCoreDNS configMap")
 		return reconcile.Result{}, errors.Wrap(err, "error reconciling ConfigMap")
 	}
@@ -145,7 +175,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		return reconcile.Result{}, err
 	}
 
-	if instance.Spec.CoreDNSCustomConfig != nil && instance.Spec.CoreDNSCustomConfig.ConfigMapName != "" {
+	if instance.IsPaused() {
+		reqLogger.Info("ServiceDiscovery is paused, not updating the DNS configuration")
+	} else if instance.Spec.CoreDNSCustomConfig != nil && instance.Spec.CoreDNSCustomConfig.ConfigMapName != "" {
 		err = r.updateDNSCustomConfigMap(ctx, instance, reqLogger)
 		if err != nil {
 			reqLogger.Error(err, "Error updating the 'custom-coredns' ConfigMap")
@@ -155,7 +187,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		err = r.updateDNSConfig(ctx, instance)
 	}
 
-	return reconcile.Result{}, err
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, r.updateStatus(ctx, instance, initialStatus)
 }
 
 func (r *Reconciler) getServiceDiscovery(ctx context.Context, key types.NamespacedName) (*submarinerv1alpha1.ServiceDiscovery, error) {
@@ -169,6 +205,37 @@ func (r *Reconciler) getServiceDiscovery(ctx context.Context, key types.Namespac
 	return instance, nil
 }
 
+func (r *Reconciler) updateStatus(ctx context.Context, instance *submarinerv1alpha1.ServiceDiscovery,
+	initialStatus *submarinerv1alpha1.ServiceDiscoveryStatus,
+) error {
+	if reflect.DeepEqual(instance.Status, *initialStatus) {
+		return nil
+	}
+
+	return errors.Wrap(r.ScopedClient.Status().Update(ctx, instance), "error updating the ServiceDiscovery status")
+}
+
+// updateDeploymentInfo sets the deployment info in the status, it's recorded with the other status changes. Failing to
+// discover it isn't fatal, the previously reported deployment info is kept and the discovery is retried on the next reconcile.
+func (r *Reconciler) updateDeploymentInfo(ctx context.Context, instance *submarinerv1alpha1.ServiceDiscovery, reqLogger logr.Logger) {
+	// The deployment info doesn't change during the lifetime of the operator so reuse a previous discovery
+	if r.deploymentInfo == nil {
+		serverVersion, err := deploymentinfo.NewServerVersionClient(r.RestConfig)
+		if err != nil {
+			reqLogger.Error(err, "Error creating the apiserver version client")
+			return
+		}
+
+		r.deploymentInfo, err = deploymentinfo.Discover(ctx, r.GeneralClient, serverVersion)
+		if err != nil {
+			reqLogger.Error(err, "Error discovering the deployment info")
+			return
+		}
+	}
+
+	instance.Status.DeploymentInfo = *r.deploymentInfo
+}
+
 func (r *Reconciler) addFinalizer(ctx context.Context,
 	instance *submarinerv1alpha1.ServiceDiscovery,
 ) (*submarinerv1alpha1.ServiceDiscovery, error) {
@@ -211,7 +278,7 @@ func newLighthouseAgent(cr *submarinerv1alpha1.ServiceDiscovery, name string) *a
 		})
 	}
 
-	return &appsv1.Deployment{
+	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: cr.Namespace,
 			Name:      name,
@@ -252,6 +319,7 @@ func newLighthouseAgent(cr *submarinerv1alpha1.ServiceDiscovery, name string) *a
 					},
 
 					ServiceAccountName:            "submariner-lighthouse-agent",
+					ImagePullSecrets:              cr.Spec.ImagePullSecrets,
 					Tolerations:                   cr.Spec.Tolerations,
 					NodeSelector:                  cr.Spec.NodeSelector,
 					TerminationGracePeriodSeconds: ptr.To(int64(0)),
@@ -260,6 +328,10 @@ func newLighthouseAgent(cr *submarinerv1alpha1.ServiceDiscovery, name string) *a
 			},
 		},
 	}
+
+	podtemplate.ApplyOverrides(&deployment.Spec.Template, cr.Spec.Components, names.ServiceDiscoveryComponent)
+
+	return deployment
 }
 
 func newLighthouseDNSConfigMap(cr *submarinerv1alpha1.ServiceDiscovery) *corev1.ConfigMap {
@@ -310,7 +382,7 @@ func newLighthouseCoreDNSDeployment(cr *submarinerv1alpha1.ServiceDiscovery) *ap
 		"app": names.LighthouseCoreDNSComponent,
 	}
 
-	return &appsv1.Deployment{
+	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: cr.Namespace,
 			Name:      names.LighthouseCoreDNSComponent,
@@ -353,6 +425,7 @@ func newLighthouseCoreDNSDeployment(cr *submarinerv1alpha1.ServiceDiscovery) *ap
 					},
 
 					ServiceAccountName:            "submariner-lighthouse-coredns",
+					ImagePullSecrets:              cr.Spec.ImagePullSecrets,
 					TerminationGracePeriodSeconds: ptr.To(int64(0)),
 					Tolerations:                   cr.Spec.Tolerations,
 					NodeSelector:                  cr.Spec.NodeSelector,
@@ -369,6 +442,10 @@ func newLighthouseCoreDNSDeployment(cr *submarinerv1alpha1.ServiceDiscovery) *ap
 			},
 		},
 	}
+
+	podtemplate.ApplyOverrides(&deployment.Spec.Template, cr.Spec.Components, names.LighthouseCoreDNSComponent)
+
+	return deployment
 }
 
 func newLighthouseCoreDNSService(cr *submarinerv1alpha1.ServiceDiscovery) *corev1.Service {
@@ -472,6 +549,11 @@ func (r *Reconciler) updateDNSConfig(ctx context.Context, cr *submarinerv1alpha1
 		return goerrors.New("the lighthouse DNS Service ClusterIP is not set")
 	}
 
+	// OpenShift manages the cluster DNS via its DNS operator
+	if cr.Status.DeploymentInfo.KubernetesType == submarinerv1alpha1.OCP {
+		return r.updateLighthouseConfigInOpenshiftDNSOperator(ctx, cr, lighthouseDNSService.Spec.ClusterIP)
+	}
+
 	err = r.updateLighthouseConfigInConfigMap(ctx, cr, DefaultCoreDNSNamespace, CoreDNSName, lighthouseDNSService.Spec.ClusterIP)
 
 	if apierrors.IsNotFound(err) {
@@ -506,6 +588,8 @@ func (r *Reconciler) updateDNSConfig(ctx context.Context, cr *submarinerv1alpha1
 func (r *Reconciler) updateLighthouseConfigInConfigMap(ctx context.Context, cr *submarinerv1alpha1.ServiceDiscovery,
 	configMapNamespace, configMapName, clusterIP string,
 ) error {
+	updated := false
+
 	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: configMapNamespace, Name: configMapName}}
 	err := util.MustUpdate[*corev1.ConfigMap](ctx, resource.ForControllerClient(r.GeneralClient, configMap.Namespace, configMap), configMap,
 		func(existing *corev1.ConfigMap) (*corev1.ConfigMap, error) {
@@ -553,12 +637,21 @@ func (r *Reconciler) updateLighthouseConfigInConfigMap(ctx context.Context, cr *
 
 			log.Infof("Updated coredns ConfigMap \"%s/%s\": %s", configMapNamespace, configMapName, coreFile)
 
+			updated = existing.Data[Corefile] != coreFile
 			existing.Data[Corefile] = coreFile
 
 			return existing, nil
 		})
+	if err != nil {
+		return errors.Wrap(err, "error updating DNS ConfigMap")
+	}
+
+	if updated {
+		r.EventRecorder.Eventf(cr, corev1.EventTypeNormal, reasonCoreDNSConfigUpdated,
+			"Updated the lighthouse configuration in the CoreDNS ConfigMap %s/%s", configMapNamespace, configMapName)
+	}
 
-	return errors.Wrap(err, "error updating DNS ConfigMap")
+	return nil
 }
 
 func findCoreDNSListeningPort(coreFile string) string {
@@ -677,8 +770,11 @@ func getUpdatedForwardServers(instance *submarinerv1alpha1.ServiceDiscovery, dns
 }
 
 func getImagePath(submariner *submarinerv1alpha1.ServiceDiscovery, imageName, componentName string) string {
-	return images.GetImagePath(submariner.Spec.Repository, submariner.Spec.Version, imageName, componentName,
+	path := images.GetImagePath(submariner.Spec.Repository, submariner.Spec.Version, imageName, componentName,
 		submariner.Spec.ImageOverrides)
+
+	return images.ApplyRegistryMirrors(images.PinDigest(path, submariner.Spec.ImageDigests[componentName]),
+		submariner.Spec.RegistryMirrors)
 }
 
 //nolint:wrapcheck // No need to wrap errors here.
@@ -692,28 +788,22 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Named("servicediscovery-controller").
 		// Watch for changes to primary resource ServiceDiscovery
 		For(&submarinerv1alpha1.ServiceDiscovery{}).
-		// Watch for changes to secondary resource Deployment and requeue the owner ServiceDiscovery
+		// Watch for changes to secondary resources Deployments, ConfigMaps and Services and requeue the owner ServiceDiscovery
 		Owns(&appsv1.Deployment{}).
+		Owns(&corev1.ConfigMap{}).
+		Owns(&corev1.Service{}).
 		Complete(r)
 }
 
 func (r *Reconciler) ensureLightHouseAgent(ctx context.Context, instance *submarinerv1alpha1.ServiceDiscovery, reqLogger logr.Logger,
 ) error {
 	lightHouseAgent := newLighthouseAgent(instance, names.ServiceDiscoveryComponent)
-	if _, err := apply.Deployment(ctx, instance, lightHouseAgent, reqLogger,
-		r.ScopedClient, r.Scheme); err != nil {
+	if _, err := apply.Apply(ctx, instance, lightHouseAgent, reqLogger,
+		r.ScopedClient, r.Scheme, r.EventRecorder); err != nil {
 		return errors.Wrap(err, "error reconciling agent deployment")
 	}
 
-	err := metrics.Setup(ctx, r.ScopedClient, r.RestConfig, r.Scheme,
-		&metrics.ServiceInfo{
-			Name:            names.ServiceDiscoveryComponent,
-			Namespace:       instance.Namespace,
-			ApplicationKey:  "app",
-			ApplicationName: names.ServiceDiscoveryComponent,
-			Owner:           instance,
-			Port:            8082,
-		}, reqLogger)
+	err := metrics.Setup(ctx, r.ScopedClient, r.RestConfig, r.Scheme, lighthouseAgentMetricsServiceInfo(instance), reqLogger)
 	if err != nil {
 		return errors.Wrap(err, "error setting up metrics")
 	}
@@ -725,21 +815,13 @@ func (r *Reconciler) ensureLighthouseCoreDNSDeployment(ctx context.Context, inst
 	reqLogger logr.Logger,
 ) error {
 	lighthouseCoreDNSDeployment := newLighthouseCoreDNSDeployment(instance)
-	if _, err := apply.Deployment(ctx, instance, lighthouseCoreDNSDeployment, reqLogger,
-		r.ScopedClient, r.Scheme); err != nil {
+	if _, err := apply.Apply(ctx, instance, lighthouseCoreDNSDeployment, reqLogger,
+		r.ScopedClient, r.Scheme, r.EventRecorder); err != nil {
 		log.Error(err, "Error creating the lighthouseCoreDNS deployment")
 		return errors.Wrap(err, "error reconciling coredns deployment")
 	}
 
-	err := metrics.Setup(ctx, r.ScopedClient, r.RestConfig, r.Scheme,
-		&metrics.ServiceInfo{
-			Name:            names.LighthouseCoreDNSComponent,
-			Namespace:       instance.Namespace,
-			ApplicationKey:  "app",
-			ApplicationName: names.LighthouseCoreDNSComponent,
-			Owner:           instance,
-			Port:            9153,
-		}, reqLogger)
+	err := metrics.Setup(ctx, r.ScopedClient, r.RestConfig, r.Scheme, lighthouseCoreDNSMetricsServiceInfo(instance), reqLogger)
 	if err != nil {
 		return errors.Wrap(err, "error setting up coredns metrics")
 	}
@@ -756,8 +838,8 @@ func (r *Reconciler) ensureLighthouseCoreDNSService(ctx context.Context, instanc
 		lighthouseCoreDNSService)
 	if apierrors.IsNotFound(err) {
 		lighthouseCoreDNSService = newLighthouseCoreDNSService(instance)
-		if _, err = apply.Service(ctx, instance, lighthouseCoreDNSService, reqLogger,
-			r.ScopedClient, r.Scheme); err != nil {
+		if _, err = apply.Apply(ctx, instance, lighthouseCoreDNSService, reqLogger,
+			r.ScopedClient, r.Scheme, r.EventRecorder); err != nil {
 			log.Error(err, "Error creating the lighthouseCoreDNS service")
 
 			return errors.Wrap(err, "error reconciling coredns Service")
@@ -767,6 +849,28 @@ func (r *Reconciler) ensureLighthouseCoreDNSService(ctx context.Context, instanc
 	return nil
 }
 
+func lighthouseAgentMetricsServiceInfo(instance *submarinerv1alpha1.ServiceDiscovery) *metrics.ServiceInfo {
+	return &metrics.ServiceInfo{
+		Name:            names.ServiceDiscoveryComponent,
+		Namespace:       instance.Namespace,
+		ApplicationKey:  "app",
+		ApplicationName: names.ServiceDiscoveryComponent,
+		Owner:           instance,
+		Port:            8082,
+	}
+}
+
+func lighthouseCoreDNSMetricsServiceInfo(instance *submarinerv1alpha1.ServiceDiscovery) *metrics.ServiceInfo {
+	return &metrics.ServiceInfo{
+		Name:            names.LighthouseCoreDNSComponent,
+		Namespace:       instance.Namespace,
+		ApplicationKey:  "app",
+		ApplicationName: names.LighthouseCoreDNSComponent,
+		Owner:           instance,
+		Port:            9153,
+	}
+}
+
 func buildDomains(s *submarinerv1alpha1.ServiceDiscovery) []string {
 	return append([]string{"clusterset.local"}, s.Spec.CustomDomains...)
 }
@@ -28,7 +28,9 @@ import (
 	"github.com/submariner-io/submariner-operator/internal/controllers/servicediscovery"
 	opnames "github.com/submariner-io/submariner-operator/pkg/names"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 var _ = Describe("Service discovery controller", func() {
@@ -76,6 +78,27 @@ func testReconciliation() {
 			})
 		})
 
+		Context("and the cluster is detected as OpenShift", func() {
+			BeforeEach(func() {
+				t.InitScopedClientObjs = append(t.InitScopedClientObjs, newDNSService(clusterIP))
+				t.InitGeneralClientObjs = append(t.InitGeneralClientObjs, newDNSConfig(""), newClusterVersion(),
+					newCoreDNSConfigMap(coreDNSCorefileData("")))
+			})
+
+			It("should add the lighthouse config to the DNS operator only and report the deployment info", func(ctx SpecContext) {
+				t.AssertReconcileSuccess(ctx)
+
+				assertDNSConfigServers(t.assertDNSConfig(ctx), newDNSConfig(clusterIP))
+				Expect(getCorefileData(t.assertCoreDNSConfigMap(ctx))).To(Equal(coreDNSCorefileData("")))
+
+				serviceDiscovery := &submariner_v1.ServiceDiscovery{}
+				Expect(t.ScopedClient.Get(ctx, types.NamespacedName{Name: serviceDiscoveryName, Namespace: submarinerNamespace},
+					serviceDiscovery)).To(Succeed())
+				Expect(serviceDiscovery.Status.DeploymentInfo.KubernetesType).To(BeEquivalentTo(submariner_v1.OCP))
+				Expect(serviceDiscovery.Status.DeploymentInfo.KubernetesTypeVersion).To(Equal("4.16.3"))
+			})
+		})
+
 		Context("and the lighthouse DNS service doesn't exist", func() {
 			BeforeEach(func() {
 				t.InitGeneralClientObjs = append(t.InitGeneralClientObjs, newDNSConfig(""))
@@ -104,6 +127,7 @@ func testReconciliation() {
 				t.AssertReconcileSuccess(ctx)
 
 				Expect(getCorefileData(t.assertCoreDNSConfigMap(ctx))).To(Equal(coreDNSCorefileData(clusterIP)))
+				Expect(t.recorder.Events).To(Receive(ContainSubstring("CoreDNSConfigUpdated")))
 			})
 		})
 
@@ -139,6 +163,60 @@ func testReconciliation() {
 		})
 	})
 
+	When("the lighthouse DNS ConfigMap was modified outside the operator", func() {
+		BeforeEach(func() {
+			t.serviceDiscovery.Spec.DriftPolicy = submariner_v1.DriftReportOnly
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, newDNSService(clusterIP))
+			t.InitGeneralClientObjs = append(t.InitGeneralClientObjs, newCoreDNSConfigMap(coreDNSCorefileData("")))
+		})
+
+		It("should report the drift in the status and leave the ConfigMap as is", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			configMap := &corev1.ConfigMap{}
+			key := types.NamespacedName{Name: names.LighthouseCoreDNSComponent, Namespace: submarinerNamespace}
+			Expect(t.ScopedClient.Get(ctx, key, configMap)).To(Succeed())
+
+			configMap.Data[servicediscovery.Corefile] = "edited"
+			Expect(t.ScopedClient.Update(ctx, configMap)).To(Succeed())
+
+			t.AssertReconcileSuccess(ctx)
+
+			Expect(t.ScopedClient.Get(ctx, key, configMap)).To(Succeed())
+			Expect(configMap.Data[servicediscovery.Corefile]).To(Equal("edited"))
+
+			serviceDiscovery := &submariner_v1.ServiceDiscovery{}
+			Expect(t.ScopedClient.Get(ctx, types.NamespacedName{Name: serviceDiscoveryName, Namespace: submarinerNamespace},
+				serviceDiscovery)).To(Succeed())
+			Expect(serviceDiscovery.Status.DriftDetected).To(Equal([]submariner_v1.DriftedResource{{
+				Kind:      "ConfigMap",
+				Namespace: submarinerNamespace,
+				Name:      names.LighthouseCoreDNSComponent,
+				Fields:    []string{"data.Corefile"},
+			}}))
+		})
+	})
+
+	When("the ServiceDiscovery resource is paused", func() {
+		BeforeEach(func() {
+			t.serviceDiscovery.Spec.Paused = true
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, newDNSService(clusterIP))
+			t.InitGeneralClientObjs = append(t.InitGeneralClientObjs, newCoreDNSConfigMap(coreDNSCorefileData("")))
+		})
+
+		It("should report the Paused condition and leave the DNS configuration as is", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			Expect(getCorefileData(t.assertCoreDNSConfigMap(ctx))).To(Equal(coreDNSCorefileData("")))
+			t.AssertNoDeployment(ctx, names.ServiceDiscoveryComponent)
+
+			serviceDiscovery := &submariner_v1.ServiceDiscovery{}
+			Expect(t.ScopedClient.Get(ctx, types.NamespacedName{Name: serviceDiscoveryName, Namespace: submarinerNamespace},
+				serviceDiscovery)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(serviceDiscovery.Status.Conditions, submariner_v1.PausedCondition)).To(BeTrue())
+		})
+	})
+
 	When("a ConfigMap exists with a non-standard coredns name", func() {
 		nonStandardName := "rke2-coredns-rke2-coredns"
 
@@ -26,6 +26,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
 	"github.com/submariner-io/admiral/pkg/log/kzerolog"
 	"github.com/submariner-io/admiral/pkg/names"
@@ -38,6 +39,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -60,6 +62,7 @@ supercluster.local:53 {
 var _ = BeforeSuite(func() {
 	Expect(v1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
 	Expect(operatorv1.Install(scheme.Scheme)).To(Succeed())
+	Expect(configv1.Install(scheme.Scheme)).To(Succeed())
 })
 
 var _ = Describe("", func() {
@@ -74,6 +77,7 @@ func TestSubmariner(t *testing.T) {
 type testDriver struct {
 	test.Driver
 	serviceDiscovery *v1alpha1.ServiceDiscovery
+	recorder         *record.FakeRecorder
 }
 
 func newTestDriver() *testDriver {
@@ -87,6 +91,7 @@ func newTestDriver() *testDriver {
 	BeforeEach(func() {
 		t.BeforeEach()
 		t.serviceDiscovery = newServiceDiscovery()
+		t.recorder = record.NewFakeRecorder(100)
 		t.InitScopedClientObjs = []controllerClient.Object{t.serviceDiscovery}
 	})
 
@@ -97,6 +102,7 @@ func newTestDriver() *testDriver {
 			ScopedClient:  t.ScopedClient,
 			GeneralClient: t.GeneralClient,
 			Scheme:        scheme.Scheme,
+			EventRecorder: t.recorder,
 		}
 	})
 
@@ -197,6 +203,17 @@ func newDNSConfig(clusterIP string) *operatorv1.DNS {
 	return dns
 }
 
+func newClusterVersion() *configv1.ClusterVersion {
+	return &configv1.ClusterVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "version",
+		},
+		Status: configv1.ClusterVersionStatus{
+			Desired: configv1.Release{Version: "4.16.3"},
+		},
+	}
+}
+
 func newServiceDiscovery() *v1alpha1.ServiceDiscovery {
 	return &v1alpha1.ServiceDiscovery{
 		ObjectMeta: metav1.ObjectMeta{
@@ -23,22 +23,29 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/cidrallocation"
 	"github.com/submariner-io/submariner-operator/pkg/crd"
 	"github.com/submariner-io/submariner-operator/pkg/discovery/clustersetip"
 	"github.com/submariner-io/submariner-operator/pkg/discovery/globalnet"
 	"github.com/submariner-io/submariner-operator/pkg/gateway"
 	"github.com/submariner-io/submariner-operator/pkg/lighthouse"
+	submv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 // BrokerReconciler reconciles a Broker object.
 type BrokerReconciler struct {
-	Client client.Client
-	Config *rest.Config
+	Client   client.Client
+	Config   *rest.Config
+	Recorder record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=submariner.io,resources=brokers,verbs=get;list;watch;create;update;patch;delete
@@ -68,50 +75,104 @@ func (r *BrokerReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		return reconcile.Result{}, nil
 	}
 
+	err = r.ensureBrokerResources(ctx, instance)
+	if err != nil {
+		r.Recorder.Event(instance, corev1.EventTypeWarning, reasonReconcileFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	departedClusters, requeueAfter, err := r.releaseDepartedClusters(ctx, instance)
+	if err != nil {
+		r.Recorder.Event(instance, corev1.EventTypeWarning, reasonReconcileFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	err = r.updateStatus(ctx, instance, departedClusters)
+	if apierrors.IsConflict(err) {
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, err
+}
+
+// ensureBrokerResources deploys the broker CRDs and the globalnet and clusterset IP ConfigMaps, and migrates the CIDR
+// allocations recorded in the ConfigMaps.
+func (r *BrokerReconciler) ensureBrokerResources(ctx context.Context, instance *v1alpha1.Broker) error {
 	// Broker CRDs
 	crdUpdater := crd.UpdaterFromControllerClient(r.Client)
 
-	err = gateway.Ensure(ctx, crdUpdater)
+	err := gateway.Ensure(ctx, crdUpdater)
 	if err != nil {
-		return ctrl.Result{}, err //nolint:wrapcheck // Errors are already wrapped
+		return err //nolint:wrapcheck // Errors are already wrapped
 	}
 
 	// Lighthouse CRDs
 	_, err = lighthouse.Ensure(ctx, crdUpdater, lighthouse.BrokerCluster)
 	if err != nil {
-		return ctrl.Result{}, err //nolint:wrapcheck // Errors are already wrapped
+		return err //nolint:wrapcheck // Errors are already wrapped
+	}
+
+	err = cidrallocation.Ensure(ctx, crdUpdater)
+	if err != nil {
+		return err //nolint:wrapcheck // Errors are already wrapped
 	}
 
 	// Globalnet
-	err = globalnet.ValidateExistingGlobalNetworks(ctx, r.Client, request.Namespace)
+	err = globalnet.ValidateExistingGlobalNetworks(ctx, r.Client, instance.Namespace)
 	if err != nil {
-		return ctrl.Result{}, err //nolint:wrapcheck // Errors are already wrapped
+		return err //nolint:wrapcheck // Errors are already wrapped
 	}
 
 	err = globalnet.CreateConfigMap(ctx, r.Client, instance.Spec.GlobalnetEnabled, instance.Spec.GlobalnetCIDRRange,
-		instance.Spec.DefaultGlobalnetClusterSize, request.Namespace)
+		instance.Spec.DefaultGlobalnetClusterSize, instance.Spec.DefaultGlobalnetIPv6ClusterSize, instance.Namespace)
 	if err != nil {
-		return ctrl.Result{}, err //nolint:wrapcheck // Errors are already wrapped
+		return err //nolint:wrapcheck // Errors are already wrapped
 	}
 
 	// clustersetip
-	err = clustersetip.ValidateExistingClustersetIPNetworks(ctx, r.Client, request.Namespace)
+	err = clustersetip.ValidateExistingClustersetIPNetworks(ctx, r.Client, instance.Namespace)
 	if err != nil {
-		return ctrl.Result{}, err //nolint:wrapcheck // Errors are already wrapped
+		return err //nolint:wrapcheck // Errors are already wrapped
 	}
 
 	err = clustersetip.CreateConfigMap(ctx, r.Client, instance.Spec.ClustersetIPEnabled,
-		instance.Spec.ClustersetIPCIDRRange, 0, request.Namespace)
+		instance.Spec.ClustersetIPCIDRRange, 0, instance.Namespace)
 	if err != nil {
-		return ctrl.Result{}, err //nolint:wrapcheck // Errors are already wrapped
+		return err //nolint:wrapcheck // Errors are already wrapped
 	}
 
-	return ctrl.Result{}, nil
+	return r.migrateAllocations(ctx, instance.Namespace)
+}
+
+// migrateAllocations copies the CIDR allocations recorded in the globalnet and clusterset IP ConfigMaps by older
+// versions to CIDRAllocation resources, and mirrors the CIDRAllocations in the ConfigMaps for those versions.
+func (r *BrokerReconciler) migrateAllocations(ctx context.Context, namespace string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error { //nolint:wrapcheck // Errors are already wrapped
+		globalnetConfigMap, err := globalnet.GetConfigMap(ctx, r.Client, namespace)
+		if err != nil {
+			return errors.Wrap(err, "error retrieving the globalnet ConfigMap")
+		}
+
+		err = cidrallocation.Migrate(ctx, r.Client, globalnetConfigMap, v1alpha1.GlobalnetPool)
+		if err != nil {
+			return err //nolint:wrapcheck // Errors are already wrapped
+		}
+
+		clustersetIPConfigMap, err := clustersetip.GetConfigMap(ctx, r.Client, namespace)
+		if err != nil {
+			return errors.Wrap(err, "error retrieving the clusterset IP ConfigMap")
+		}
+
+		return cidrallocation.Migrate(ctx, r.Client, clustersetIPConfigMap, v1alpha1.ClustersetIPPool) //nolint:wrapcheck // Errors are already wrapped
+	})
 }
 
 //nolint:wrapcheck // No need to wrap here.
 func (r *BrokerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.Broker{}).
+		// Watch for clusters joining or leaving to update the status of the Brokers in the same namespace and track the
+		// departed clusters
+		Watches(&submv1.Cluster{}, handler.EnqueueRequestsFromMapFunc(r.brokersInNamespace)).
 		Complete(r)
 }
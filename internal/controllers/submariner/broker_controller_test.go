@@ -19,14 +19,20 @@ limitations under the License.
 package submariner_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/submariner-io/submariner-operator/api/v1alpha1"
 	submarinerController "github.com/submariner-io/submariner-operator/internal/controllers/submariner"
 	"github.com/submariner-io/submariner-operator/internal/controllers/test"
+	"github.com/submariner-io/submariner-operator/pkg/cidr"
+	"github.com/submariner-io/submariner-operator/pkg/cidrallocation"
 	"github.com/submariner-io/submariner-operator/pkg/discovery/globalnet"
+	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -61,7 +67,8 @@ var _ = Describe("Broker controller tests", func() {
 		t.JustBeforeEach()
 
 		t.Controller = &submarinerController.BrokerReconciler{
-			Client: t.ScopedClient,
+			Client:   t.ScopedClient,
+			Recorder: record.NewFakeRecorder(10),
 		}
 	})
 
@@ -74,6 +81,31 @@ var _ = Describe("Broker controller tests", func() {
 		Expect(globalnetInfo.AllocationSize).To(Equal(broker.Spec.DefaultGlobalnetClusterSize))
 	})
 
+	When("the Globalnet CIDR range is dual-stack", func() {
+		BeforeEach(func() {
+			broker.Spec.GlobalnetCIDRRange = "168.254.0.0/16,fd00:242::/96"
+			broker.Spec.DefaultGlobalnetIPv6ClusterSize = 1 << 20
+		})
+
+		It("should report the usage of each IP family with its cluster size in the status", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			Expect(t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(broker), broker)).To(Succeed())
+			Expect(broker.Status.Globalnet).NotTo(BeNil())
+			Expect(broker.Status.Globalnet.CIDR).To(Equal("168.254.0.0/16"))
+			Expect(broker.Status.Globalnet.FreeAllocations).To(Equal(uint64(8)))
+			Expect(broker.Status.Globalnet.IPv6).To(Equal(&v1alpha1.CIDRRangeStatus{
+				CIDR:             "fd00:242::/96",
+				AllocationSize:   1 << 20,
+				FreeAddresses:    1 << 32,
+				FreeAllocations:  1 << 12,
+				FreeBlockCount:   1,
+				FreeBlocks:       []string{"fd00:242::/96"},
+				LargestFreeBlock: "fd00:242::/96",
+			}))
+		})
+	})
+
 	It("should create the CRDs", func(ctx SpecContext) {
 		t.AssertReconcileSuccess(ctx)
 
@@ -82,6 +114,119 @@ var _ = Describe("Broker controller tests", func() {
 		Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: "endpoints.submariner.io"}, crd)).To(Succeed())
 		Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: "gateways.submariner.io"}, crd)).To(Succeed())
 		Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: "serviceimports.multicluster.x-k8s.io"}, crd)).To(Succeed())
+		Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: "cidrallocations.submariner.io"}, crd)).To(Succeed())
+	})
+
+	When("clusters have joined the broker", func() {
+		BeforeEach(func() {
+			globalnetConfigMap, err := globalnet.NewGlobalnetConfigMap(true, broker.Spec.GlobalnetCIDRRange,
+				broker.Spec.DefaultGlobalnetClusterSize, 0, submarinerNamespace)
+			Expect(err).To(Succeed())
+			Expect(cidr.AddClusterInfoData(globalnetConfigMap, cidr.ClusterInfo{
+				ClusterID: "east",
+				CIDRs:     []string{"168.254.0.0/19"},
+			})).To(Succeed())
+
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, globalnetConfigMap, newCluster("west"), newCluster("east"))
+		})
+
+		It("should report the clusters and the CIDR allocations in the status", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			Expect(t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(broker), broker)).To(Succeed())
+			Expect(broker.Status.Clusters).To(HaveLen(2))
+			Expect(broker.Status.Clusters[0].ClusterID).To(Equal("east"))
+			Expect(broker.Status.Clusters[0].ServiceCIDRs).To(Equal([]string{"10.96.0.0/16"}))
+			Expect(broker.Status.Clusters[1].ClusterID).To(Equal("west"))
+
+			Expect(broker.Status.Globalnet).NotTo(BeNil())
+			Expect(*broker.Status.Globalnet).To(Equal(v1alpha1.CIDRAllocationStatus{
+				Enabled: true,
+				CIDRRangeStatus: v1alpha1.CIDRRangeStatus{
+					CIDR:             broker.Spec.GlobalnetCIDRRange,
+					AllocationSize:   broker.Spec.DefaultGlobalnetClusterSize,
+					FreeAddresses:    65536 - 8192,
+					FreeAllocations:  7,
+					FreeBlockCount:   3,
+					FreeBlocks:       []string{"168.254.32.0/19", "168.254.64.0/18", "168.254.128.0/17"},
+					LargestFreeBlock: "168.254.128.0/17",
+				},
+				Allocations: []v1alpha1.ClusterCIDRAllocation{{
+					ClusterID: "east",
+					CIDRs:     []string{"168.254.0.0/19"},
+				}},
+			}))
+
+			Expect(broker.Status.ClustersetIP).NotTo(BeNil())
+			Expect(broker.Status.ClustersetIP.Enabled).To(BeFalse())
+			Expect(broker.Status.ClustersetIP.Allocations).To(BeEmpty())
+		})
+
+		It("should copy the CIDR allocations recorded in the ConfigMap to CIDRAllocations", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			allocation := &v1alpha1.CIDRAllocation{}
+			Expect(t.ScopedClient.Get(ctx, client.ObjectKey{
+				Namespace: submarinerNamespace,
+				Name:      cidrallocation.Name(v1alpha1.GlobalnetPool, "east"),
+			}, allocation)).To(Succeed())
+			Expect(allocation.Spec.CIDRs).To(Equal([]string{"168.254.0.0/19"}))
+
+			globalnetConfigMap, err := globalnet.GetConfigMap(ctx, t.ScopedClient, submarinerNamespace)
+			Expect(err).To(Succeed())
+			Expect(globalnetConfigMap.Data).To(HaveKey(cidr.ClusterInfoKey))
+		})
+	})
+
+	When("a cluster with CIDR allocations has departed", func() {
+		BeforeEach(func() {
+			globalnetConfigMap, err := globalnet.NewGlobalnetConfigMap(true, broker.Spec.GlobalnetCIDRRange,
+				broker.Spec.DefaultGlobalnetClusterSize, 0, submarinerNamespace)
+			Expect(err).To(Succeed())
+
+			for _, info := range []cidr.ClusterInfo{
+				{ClusterID: "east", CIDRs: []string{"168.254.0.0/19"}},
+				{ClusterID: "west", CIDRs: []string{"168.254.32.0/19"}},
+			} {
+				Expect(cidr.AddClusterInfoData(globalnetConfigMap, info)).To(Succeed())
+			}
+
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, globalnetConfigMap, newCluster("east"))
+		})
+
+		Context("and the grace period hasn't expired", func() {
+			It("should keep its allocations and report it in the status", func(ctx SpecContext) {
+				r, err := t.DoReconcile(ctx)
+				Expect(err).To(Succeed())
+				Expect(r.RequeueAfter).To(BeNumerically("~", submarinerController.DefaultDepartedClusterGracePeriod, time.Minute))
+
+				Expect(t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(broker), broker)).To(Succeed())
+				Expect(broker.Status.DepartedClusters).To(HaveLen(1))
+				Expect(broker.Status.DepartedClusters[0].ClusterID).To(Equal("west"))
+
+				globalnetInfo, _, err := globalnet.GetGlobalNetworks(ctx, t.ScopedClient, submarinerNamespace)
+				Expect(err).To(Succeed())
+				Expect(globalnetInfo.Clusters).To(HaveKey("west"))
+			})
+		})
+
+		Context("and the grace period has expired", func() {
+			BeforeEach(func() {
+				broker.Spec.DepartedClusterGracePeriod = &metav1.Duration{}
+			})
+
+			It("should release its allocations", func(ctx SpecContext) {
+				t.AssertReconcileSuccess(ctx)
+
+				Expect(t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(broker), broker)).To(Succeed())
+				Expect(broker.Status.DepartedClusters).To(BeEmpty())
+
+				globalnetInfo, _, err := globalnet.GetGlobalNetworks(ctx, t.ScopedClient, submarinerNamespace)
+				Expect(err).To(Succeed())
+				Expect(globalnetInfo.Clusters).To(HaveLen(1))
+				Expect(globalnetInfo.Clusters).To(HaveKey("east"))
+			})
+		})
 	})
 
 	When("the Broker resource doesn't exist", func() {
@@ -94,3 +239,17 @@ var _ = Describe("Broker controller tests", func() {
 		})
 	})
 })
+
+func newCluster(clusterID string) *submarinerv1.Cluster {
+	return &submarinerv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterID,
+			Namespace: submarinerNamespace,
+		},
+		Spec: submarinerv1.ClusterSpec{
+			ClusterID:   clusterID,
+			ServiceCIDR: []string{"10.96.0.0/16"},
+			ClusterCIDR: []string{"10.244.0.0/16"},
+		},
+	}
+}
@@ -0,0 +1,143 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"context"
+	"time"
+
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/discovery/clustersetip"
+	"github.com/submariner-io/submariner-operator/pkg/discovery/globalnet"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// DefaultDepartedClusterGracePeriod is how long the CIDRs allocated to a departed cluster are kept by default.
+const DefaultDepartedClusterGracePeriod = 24 * time.Hour
+
+const reasonAllocationsReleased = "AllocationsReleased"
+
+// releaseDepartedClusters releases the CIDRs allocated to the clusters whose Cluster resource has been gone for longer
+// than the grace period. It returns the departed clusters still within the grace period and the time until the first of
+// them expires, if any.
+func (r *BrokerReconciler) releaseDepartedClusters(ctx context.Context, instance *v1alpha1.Broker,
+) ([]v1alpha1.DepartedCluster, time.Duration, error) {
+	clusters, err := r.getClusters(ctx, instance.Namespace)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	registered := sets.New[string]()
+	for i := range clusters {
+		registered.Insert(clusters[i].ClusterID)
+	}
+
+	allocated, err := r.allocatedClusterIDs(ctx, instance.Namespace)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	gracePeriod := DefaultDepartedClusterGracePeriod
+	if instance.Spec.DepartedClusterGracePeriod != nil {
+		gracePeriod = instance.Spec.DepartedClusterGracePeriod.Duration
+	}
+
+	departedSince := map[string]metav1.Time{}
+	for _, departed := range instance.Status.DepartedClusters {
+		departedSince[departed.ClusterID] = departed.DepartedSince
+	}
+
+	now := metav1.Now()
+
+	var departedClusters []v1alpha1.DepartedCluster
+
+	var requeueAfter time.Duration
+
+	for _, clusterID := range sets.List(allocated.Difference(registered)) {
+		since, ok := departedSince[clusterID]
+		if !ok {
+			since = now
+		}
+
+		remaining := gracePeriod - now.Sub(since.Time)
+		if remaining > 0 {
+			departedClusters = append(departedClusters, v1alpha1.DepartedCluster{ClusterID: clusterID, DepartedSince: since})
+
+			if requeueAfter == 0 || remaining < requeueAfter {
+				requeueAfter = remaining
+			}
+
+			continue
+		}
+
+		if err := r.releaseAllocations(ctx, instance, clusterID); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return departedClusters, requeueAfter, nil
+}
+
+// allocatedClusterIDs returns the IDs of the clusters with Globalnet or ClustersetIP CIDR allocations.
+func (r *BrokerReconciler) allocatedClusterIDs(ctx context.Context, namespace string) (sets.Set[string], error) {
+	allocated := sets.New[string]()
+
+	globalnetInfo, _, err := globalnet.GetGlobalNetworks(ctx, r.Client, namespace)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err //nolint:wrapcheck // Errors are already wrapped
+	}
+
+	if globalnetInfo != nil {
+		allocated.Insert(sets.KeySet(globalnetInfo.Clusters).UnsortedList()...)
+	}
+
+	clustersetIPInfo, _, err := clustersetip.GetClustersetIPNetworks(ctx, r.Client, namespace)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err //nolint:wrapcheck // Errors are already wrapped
+	}
+
+	if clustersetIPInfo != nil {
+		allocated.Insert(sets.KeySet(clustersetIPInfo.Clusters).UnsortedList()...)
+	}
+
+	return allocated, nil
+}
+
+func (r *BrokerReconciler) releaseAllocations(ctx context.Context, instance *v1alpha1.Broker, clusterID string) error {
+	releasedGlobalnet, err := globalnet.RemoveClusterAllocation(ctx, r.Client, instance.Namespace, clusterID)
+	if err != nil {
+		return err //nolint:wrapcheck // Errors are already wrapped
+	}
+
+	releasedClustersetIP, err := clustersetip.RemoveClusterAllocation(ctx, r.Client, instance.Namespace, clusterID)
+	if err != nil {
+		return err //nolint:wrapcheck // Errors are already wrapped
+	}
+
+	if releasedGlobalnet || releasedClustersetIP {
+		log.Info("Released the CIDRs allocated to a departed cluster", "ClusterID", clusterID)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, reasonAllocationsReleased,
+			"Released the CIDRs allocated to cluster %q whose Cluster resource was removed", clusterID)
+	}
+
+	return nil
+}
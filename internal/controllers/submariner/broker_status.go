@@ -0,0 +1,207 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/resource"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/cidr"
+	"github.com/submariner-io/submariner-operator/pkg/discovery/clustersetip"
+	"github.com/submariner-io/submariner-operator/pkg/discovery/globalnet"
+	submv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func (r *BrokerReconciler) updateStatus(ctx context.Context, instance *v1alpha1.Broker, departedClusters []v1alpha1.DepartedCluster,
+) error {
+	status := v1alpha1.BrokerStatus{
+		DepartedClusters:   departedClusters,
+		ObservedGeneration: instance.Generation,
+	}
+
+	var err error
+
+	status.Clusters, err = r.getClusters(ctx, instance.Namespace)
+	if err != nil {
+		return err
+	}
+
+	globalnetInfo, _, err := globalnet.GetGlobalNetworks(ctx, r.Client, instance.Namespace)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err //nolint:wrapcheck // Errors are already wrapped
+	}
+
+	if globalnetInfo != nil {
+		status.Globalnet, err = newCIDRAllocationStatus(globalnetInfo.Enabled, &globalnetInfo.Info, globalnetInfo.IPv6CIDR,
+			globalnetInfo.IPv6AllocationSize)
+		if err != nil {
+			return errors.Wrap(err, "error computing the Globalnet usage")
+		}
+	}
+
+	clustersetIPInfo, _, err := clustersetip.GetClustersetIPNetworks(ctx, r.Client, instance.Namespace)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err //nolint:wrapcheck // Errors are already wrapped
+	}
+
+	if clustersetIPInfo != nil {
+		status.ClustersetIP, err = newCIDRAllocationStatus(clustersetIPInfo.Enabled, &clustersetIPInfo.Info, clustersetIPInfo.IPv6CIDR,
+			clustersetIPInfo.IPv6AllocationSize)
+		if err != nil {
+			return errors.Wrap(err, "error computing the ClustersetIP usage")
+		}
+	}
+
+	if reflect.DeepEqual(instance.Status, status) {
+		return nil
+	}
+
+	instance.Status = status
+
+	return errors.Wrap(r.Client.Status().Update(ctx, instance), "error updating the Broker status")
+}
+
+func (r *BrokerReconciler) getClusters(ctx context.Context, namespace string) ([]v1alpha1.BrokerClusterStatus, error) {
+	clusterList := &submv1.ClusterList{}
+
+	err := r.Client.List(ctx, clusterList, client.InNamespace(namespace))
+	if resource.IsNotFoundErr(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing the Cluster resources")
+	}
+
+	var clusters []v1alpha1.BrokerClusterStatus
+
+	for i := range clusterList.Items {
+		spec := &clusterList.Items[i].Spec
+
+		clusters = append(clusters, v1alpha1.BrokerClusterStatus{
+			ClusterID:    spec.ClusterID,
+			ServiceCIDRs: spec.ServiceCIDR,
+			ClusterCIDRs: spec.ClusterCIDR,
+			GlobalCIDRs:  spec.GlobalCIDR,
+		})
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].ClusterID < clusters[j].ClusterID
+	})
+
+	return clusters, nil
+}
+
+// maxReportedFreeBlocks bounds the free blocks listed in the status, as a fragmented range can have many.
+const maxReportedFreeBlocks = 16
+
+// newCIDRAllocationStatus describes the allocations from the given range and its usage, and that of the given IPv6
+// range if any.
+func newCIDRAllocationStatus(enabled bool, info *cidr.Info, ipv6CIDR string, ipv6AllocationSize uint,
+) (*v1alpha1.CIDRAllocationStatus, error) {
+	status := &v1alpha1.CIDRAllocationStatus{
+		Enabled: enabled,
+	}
+
+	for _, clusterInfo := range info.Clusters {
+		status.Allocations = append(status.Allocations, v1alpha1.ClusterCIDRAllocation{
+			ClusterID: clusterInfo.ClusterID,
+			CIDRs:     clusterInfo.CIDRs,
+		})
+	}
+
+	sort.Slice(status.Allocations, func(i, j int) bool {
+		return status.Allocations[i].ClusterID < status.Allocations[j].ClusterID
+	})
+
+	rangeStatus, err := newCIDRRangeStatus(info)
+	if err != nil {
+		return nil, err
+	}
+
+	status.CIDRRangeStatus = *rangeStatus
+
+	if ipv6CIDR != "" {
+		status.IPv6, err = newCIDRRangeStatus(&cidr.Info{CIDR: ipv6CIDR, AllocationSize: ipv6AllocationSize, Clusters: info.Clusters})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return status, nil
+}
+
+func newCIDRRangeStatus(info *cidr.Info) (*v1alpha1.CIDRRangeStatus, error) {
+	status := &v1alpha1.CIDRRangeStatus{
+		CIDR:           info.CIDR,
+		AllocationSize: info.AllocationSize,
+	}
+
+	if info.CIDR == "" {
+		return status, nil
+	}
+
+	freeAddresses, freeAllocations, err := cidr.Capacity(info)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Wrapped by the caller
+	}
+
+	// Integers are signed 64-bit in the API, the capacity of large IPv6 ranges saturates.
+	status.FreeAddresses = min(freeAddresses, math.MaxInt64)
+	status.FreeAllocations = min(freeAllocations, math.MaxInt64)
+
+	usage, err := cidr.Usage(info)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Wrapped by the caller
+	}
+
+	status.FreeBlockCount = uint(len(usage.Free))
+	status.FreeBlocks = usage.Free[:min(len(usage.Free), maxReportedFreeBlocks)]
+	status.LargestFreeBlock = usage.LargestFree
+
+	return status, nil
+}
+
+// brokersInNamespace maps an object to all the Brokers in its namespace.
+func (r *BrokerReconciler) brokersInNamespace(ctx context.Context, object client.Object) []reconcile.Request {
+	brokers := &v1alpha1.BrokerList{}
+
+	err := r.Client.List(ctx, brokers, client.InNamespace(object.GetNamespace()))
+	if err != nil {
+		log.Error(err, "Error listing the Brokers", "namespace", object.GetNamespace())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, len(brokers.Items))
+	for i := range brokers.Items {
+		requests[i].NamespacedName = types.NamespacedName{Namespace: brokers.Items[i].Namespace, Name: brokers.Items[i].Name}
+	}
+
+	return requests
+}
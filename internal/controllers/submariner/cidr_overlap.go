@@ -0,0 +1,160 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	submopv1a1 "github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/cidr"
+	submv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// cidrOverlapCheckInterval is the interval at which the overlaps with the CIDRs of the other clusters are checked.
+const cidrOverlapCheckInterval = 5 * time.Minute
+
+// checkCIDROverlaps compares the cluster's pod and service CIDRs with the subnets the other clusters advertise on the
+// broker and reports the overlaps in the CIDRsDistinct condition. Overlapping CIDRs are only an issue without Globalnet,
+// so the condition is removed when Globalnet is enabled or no broker is configured. As the broker isn't watched, the
+// Submariner resource is reconciled periodically while the condition is reported.
+func (r *Reconciler) checkCIDROverlaps(ctx context.Context, instance *submopv1a1.Submariner) {
+	if instance.Spec.GlobalCIDR != "" || instance.Spec.BrokerK8sApiServer == "" {
+		meta.RemoveStatusCondition(&instance.Status.Conditions, submopv1a1.CIDRsDistinctCondition)
+		return
+	}
+
+	overlaps, err := r.findCIDROverlaps(ctx, instance)
+	if err != nil {
+		log.Error(err, "Error checking the cluster CIDRs for overlaps with the other clusters")
+		setCondition(instance, submopv1a1.CIDRsDistinctCondition, metav1.ConditionUnknown, reasonBrokerConnectionFailed, err.Error())
+
+		return
+	}
+
+	if len(overlaps) == 0 {
+		setCondition(instance, submopv1a1.CIDRsDistinctCondition, metav1.ConditionTrue, reasonCIDRsDistinct,
+			"The cluster CIDRs don't overlap with those of the other clusters")
+
+		return
+	}
+
+	message := fmt.Sprintf("The cluster CIDRs overlap with those of other clusters: %s. Enable Globalnet to connect clusters"+
+		" with overlapping CIDRs", strings.Join(overlaps, "; "))
+
+	previous := meta.FindStatusCondition(instance.Status.Conditions, submopv1a1.CIDRsDistinctCondition)
+	if previous == nil || previous.Status != metav1.ConditionFalse || previous.Message != message {
+		r.config.EventRecorder.Event(instance, corev1.EventTypeWarning, reasonCIDROverlap, message)
+	}
+
+	setCondition(instance, submopv1a1.CIDRsDistinctCondition, metav1.ConditionFalse, reasonCIDROverlap, message)
+}
+
+// findCIDROverlaps returns a description of each of the cluster's pod and service CIDRs which overlap with the subnets
+// of the other clusters, as recorded in their Cluster and Endpoint resources on the broker.
+func (r *Reconciler) findCIDROverlaps(ctx context.Context, instance *submopv1a1.Submariner) ([]string, error) {
+	brokerClient, err := r.getBrokerControllerClient(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+
+	peers, err := peerClusterCIDRs(ctx, brokerClient, instance.Spec.BrokerK8sRemoteNamespace, instance.Spec.ClusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	var overlaps []string
+
+	for _, local := range []struct {
+		kind  string
+		cidrs []string
+	}{
+		{kind: "pod", cidrs: instance.Status.ClusterCIDRs},
+		{kind: "service", cidrs: instance.Status.ServiceCIDRs},
+	} {
+		for _, localCIDR := range local.cidrs {
+			clusterIDs, err := cidr.FindOverlaps(peers, localCIDR, instance.Spec.ClusterID)
+			if err != nil {
+				return nil, err //nolint:wrapcheck // No need to wrap here
+			}
+
+			if len(clusterIDs) > 0 {
+				overlaps = append(overlaps, fmt.Sprintf("%s CIDR %s overlaps with cluster(s) %s", local.kind, localCIDR,
+					strings.Join(clusterIDs, ", ")))
+			}
+		}
+	}
+
+	return overlaps, nil
+}
+
+// peerClusterCIDRs returns the subnets of the clusters other than the given one, merged from their Cluster and Endpoint
+// resources on the broker.
+func peerClusterCIDRs(ctx context.Context, brokerClient client.Reader, namespace, clusterID string,
+) (map[string]*cidr.ClusterInfo, error) {
+	peers := map[string]*cidr.ClusterInfo{}
+
+	addCIDRs := func(peerID string, cidrs ...[]string) {
+		if peerID == "" || peerID == clusterID {
+			return
+		}
+
+		info, ok := peers[peerID]
+		if !ok {
+			info = &cidr.ClusterInfo{ClusterID: peerID}
+			peers[peerID] = info
+		}
+
+		for _, list := range cidrs {
+			for _, c := range list {
+				if !slices.Contains(info.CIDRs, c) {
+					info.CIDRs = append(info.CIDRs, c)
+				}
+			}
+		}
+	}
+
+	clusters := &submv1.ClusterList{}
+	if err := brokerClient.List(ctx, clusters, client.InNamespace(namespace)); err != nil {
+		return nil, errors.Wrap(err, "error listing the Clusters on the broker")
+	}
+
+	for i := range clusters.Items {
+		addCIDRs(clusters.Items[i].Spec.ClusterID, clusters.Items[i].Spec.ClusterCIDR, clusters.Items[i].Spec.ServiceCIDR)
+	}
+
+	endpoints := &submv1.EndpointList{}
+	if err := brokerClient.List(ctx, endpoints, client.InNamespace(namespace)); err != nil {
+		return nil, errors.Wrap(err, "error listing the Endpoints on the broker")
+	}
+
+	for i := range endpoints.Items {
+		addCIDRs(endpoints.Items[i].Spec.ClusterID, endpoints.Items[i].Spec.Subnets)
+	}
+
+	return peers, nil
+}
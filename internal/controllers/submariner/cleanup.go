@@ -27,12 +27,15 @@ import (
 	"github.com/submariner-io/admiral/pkg/resource"
 	operatorv1alpha1 "github.com/submariner-io/submariner-operator/api/v1alpha1"
 	"github.com/submariner-io/submariner-operator/internal/controllers/uninstall"
+	"github.com/submariner-io/submariner-operator/pkg/discovery/clustersetip"
+	"github.com/submariner-io/submariner-operator/pkg/discovery/globalnet"
 	"github.com/submariner-io/submariner-operator/pkg/images"
 	opnames "github.com/submariner-io/submariner-operator/pkg/names"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -46,12 +49,18 @@ func (r *Reconciler) runComponentCleanup(ctx context.Context, instance *operator
 		return reconcile.Result{}, r.removeFinalizer(ctx, instance)
 	}
 
+	initialStatus := instance.Status.DeepCopy()
+
 	// This has the side effect of setting the CIDRs in the Submariner instance.
 	_, err := r.discoverNetwork(ctx, instance, log)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
+	if instance.Status.Uninstall == nil {
+		instance.Status.Uninstall = &operatorv1alpha1.UninstallStatus{}
+	}
+
 	components := []*uninstall.Component{
 		{
 			Resource:          newDaemonSet(names.GatewayComponent, instance.Namespace),
@@ -71,10 +80,16 @@ func (r *Reconciler) runComponentCleanup(ctx context.Context, instance *operator
 	}
 
 	uninstallInfo := &uninstall.Info{
-		Client:     r.config.ScopedClient,
-		Components: components,
-		StartTime:  instance.DeletionTimestamp.Time,
-		Log:        log,
+		Client:        r.config.ScopedClient,
+		Components:    components,
+		StartTime:     instance.DeletionTimestamp.Time,
+		Log:           log,
+		Recorder:      r.config.EventRecorder,
+		Instance:      instance,
+		Spec:          instance.Spec.Uninstall,
+		Status:        instance.Status.Uninstall,
+		PodLogs:       r.config.PodLogs,
+		GeneralClient: r.config.GeneralClient,
 		GetImageInfo: func(imageName, componentName string) (string, corev1.PullPolicy) {
 			return getImagePath(instance, imageName, componentName),
 				images.GetPullPolicy(instance.Spec.Version, instance.Spec.ImageOverrides[componentName])
@@ -82,6 +97,11 @@ func (r *Reconciler) runComponentCleanup(ctx context.Context, instance *operator
 	}
 
 	requeue, timedOut, err := uninstallInfo.Run(ctx)
+
+	if updateErr := r.updateStatus(ctx, instance, initialStatus); updateErr != nil {
+		log.Error(updateErr, "Error updating the uninstall status")
+	}
+
 	if err != nil {
 		return reconcile.Result{}, err //nolint:wrapcheck // No need to wrap
 	}
@@ -94,9 +114,51 @@ func (r *Reconciler) runComponentCleanup(ctx context.Context, instance *operator
 		return reconcile.Result{RequeueAfter: time.Millisecond * 500}, nil
 	}
 
+	r.releaseBrokerAllocations(ctx, instance)
+
 	return reconcile.Result{}, r.removeFinalizer(ctx, instance)
 }
 
+// releaseBrokerAllocations releases the Globalnet and ClustersetIP CIDRs allocated to the cluster on the broker. Failures
+// are only reported since the broker releases the allocations of departed clusters itself after a grace period.
+func (r *Reconciler) releaseBrokerAllocations(ctx context.Context, instance *operatorv1alpha1.Submariner) {
+	if instance.Spec.BrokerK8sSecret == "" && instance.Spec.BrokerK8sApiServer == "" && instance.Spec.BrokerK8sApiServerToken == "" {
+		log.Info("No broker is configured, there are no CIDRs to release on the broker")
+		return
+	}
+
+	err := r.deleteBrokerAllocations(ctx, instance)
+	if err != nil {
+		log.Error(err, "Error releasing the CIDRs allocated on the broker")
+		r.config.EventRecorder.Event(instance, corev1.EventTypeWarning, reasonBrokerDeregistrationFailed, err.Error())
+	}
+}
+
+func (r *Reconciler) deleteBrokerAllocations(ctx context.Context, instance *operatorv1alpha1.Submariner) error {
+	brokerClient, err := r.getBrokerControllerClient(ctx, instance)
+	if err != nil {
+		return err
+	}
+
+	removers := map[operatorv1alpha1.CIDRAllocationPool]func(context.Context, client.Client, string, string) (bool, error){
+		operatorv1alpha1.GlobalnetPool:    globalnet.RemoveClusterAllocation,
+		operatorv1alpha1.ClustersetIPPool: clustersetip.RemoveClusterAllocation,
+	}
+
+	for pool, remove := range removers {
+		removed, err := remove(ctx, brokerClient, instance.Spec.BrokerK8sRemoteNamespace, instance.Spec.ClusterID)
+		if err != nil {
+			return err //nolint:wrapcheck // Errors are already wrapped
+		}
+
+		if removed {
+			log.Info("Released the CIDRs allocated on the broker", "pool", pool, "ClusterID", instance.Spec.ClusterID)
+		}
+	}
+
+	return nil
+}
+
 func (r *Reconciler) removeFinalizer(ctx context.Context, instance *operatorv1alpha1.Submariner) error {
 	return finalizer.Remove[*operatorv1alpha1.Submariner](ctx, resource.ForControllerClient(
 		r.config.ScopedClient, instance.Namespace, &operatorv1alpha1.Submariner{}),
@@ -0,0 +1,157 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+	submopv1a1 "github.com/submariner-io/submariner-operator/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	reasonReady                      = "Ready"
+	reasonNetworkDiscovered          = "NetworkDiscovered"
+	reasonNetworkDiscoveryFailed     = "NetworkDiscoveryFailed"
+	reasonBrokerSecretSynced         = "BrokerSecretSynced"
+	reasonBrokerSecretNotSet         = "BrokerSecretNotConfigured"
+	reasonBrokerConnectionFailed     = "BrokerConnectionFailed"
+	reasonReconcileFailed            = "ReconcileFailed"
+	reasonRolloutInProgress          = "RolloutInProgress"
+	reasonNoNodesScheduled           = "NoNodesScheduled"
+	reasonPodsNotReady               = "PodsNotReady"
+	reasonStatusCheckFailed          = "StatusCheckFailed"
+	reasonCIDRMismatch               = "CIDRMismatch"
+	reasonPaused                     = "Paused"
+	reasonBrokerDeregistrationFailed = "BrokerDeregistrationFailed"
+	reasonGlobalCIDRExpanded         = "GlobalCIDRExpanded"
+	reasonGlobalCIDRExpansionFailed  = "GlobalCIDRExpansionFailed"
+	reasonCIDRsDistinct              = "CIDRsDistinct"
+	reasonCIDROverlap                = "CIDROverlap"
+)
+
+// componentConditions are the conditions aggregated into the Ready condition.
+var componentConditions = []string{
+	submopv1a1.NetworkDiscoveredCondition,
+	submopv1a1.BrokerConnectedCondition,
+	submopv1a1.GatewayReadyCondition,
+	submopv1a1.RouteAgentReadyCondition,
+	submopv1a1.GlobalnetReadyCondition,
+	submopv1a1.CIDRsDistinctCondition,
+}
+
+func setCondition(instance *submopv1a1.Submariner, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: instance.Generation,
+	})
+}
+
+func setDaemonSetCondition(instance *submopv1a1.Submariner, conditionType string, daemonSet *appsv1.DaemonSet) {
+	status, reason, message := daemonSetReadiness(daemonSet)
+	setCondition(instance, conditionType, status, reason, message)
+}
+
+func daemonSetReadiness(daemonSet *appsv1.DaemonSet) (metav1.ConditionStatus, string, string) {
+	status := &daemonSet.Status
+
+	switch {
+	case status.ObservedGeneration < daemonSet.Generation:
+		return metav1.ConditionFalse, reasonRolloutInProgress,
+			fmt.Sprintf("DaemonSet %q generation %d has not been observed yet", daemonSet.Name, daemonSet.Generation)
+	case status.DesiredNumberScheduled == 0:
+		return metav1.ConditionFalse, reasonNoNodesScheduled,
+			fmt.Sprintf("DaemonSet %q is not scheduled on any node", daemonSet.Name)
+	case status.UpdatedNumberScheduled < status.DesiredNumberScheduled:
+		return metav1.ConditionFalse, reasonRolloutInProgress,
+			fmt.Sprintf("DaemonSet %q has %d of %d pods updated", daemonSet.Name, status.UpdatedNumberScheduled,
+				status.DesiredNumberScheduled)
+	case status.NumberReady < status.DesiredNumberScheduled:
+		return metav1.ConditionFalse, reasonPodsNotReady,
+			fmt.Sprintf("DaemonSet %q has %d of %d pods ready", daemonSet.Name, status.NumberReady, status.DesiredNumberScheduled)
+	}
+
+	return metav1.ConditionTrue, reasonReady,
+		fmt.Sprintf("DaemonSet %q has %d of %d pods ready", daemonSet.Name, status.NumberReady, status.DesiredNumberScheduled)
+}
+
+// setReadyCondition derives the Ready condition from the component conditions: the first component condition that is
+// false determines the reason and message.
+func setReadyCondition(instance *submopv1a1.Submariner) {
+	for _, conditionType := range componentConditions {
+		condition := meta.FindStatusCondition(instance.Status.Conditions, conditionType)
+		if condition != nil && condition.Status == metav1.ConditionFalse {
+			setCondition(instance, submopv1a1.ReadyCondition, metav1.ConditionFalse, condition.Reason,
+				fmt.Sprintf("%s: %s", conditionType, condition.Message))
+
+			return
+		}
+	}
+
+	setCondition(instance, submopv1a1.ReadyCondition, metav1.ConditionTrue, reasonReady, "All Submariner components are ready")
+}
+
+// reconcileFailed records the failed step in the given condition (if any) and in the Ready condition, records a
+// warning event if the Ready condition changes, updates the status and returns the original error. Conflicts are
+// requeued as is.
+func (r *Reconciler) reconcileFailed(ctx context.Context, instance *submopv1a1.Submariner, initialStatus *submopv1a1.SubmarinerStatus,
+	conditionType, reason string, err error,
+) (reconcile.Result, error) {
+	if apierrors.IsConflict(err) {
+		log.V(2).Info("Conflict during the reconcile, requeueing", "error", err.Error())
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	if conditionType != "" {
+		setCondition(instance, conditionType, metav1.ConditionFalse, reason, err.Error())
+	}
+
+	ready := meta.FindStatusCondition(instance.Status.Conditions, submopv1a1.ReadyCondition)
+	if ready == nil || ready.Status != metav1.ConditionFalse || ready.Reason != reason {
+		r.config.EventRecorder.Event(instance, corev1.EventTypeWarning, reason, err.Error())
+	}
+
+	setCondition(instance, submopv1a1.ReadyCondition, metav1.ConditionFalse, reason, err.Error())
+
+	if updateErr := r.updateStatus(ctx, instance, initialStatus); updateErr != nil {
+		log.Error(updateErr, "Error updating the Submariner status after a failed reconcile")
+	}
+
+	return reconcile.Result{}, err
+}
+
+func (r *Reconciler) updateStatus(ctx context.Context, instance *submopv1a1.Submariner, initialStatus *submopv1a1.SubmarinerStatus,
+) error {
+	if reflect.DeepEqual(instance.Status, *initialStatus) {
+		return nil
+	}
+
+	return errors.Wrap(r.config.ScopedClient.Status().Update(ctx, instance), "failed to update the Submariner status")
+}
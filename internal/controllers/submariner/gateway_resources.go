@@ -32,6 +32,7 @@ import (
 	"github.com/submariner-io/submariner-operator/pkg/httpproxy"
 	"github.com/submariner-io/submariner-operator/pkg/images"
 	opnames "github.com/submariner-io/submariner-operator/pkg/names"
+	"github.com/submariner-io/submariner-operator/pkg/podtemplate"
 	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
 	"github.com/submariner-io/submariner/pkg/port"
 	appsv1 "k8s.io/api/apps/v1"
@@ -199,7 +200,7 @@ func newGatewayPodTemplate(cr *v1alpha1.Submariner, name string, podSelectorLabe
 						{Name: "SUBMARINER_NAMESPACE", Value: cr.Spec.Namespace},
 						{Name: "SUBMARINER_CLUSTERCIDR", Value: cr.Status.ClusterCIDR},
 						{Name: "SUBMARINER_SERVICECIDR", Value: cr.Status.ServiceCIDR},
-						{Name: "SUBMARINER_GLOBALCIDR", Value: cr.Spec.GlobalCIDR},
+						{Name: "SUBMARINER_GLOBALCIDR", Value: globalCIDRs(cr)},
 						{Name: "SUBMARINER_CLUSTERID", Value: cr.Spec.ClusterID},
 						{Name: "SUBMARINER_COLORCODES", Value: cr.Spec.ColorCodes},
 						{Name: "SUBMARINER_DEBUG", Value: strconv.FormatBool(cr.Spec.Debug)},
@@ -236,6 +237,7 @@ func newGatewayPodTemplate(cr *v1alpha1.Submariner, name string, podSelectorLabe
 				},
 			},
 			ServiceAccountName:            names.GatewayComponent,
+			ImagePullSecrets:              cr.Spec.ImagePullSecrets,
 			HostNetwork:                   true,
 			DNSPolicy:                     corev1.DNSClusterFirstWithHostNet,
 			TerminationGracePeriodSeconds: ptr.To(int64(1)),
@@ -261,6 +263,8 @@ func newGatewayPodTemplate(cr *v1alpha1.Submariner, name string, podSelectorLabe
 			corev1.EnvVar{Name: "SUBMARINER_PUBLICIP", Value: "lb:" + loadBalancerName})
 	}
 
+	podtemplate.ApplyOverrides(&podTemplate, cr.Spec.Components, names.GatewayComponent)
+
 	return podTemplate
 }
 
@@ -268,25 +272,29 @@ func newGatewayPodTemplate(cr *v1alpha1.Submariner, name string, podSelectorLabe
 func (r *Reconciler) reconcileGatewayDaemonSet(
 	ctx context.Context, instance *v1alpha1.Submariner, reqLogger logr.Logger,
 ) (*appsv1.DaemonSet, error) {
-	daemonSet, err := apply.DaemonSet(ctx, instance, newGatewayDaemonSet(instance, names.GatewayComponent),
-		reqLogger, r.config.ScopedClient, r.config.Scheme)
+	daemonSet, err := apply.Apply(ctx, instance, newGatewayDaemonSet(instance, names.GatewayComponent),
+		reqLogger, r.config.ScopedClient, r.config.Scheme, r.config.EventRecorder)
 	if err != nil {
 		return nil, err
 	}
 
 	err = metrics.Setup(ctx, r.config.ScopedClient, r.config.RestConfig, r.config.Scheme,
-		&metrics.ServiceInfo{
-			Name:            names.GatewayComponent,
-			Namespace:       instance.Namespace,
-			ApplicationKey:  "app",
-			ApplicationName: names.MetricsProxyComponent,
-			Owner:           instance,
-			Port:            gatewayMetricsServicePort,
-		}, reqLogger)
+		gatewayMetricsServiceInfo(instance), reqLogger)
 
 	return daemonSet, err
 }
 
+func gatewayMetricsServiceInfo(instance *v1alpha1.Submariner) *metrics.ServiceInfo {
+	return &metrics.ServiceInfo{
+		Name:            names.GatewayComponent,
+		Namespace:       instance.Namespace,
+		ApplicationKey:  "app",
+		ApplicationName: names.MetricsProxyComponent,
+		Owner:           instance,
+		Port:            gatewayMetricsServicePort,
+	}
+}
+
 func buildGatewayStatusAndUpdateMetrics(gateways []submarinerv1.Gateway) []submarinerv1.GatewayStatus {
 	gatewayStatuses := []submarinerv1.GatewayStatus{}
 
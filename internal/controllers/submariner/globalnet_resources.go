@@ -20,17 +20,24 @@ package submariner
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
 	"github.com/submariner-io/admiral/pkg/names"
 	"github.com/submariner-io/submariner-operator/api/v1alpha1"
 	"github.com/submariner-io/submariner-operator/internal/controllers/apply"
 	"github.com/submariner-io/submariner-operator/internal/controllers/metrics"
+	"github.com/submariner-io/submariner-operator/pkg/discovery/globalnet"
 	"github.com/submariner-io/submariner-operator/pkg/httpproxy"
 	"github.com/submariner-io/submariner-operator/pkg/images"
 	opnames "github.com/submariner-io/submariner-operator/pkg/names"
+	"github.com/submariner-io/submariner-operator/pkg/podtemplate"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
@@ -38,32 +45,120 @@ import (
 //nolint:wrapcheck // No need to wrap errors here.
 func (r *Reconciler) reconcileGlobalnetDaemonSet(ctx context.Context, instance *v1alpha1.Submariner, reqLogger logr.Logger,
 ) (*appsv1.DaemonSet, error) {
-	daemonSet, err := apply.DaemonSet(ctx, instance, newGlobalnetDaemonSet(instance, names.GlobalnetComponent), reqLogger,
-		r.config.ScopedClient, r.config.Scheme)
+	daemonSet, err := apply.Apply(ctx, instance, newGlobalnetDaemonSet(instance, names.GlobalnetComponent), reqLogger,
+		r.config.ScopedClient, r.config.Scheme, r.config.EventRecorder)
 	if err != nil {
 		return nil, err
 	}
 
 	err = metrics.Setup(ctx, r.config.ScopedClient, r.config.RestConfig, r.config.Scheme,
-		&metrics.ServiceInfo{
-			Name:            names.GlobalnetComponent,
-			Namespace:       instance.Namespace,
-			ApplicationKey:  "app",
-			ApplicationName: names.MetricsProxyComponent,
-			Owner:           instance,
-			Port:            globalnetMetricsServicePort,
-		}, reqLogger)
+		globalnetMetricsServiceInfo(instance), reqLogger)
 
 	return daemonSet, err
 }
 
+// setGlobalCIDRsExpandedCondition reports the outcome of the global CIDR expansion, if the GlobalCIDR is to be expanded.
+func setGlobalCIDRsExpandedCondition(instance *v1alpha1.Submariner, err error) {
+	switch {
+	case err != nil:
+		log.Error(err, "Error expanding the global CIDRs, keeping the current ones", "GlobalCIDRs", instance.Status.GlobalCIDRs)
+		setCondition(instance, v1alpha1.GlobalCIDRsExpandedCondition, metav1.ConditionFalse, reasonGlobalCIDRExpansionFailed,
+			err.Error())
+	case instance.Spec.GlobalCIDR == "" || instance.Spec.GlobalCIDRExpansions == 0:
+		meta.RemoveStatusCondition(&instance.Status.Conditions, v1alpha1.GlobalCIDRsExpandedCondition)
+	case uint(len(instance.Status.GlobalCIDRs)) >=
+		uint(len(splitGlobalCIDR(instance.Spec.GlobalCIDR)))*(instance.Spec.GlobalCIDRExpansions+1):
+		setCondition(instance, v1alpha1.GlobalCIDRsExpandedCondition, metav1.ConditionTrue, reasonGlobalCIDRExpanded,
+			fmt.Sprintf("The global CIDRs are %s", strings.Join(instance.Status.GlobalCIDRs, ",")))
+	}
+}
+
+// reconcileGlobalCIDRs records the global CIDRs of the cluster in the status. If the GlobalCIDR is to be expanded, the
+// additional blocks are allocated from the broker's Globalnet range when the status doesn't have enough.
+func (r *Reconciler) reconcileGlobalCIDRs(ctx context.Context, instance *v1alpha1.Submariner) error {
+	specCIDRs := splitGlobalCIDR(instance.Spec.GlobalCIDR)
+	if len(specCIDRs) == 0 {
+		instance.Status.GlobalCIDRs = nil
+		return nil
+	}
+
+	currentCIDRs := instance.Status.GlobalCIDRs
+	if !hasSpecCIDRs(currentCIDRs, specCIDRs) {
+		currentCIDRs = specCIDRs
+	}
+
+	instance.Status.GlobalCIDRs = currentCIDRs
+
+	// Blocks aren't released when the number of expansions decreases
+	if uint(len(currentCIDRs)) >= uint(len(specCIDRs))*(instance.Spec.GlobalCIDRExpansions+1) || instance.IsPaused() {
+		return nil
+	}
+
+	brokerClient, err := r.getBrokerControllerClient(ctx, instance)
+	if err != nil {
+		return err
+	}
+
+	globalCIDRs, err := globalnet.ExpandGlobalCIDRs(ctx, brokerClient, instance.Spec.BrokerK8sRemoteNamespace,
+		instance.Spec.ClusterID, instance.Spec.GlobalCIDR, instance.Spec.GlobalCIDRExpansions)
+	if err != nil {
+		return errors.Wrap(err, "error expanding the global CIDRs")
+	}
+
+	if len(globalCIDRs) > len(currentCIDRs) {
+		r.config.EventRecorder.Event(instance, corev1.EventTypeNormal, reasonGlobalCIDRExpanded,
+			fmt.Sprintf("The global CIDRs were expanded to %s", strings.Join(globalCIDRs, ",")))
+	}
+
+	instance.Status.GlobalCIDRs = globalCIDRs
+
+	return nil
+}
+
+// globalCIDRs returns the global CIDRs of the cluster, comma-separated: the GlobalCIDR followed by the blocks it was
+// expanded with, if any.
+func globalCIDRs(cr *v1alpha1.Submariner) string {
+	if !hasSpecCIDRs(cr.Status.GlobalCIDRs, splitGlobalCIDR(cr.Spec.GlobalCIDR)) {
+		return cr.Spec.GlobalCIDR
+	}
+
+	return strings.Join(cr.Status.GlobalCIDRs, ",")
+}
+
+func hasSpecCIDRs(globalCIDRs, specCIDRs []string) bool {
+	return len(globalCIDRs) >= len(specCIDRs) && slices.Equal(globalCIDRs[:len(specCIDRs)], specCIDRs)
+}
+
+func splitGlobalCIDR(globalCIDR string) []string {
+	var cidrs []string
+
+	for _, c := range strings.Split(globalCIDR, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			cidrs = append(cidrs, c)
+		}
+	}
+
+	return cidrs
+}
+
+func globalnetMetricsServiceInfo(instance *v1alpha1.Submariner) *metrics.ServiceInfo {
+	return &metrics.ServiceInfo{
+		Name:            names.GlobalnetComponent,
+		Namespace:       instance.Namespace,
+		ApplicationKey:  "app",
+		ApplicationName: names.MetricsProxyComponent,
+		Owner:           instance,
+		Port:            globalnetMetricsServicePort,
+	}
+}
+
 func newGlobalnetDaemonSet(cr *v1alpha1.Submariner, name string) *appsv1.DaemonSet {
 	labels := map[string]string{
 		"app":       name,
 		"component": "globalnet",
 	}
 
-	return &appsv1.DaemonSet{
+	daemonSet := &appsv1.DaemonSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: cr.Namespace,
 			Name:      name,
@@ -101,6 +196,7 @@ func newGlobalnetDaemonSet(cr *v1alpha1.Submariner, name string) *appsv1.DaemonS
 							Env: httpproxy.AddEnvVars([]corev1.EnvVar{
 								{Name: "SUBMARINER_NAMESPACE", Value: cr.Spec.Namespace},
 								{Name: "SUBMARINER_CLUSTERID", Value: cr.Spec.ClusterID},
+								{Name: "SUBMARINER_GLOBALCIDR", Value: globalCIDRs(cr)},
 								{Name: "SUBMARINER_METRICSPORT", Value: globalnetMetricsServerPort},
 								{Name: "NODE_NAME", ValueFrom: &corev1.EnvVarSource{
 									FieldRef: &corev1.ObjectFieldSelector{
@@ -111,6 +207,7 @@ func newGlobalnetDaemonSet(cr *v1alpha1.Submariner, name string) *appsv1.DaemonS
 						},
 					},
 					ServiceAccountName:            names.GlobalnetComponent,
+					ImagePullSecrets:              cr.Spec.ImagePullSecrets,
 					TerminationGracePeriodSeconds: ptr.To(int64(2)),
 					NodeSelector:                  map[string]string{"submariner.io/gateway": "true"},
 					HostNetwork:                   true,
@@ -121,4 +218,8 @@ func newGlobalnetDaemonSet(cr *v1alpha1.Submariner, name string) *appsv1.DaemonS
 			},
 		},
 	}
+
+	podtemplate.ApplyOverrides(&daemonSet.Spec.Template, cr.Spec.Components, names.GlobalnetComponent)
+
+	return daemonSet
 }
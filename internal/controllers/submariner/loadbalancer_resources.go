@@ -23,17 +23,14 @@ import (
 	"strconv"
 
 	"github.com/go-logr/logr"
-	configv1 "github.com/openshift/api/config/v1"
 	"github.com/pkg/errors"
 	"github.com/submariner-io/admiral/pkg/names"
-	"github.com/submariner-io/admiral/pkg/resource"
 	"github.com/submariner-io/submariner-operator/api/v1alpha1"
 	"github.com/submariner-io/submariner-operator/internal/controllers/apply"
 	submv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
 	"github.com/submariner-io/submariner/pkg/port"
 	corev1 "k8s.io/api/core/v1"
 	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -48,65 +45,57 @@ const (
 func (r *Reconciler) reconcileLoadBalancer(
 	ctx context.Context, instance *v1alpha1.Submariner, reqLogger logr.Logger,
 ) (*corev1.Service, error) {
-	platformTypeOCP, err := r.getOCPPlatformType(ctx)
-	if err != nil {
-		return nil, err
+	deploymentInfo := &instance.Status.DeploymentInfo
+
+	// The cloud specific settings would be dropped from the Service if it was reconciled without the deployment info
+	if deploymentInfo.KubernetesType == "" {
+		return nil, errors.New("the deployment info hasn't been discovered yet")
 	}
 
-	svc, err := apply.Service(ctx, instance, newLoadBalancerService(instance, platformTypeOCP),
-		reqLogger, r.config.ScopedClient, r.config.Scheme)
+	svc, err := apply.Apply(ctx, instance, newLoadBalancerService(instance, deploymentInfo),
+		reqLogger, r.config.ScopedClient, r.config.Scheme, r.config.EventRecorder)
 	if err != nil {
 		return nil, err
 	}
 
 	// For IBM cloud also needs to annotate the allocated health check node port
-	if platformTypeOCP == string(configv1.IBMCloudPlatformType) {
-		healthPortStr := strconv.Itoa(int(svc.Spec.HealthCheckNodePort))
-		svc.ObjectMeta.Annotations = map[string]string{
-			"service.kubernetes.io/ibm-load-balancer-cloud-provider-vpc-health-check-port": healthPortStr,
-		}
-		svc, err = apply.Service(ctx, instance, svc, reqLogger, r.config.ScopedClient, r.config.Scheme)
+	if loadBalancerCloudProvider(deploymentInfo) == v1alpha1.IBMCloud {
+		annotated := newLoadBalancerService(instance, deploymentInfo)
+		annotated.Annotations["service.kubernetes.io/ibm-load-balancer-cloud-provider-vpc-health-check-port"] =
+			strconv.Itoa(int(svc.Spec.HealthCheckNodePort))
+		svc, err = apply.Apply(ctx, instance, annotated, reqLogger, r.config.ScopedClient, r.config.Scheme, r.config.EventRecorder)
 	}
 
 	return svc, err
 }
 
-func (r *Reconciler) getOCPPlatformType(ctx context.Context) (string, error) {
-	clusterInfra := &configv1.Infrastructure{}
-	err := r.config.GeneralClient.Get(ctx, types.NamespacedName{Name: "cluster"}, clusterInfra)
-
-	if resource.IsNotFoundErr(err) {
-		return "", nil
-	}
-
-	if err != nil {
-		return "", errors.Wrap(err, "error retrieving cluster Infrastructure resource")
-	}
-
-	if clusterInfra.Status.PlatformStatus == nil {
-		return string(clusterInfra.Status.Platform), nil //nolint:staticcheck //Purposely using deprecated field for backwards compatibility
+// loadBalancerCloudProvider returns the cloud provider whose load balancer settings apply. These are only applied on
+// OpenShift, where the cloud provider comes from the platform of the Infrastructure resource.
+func loadBalancerCloudProvider(deploymentInfo *v1alpha1.DeploymentInfo) v1alpha1.CloudProvider {
+	if deploymentInfo.KubernetesType != v1alpha1.OCP {
+		return ""
 	}
 
-	return string(clusterInfra.Status.PlatformStatus.Type), nil
+	return deploymentInfo.CloudProvider
 }
 
-func newLoadBalancerService(instance *v1alpha1.Submariner, platformTypeOCP string) *corev1.Service {
+func newLoadBalancerService(instance *v1alpha1.Submariner, deploymentInfo *v1alpha1.DeploymentInfo) *corev1.Service {
 	externalTrafficPolicy := corev1.ServiceExternalTrafficPolicyTypeLocal
 
 	var svcAnnotations map[string]string
 
-	switch platformTypeOCP {
-	case string(configv1.AWSPlatformType):
+	switch loadBalancerCloudProvider(deploymentInfo) {
+	case v1alpha1.AWS:
 		svcAnnotations = map[string]string{
 			"service.beta.kubernetes.io/aws-load-balancer-type": "nlb",
 		}
-	case string(configv1.IBMCloudPlatformType):
+	case v1alpha1.IBMCloud:
 		svcAnnotations = map[string]string{
 			"service.kubernetes.io/ibm-load-balancer-cloud-provider-enable-features":           "nlb",
 			"service.kubernetes.io/ibm-load-balancer-cloud-provider-ip-type":                   "public",
 			"service.kubernetes.io/ibm-load-balancer-cloud-provider-vpc-health-check-protocol": "http",
 		}
-	case string(configv1.KubevirtPlatformType):
+	case v1alpha1.KubeVirt:
 		if instance.Spec.HostedCluster {
 			externalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeCluster
 		}
@@ -29,6 +29,7 @@ import (
 	"github.com/submariner-io/submariner-operator/pkg/httpproxy"
 	"github.com/submariner-io/submariner-operator/pkg/images"
 	opnames "github.com/submariner-io/submariner-operator/pkg/names"
+	"github.com/submariner-io/submariner-operator/pkg/podtemplate"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,8 +38,8 @@ import (
 //nolint:wrapcheck // No need to wrap errors here.
 func (r *Reconciler) reconcileMetricsProxyDaemonSet(ctx context.Context, instance *v1alpha1.Submariner, reqLogger logr.Logger,
 ) (*appsv1.DaemonSet, error) {
-	return apply.DaemonSet(ctx, instance, newMetricsProxyDaemonSet(instance), reqLogger,
-		r.config.ScopedClient, r.config.Scheme)
+	return apply.Apply(ctx, instance, newMetricsProxyDaemonSet(instance), reqLogger,
+		r.config.ScopedClient, r.config.Scheme, r.config.EventRecorder)
 }
 
 func newMetricsProxyDaemonSet(cr *v1alpha1.Submariner) *appsv1.DaemonSet {
@@ -66,7 +67,8 @@ func newMetricsProxyDaemonSet(cr *v1alpha1.Submariner) *appsv1.DaemonSet {
 						*metricProxyContainer(cr, "gateway-metrics-proxy", strconv.Itoa(gatewayMetricsServicePort),
 							gatewayMetricsServerPort),
 					},
-					NodeSelector: map[string]string{"submariner.io/gateway": "true"},
+					NodeSelector:     map[string]string{"submariner.io/gateway": "true"},
+					ImagePullSecrets: cr.Spec.ImagePullSecrets,
 					// The MetricsProxy Pod must be able to run on any flagged node, regardless of existing taints
 					Tolerations: []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
 				},
@@ -79,6 +81,8 @@ func newMetricsProxyDaemonSet(cr *v1alpha1.Submariner) *appsv1.DaemonSet {
 			*metricProxyContainer(cr, "globalnet-metrics-proxy", strconv.Itoa(globalnetMetricsServicePort), globalnetMetricsServerPort))
 	}
 
+	podtemplate.ApplyOverrides(&daemonSet.Spec.Template, cr.Spec.Components, names.MetricsProxyComponent)
+
 	return daemonSet
 }
 
@@ -0,0 +1,79 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/names"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/internal/controllers/metrics"
+	"github.com/submariner-io/submariner-operator/pkg/discovery/network"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Render returns the resources the operator deploys for the given Submariner resource, built without contacting the
+// apiserver. The given cluster network and deployment info stand in for the discovery performed when reconciling, and
+// the instance status is updated as the reconciler does. If service discovery is enabled, the ServiceDiscovery resource
+// is included; its own resources are built by servicediscovery.Render.
+func Render(instance *v1alpha1.Submariner, clusterNetwork *network.ClusterNetwork, deploymentInfo *v1alpha1.DeploymentInfo,
+	scheme *runtime.Scheme,
+) ([]client.Object, error) {
+	r := NewReconciler(&Config{
+		Scheme:         scheme,
+		ClusterNetwork: clusterNetwork,
+		DeploymentInfo: deploymentInfo,
+	})
+
+	r.setNetworkStatus(instance, clusterNetwork, log)
+	instance.Status.DeploymentInfo = *deploymentInfo
+
+	objs := []client.Object{
+		newGatewayDaemonSet(instance, names.GatewayComponent),
+		metrics.NewService(gatewayMetricsServiceInfo(instance)),
+	}
+
+	if instance.Spec.LoadBalancerEnabled {
+		objs = append(objs, newLoadBalancerService(instance, deploymentInfo))
+	}
+
+	objs = append(objs, newRouteAgentDaemonSet(instance, names.RouteAgentComponent))
+
+	if instance.Spec.GlobalCIDR != "" {
+		objs = append(objs, newGlobalnetDaemonSet(instance, names.GlobalnetComponent),
+			metrics.NewService(globalnetMetricsServiceInfo(instance)))
+	}
+
+	objs = append(objs, newMetricsProxyDaemonSet(instance))
+
+	if instance.Spec.ServiceDiscoveryEnabled {
+		sd := newServiceDiscoveryCR(instance.Namespace)
+		sd.Spec = newServiceDiscoverySpec(instance)
+		objs = append(objs, sd)
+	}
+
+	for _, obj := range objs {
+		if err := controllerutil.SetControllerReference(instance, obj, scheme); err != nil {
+			return nil, errors.Wrapf(err, "error setting owner reference for %T %s", obj, obj.GetName())
+		}
+	}
+
+	return objs, nil
+}
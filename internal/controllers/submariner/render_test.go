@@ -0,0 +1,105 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner_test
+
+import (
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/admiral/pkg/names"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	submarinerController "github.com/submariner-io/submariner-operator/internal/controllers/submariner"
+	"github.com/submariner-io/submariner-operator/internal/controllers/test"
+	"github.com/submariner-io/submariner-operator/pkg/discovery/network"
+	opnames "github.com/submariner-io/submariner-operator/pkg/names"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Render", func() {
+	var (
+		submariner     *v1alpha1.Submariner
+		clusterNetwork *network.ClusterNetwork
+		deploymentInfo *v1alpha1.DeploymentInfo
+	)
+
+	BeforeEach(func() {
+		submariner = newSubmariner()
+		clusterNetwork = &network.ClusterNetwork{
+			NetworkPlugin: "fake",
+			ServiceCIDRs:  []string{testDetectedServiceCIDR},
+			PodCIDRs:      []string{testDetectedClusterCIDR},
+		}
+		deploymentInfo = &v1alpha1.DeploymentInfo{KubernetesType: v1alpha1.OCP, CloudProvider: v1alpha1.AWS}
+	})
+
+	render := func() map[string]client.Object {
+		objs, err := submarinerController.Render(submariner, clusterNetwork, deploymentInfo, scheme.Scheme)
+		Expect(err).To(Succeed())
+
+		byName := map[string]client.Object{}
+
+		for _, obj := range objs {
+			Expect(obj.GetOwnerReferences()).To(HaveLen(1))
+			Expect(obj.GetOwnerReferences()[0].Name).To(Equal(submarinerName))
+
+			byName[reflect.TypeOf(obj).Elem().Name()+"/"+obj.GetName()] = obj
+		}
+
+		return byName
+	}
+
+	It("should return the component resources built from the supplied network details", func() {
+		objs := render()
+
+		Expect(objs).To(HaveKey("DaemonSet/" + names.GatewayComponent))
+		Expect(objs).To(HaveKey("Service/" + names.GatewayComponent + "-metrics"))
+		Expect(objs).To(HaveKey("DaemonSet/" + names.RouteAgentComponent))
+		Expect(objs).To(HaveKey("DaemonSet/" + names.GlobalnetComponent))
+		Expect(objs).To(HaveKey("Service/" + names.GlobalnetComponent + "-metrics"))
+		Expect(objs).To(HaveKey("DaemonSet/" + names.MetricsProxyComponent))
+		Expect(objs).ToNot(HaveKey("Service/submariner-gateway"))
+		Expect(objs).ToNot(HaveKey("ServiceDiscovery/" + opnames.ServiceDiscoveryCrName))
+
+		envMap := test.EnvMapFrom(objs["DaemonSet/"+names.RouteAgentComponent].(*appsv1.DaemonSet))
+		Expect(envMap).To(HaveKeyWithValue("SUBMARINER_CLUSTERCIDR", testDetectedClusterCIDR))
+		Expect(envMap).To(HaveKeyWithValue("SUBMARINER_SERVICECIDR", testDetectedServiceCIDR))
+		Expect(envMap).To(HaveKeyWithValue("SUBMARINER_NETWORKPLUGIN", "fake"))
+	})
+
+	When("the load balancer and service discovery are enabled", func() {
+		BeforeEach(func() {
+			submariner.Spec.LoadBalancerEnabled = true
+			submariner.Spec.ServiceDiscoveryEnabled = true
+		})
+
+		It("should also return the load balancer Service and the ServiceDiscovery resource", func() {
+			objs := render()
+
+			Expect(objs).To(HaveKey("Service/submariner-gateway"))
+			Expect(objs["Service/submariner-gateway"].GetAnnotations()).To(HaveKey("service.beta.kubernetes.io/aws-load-balancer-type"))
+
+			Expect(objs).To(HaveKey("ServiceDiscovery/" + opnames.ServiceDiscoveryCrName))
+			Expect(objs["ServiceDiscovery/"+opnames.ServiceDiscoveryCrName].(*v1alpha1.ServiceDiscovery).Spec.ClusterID).To(
+				Equal(submariner.Spec.ClusterID))
+		})
+	})
+})
@@ -29,6 +29,7 @@ import (
 	"github.com/submariner-io/submariner-operator/pkg/httpproxy"
 	"github.com/submariner-io/submariner-operator/pkg/images"
 	opnames "github.com/submariner-io/submariner-operator/pkg/names"
+	"github.com/submariner-io/submariner-operator/pkg/podtemplate"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -40,8 +41,8 @@ import (
 func (r *Reconciler) reconcileRouteagentDaemonSet(ctx context.Context, instance *v1alpha1.Submariner,
 	reqLogger logr.Logger,
 ) (*appsv1.DaemonSet, error) {
-	return apply.DaemonSet(ctx, instance, newRouteAgentDaemonSet(instance, names.RouteAgentComponent),
-		reqLogger, r.config.ScopedClient, r.config.Scheme)
+	return apply.Apply(ctx, instance, newRouteAgentDaemonSet(instance, names.RouteAgentComponent),
+		reqLogger, r.config.ScopedClient, r.config.Scheme, r.config.EventRecorder)
 }
 
 func newRouteAgentDaemonSet(cr *v1alpha1.Submariner, name string) *appsv1.DaemonSet {
@@ -144,7 +145,7 @@ func newRouteAgentDaemonSet(cr *v1alpha1.Submariner, name string) *appsv1.Daemon
 								{Name: "SUBMARINER_DEBUG", Value: strconv.FormatBool(cr.Spec.Debug)},
 								{Name: "SUBMARINER_CLUSTERCIDR", Value: cr.Status.ClusterCIDR},
 								{Name: "SUBMARINER_SERVICECIDR", Value: cr.Status.ServiceCIDR},
-								{Name: "SUBMARINER_GLOBALCIDR", Value: cr.Spec.GlobalCIDR},
+								{Name: "SUBMARINER_GLOBALCIDR", Value: globalCIDRs(cr)},
 								{Name: "SUBMARINER_NETWORKPLUGIN", Value: cr.Status.NetworkPlugin},
 								{Name: "NODE_NAME", ValueFrom: &corev1.EnvVarSource{
 									FieldRef: &corev1.ObjectFieldSelector{
@@ -158,6 +159,7 @@ func newRouteAgentDaemonSet(cr *v1alpha1.Submariner, name string) *appsv1.Daemon
 						},
 					},
 					ServiceAccountName: names.RouteAgentComponent,
+					ImagePullSecrets:   cr.Spec.ImagePullSecrets,
 					HostNetwork:        true,
 					DNSPolicy:          corev1.DNSClusterFirstWithHostNet,
 					// The route agent engine on all nodes, regardless of existing taints
@@ -167,5 +169,7 @@ func newRouteAgentDaemonSet(cr *v1alpha1.Submariner, name string) *appsv1.Daemon
 		},
 	}
 
+	podtemplate.ApplyOverrides(&ds.Spec.Template, cr.Spec.Components, names.RouteAgentComponent)
+
 	return ds
 }
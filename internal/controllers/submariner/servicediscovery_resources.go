@@ -28,6 +28,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
@@ -40,31 +41,8 @@ func (r *Reconciler) serviceDiscoveryReconciler(ctx context.Context, submariner
 			sd := newServiceDiscoveryCR(submariner.Namespace)
 
 			result, err := controllerutil.CreateOrUpdate(ctx, r.config.ScopedClient, sd, func() error {
-				sd.Spec = v1alpha1.ServiceDiscoverySpec{
-					Version:                  submariner.Spec.Version,
-					Repository:               submariner.Spec.Repository,
-					BrokerK8sCA:              submariner.Spec.BrokerK8sCA,
-					BrokerK8sRemoteNamespace: submariner.Spec.BrokerK8sRemoteNamespace,
-					BrokerK8sApiServerToken:  submariner.Spec.BrokerK8sApiServerToken,
-					BrokerK8sApiServer:       submariner.Spec.BrokerK8sApiServer,
-					BrokerK8sInsecure:        submariner.Spec.BrokerK8sInsecure,
-					BrokerK8sSecret:          submariner.Spec.BrokerK8sSecret,
-					HaltOnCertificateError:   submariner.Spec.HaltOnCertificateError,
-					Debug:                    submariner.Spec.Debug,
-					ClusterID:                submariner.Spec.ClusterID,
-					Namespace:                submariner.Spec.Namespace,
-					GlobalnetEnabled:         submariner.Spec.GlobalCIDR != "",
-					ClustersetIPEnabled:      submariner.Spec.ClustersetIPEnabled,
-					ClustersetIPCIDR:         submariner.Spec.ClustersetIPCIDR,
-					ImageOverrides:           submariner.Spec.ImageOverrides,
-					CoreDNSCustomConfig:      submariner.Spec.CoreDNSCustomConfig,
-					NodeSelector:             submariner.Spec.NodeSelector,
-					Tolerations:              submariner.Spec.Tolerations,
-				}
-
-				if len(submariner.Spec.CustomDomains) > 0 {
-					sd.Spec.CustomDomains = submariner.Spec.CustomDomains
-				}
+				sd.Spec = newServiceDiscoverySpec(submariner)
+
 				// Set the owner and controller
 				return controllerutil.SetControllerReference(submariner, sd, r.config.Scheme)
 			})
@@ -96,6 +74,72 @@ func (r *Reconciler) serviceDiscoveryReconciler(ctx context.Context, submariner
 	return errors.Wrapf(err, "error reconciling the Service Discovery CR")
 }
 
+// pauseServiceDiscovery pauses the existing ServiceDiscovery resource, leaving the rest of its spec as is. It isn't created
+// if it doesn't exist.
+func (r *Reconciler) pauseServiceDiscovery(ctx context.Context, submariner *v1alpha1.Submariner, reqLogger logr.Logger) error {
+	//nolint:wrapcheck // No need to wrap errors here
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		sd := newServiceDiscoveryCR(submariner.Namespace)
+
+		err := r.config.ScopedClient.Get(ctx, client.ObjectKeyFromObject(sd), sd)
+		if apierrors.IsNotFound(err) || (err == nil && sd.Spec.Paused) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		sd.Spec.Paused = true
+
+		err = r.config.ScopedClient.Update(ctx, sd)
+		if err == nil {
+			reqLogger.Info("Paused Service Discovery CR", "Namespace", sd.Namespace, "Name", sd.Name)
+		}
+
+		return err
+	})
+
+	return errors.Wrapf(err, "error pausing the Service Discovery CR")
+}
+
+func newServiceDiscoverySpec(submariner *v1alpha1.Submariner) v1alpha1.ServiceDiscoverySpec {
+	spec := v1alpha1.ServiceDiscoverySpec{
+		Version:                  submariner.Spec.Version,
+		Repository:               submariner.Spec.Repository,
+		BrokerK8sCA:              submariner.Spec.BrokerK8sCA,
+		BrokerK8sRemoteNamespace: submariner.Spec.BrokerK8sRemoteNamespace,
+		BrokerK8sApiServerToken:  submariner.Spec.BrokerK8sApiServerToken,
+		BrokerK8sApiServer:       submariner.Spec.BrokerK8sApiServer,
+		BrokerK8sInsecure:        submariner.Spec.BrokerK8sInsecure,
+		BrokerK8sSecret:          submariner.Spec.BrokerK8sSecret,
+		HaltOnCertificateError:   submariner.Spec.HaltOnCertificateError,
+		Debug:                    submariner.Spec.Debug,
+		ClusterID:                submariner.Spec.ClusterID,
+		Namespace:                submariner.Spec.Namespace,
+		GlobalnetEnabled:         submariner.Spec.GlobalCIDR != "",
+		ClustersetIPEnabled:      submariner.Spec.ClustersetIPEnabled,
+		ClustersetIPCIDR:         submariner.Spec.ClustersetIPCIDR,
+		ImageOverrides:           submariner.Spec.ImageOverrides,
+		ImagePullSecrets:         submariner.Spec.ImagePullSecrets,
+		RegistryMirrors:          submariner.Spec.RegistryMirrors,
+		ImageDigests:             submariner.Spec.ImageDigests,
+		CoreDNSCustomConfig:      submariner.Spec.CoreDNSCustomConfig,
+		NodeSelector:             submariner.Spec.NodeSelector,
+		Tolerations:              submariner.Spec.Tolerations,
+		Components:               submariner.Spec.Components,
+		DriftPolicy:              submariner.Spec.DriftPolicy,
+		Paused:                   submariner.IsPaused(),
+		Uninstall:                submariner.Spec.Uninstall,
+	}
+
+	if len(submariner.Spec.CustomDomains) > 0 {
+		spec.CustomDomains = submariner.Spec.CustomDomains
+	}
+
+	return spec
+}
+
 func newServiceDiscoveryCR(namespace string) *v1alpha1.ServiceDiscovery {
 	return &v1alpha1.ServiceDiscovery{
 		ObjectMeta: metav1.ObjectMeta{
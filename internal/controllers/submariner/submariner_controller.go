@@ -21,7 +21,7 @@ package submariner
 import (
 	"context"
 	"encoding/base64"
-	"reflect"
+	"fmt"
 	"sync"
 	"time"
 
@@ -34,6 +34,7 @@ import (
 	"github.com/submariner-io/admiral/pkg/syncer"
 	"github.com/submariner-io/admiral/pkg/util"
 	submopv1a1 "github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/internal/controllers/uninstall"
 	"github.com/submariner-io/submariner-operator/pkg/discovery/network"
 	"github.com/submariner-io/submariner-operator/pkg/images"
 	"github.com/submariner-io/submariner-operator/pkg/names"
@@ -41,12 +42,14 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -73,8 +76,16 @@ type Config struct {
 	Scheme                       *runtime.Scheme
 	DynClient                    dynamic.Interface
 	ClusterNetwork               *network.ClusterNetwork
+	DeploymentInfo               *submopv1a1.DeploymentInfo
+	EventRecorder                record.EventRecorder
 	GetAuthorizedBrokerClientFor func(spec *submopv1a1.SubmarinerSpec, brokerToken, brokerCA string,
 		secretGVR schema.GroupVersionResource) (dynamic.Interface, error)
+	// GetAuthorizedBrokerControllerClientFor returns a controller-runtime client for the broker, used to manage the CIDRs
+	// allocated to the cluster.
+	GetAuthorizedBrokerControllerClientFor func(spec *submopv1a1.SubmarinerSpec, brokerToken, brokerCA string,
+		secretGVR schema.GroupVersionResource) (client.Client, error)
+	// PodLogs reads the logs of the failed uninstall Job pods, it's optional.
+	PodLogs uninstall.PodLogReader
 }
 
 // Reconciler reconciles a Submariner object.
@@ -100,6 +111,19 @@ type Reconciler struct {
 	syncerMutex           sync.Mutex
 
 	networkPluginSyncerRemoved bool
+
+	// The broker client is re-created only when the broker connection settings or credentials change.
+	brokerClient      client.Client
+	brokerClientKey   brokerClientKey
+	brokerClientMutex sync.Mutex
+}
+
+type brokerClientKey struct {
+	apiServer string
+	namespace string
+	token     string
+	ca        string
+	insecure  bool
 }
 
 // blank assignment to verify that Reconciler implements reconcile.Reconciler.
@@ -117,6 +141,14 @@ func NewReconciler(config *Config) *Reconciler {
 		r.config.GetAuthorizedBrokerClientFor = getAuthorizedBrokerClientFor
 	}
 
+	if r.config.GetAuthorizedBrokerControllerClientFor == nil {
+		r.config.GetAuthorizedBrokerControllerClientFor = func(spec *submopv1a1.SubmarinerSpec, brokerToken, brokerCA string,
+			secretGVR schema.GroupVersionResource,
+		) (client.Client, error) {
+			return getAuthorizedBrokerControllerClientFor(spec, brokerToken, brokerCA, secretGVR, r.config.Scheme)
+		}
+	}
+
 	return r
 }
 
@@ -159,55 +191,99 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		return r.runComponentCleanup(ctx, instance)
 	}
 
+	initialStatus := instance.Status.DeepCopy()
+	instance.Status.ObservedGeneration = instance.Generation
+	// Drifted resources are recorded afresh as they're applied
+	instance.Status.DriftDetected = nil
+
+	if instance.IsPaused() {
+		setCondition(instance, submopv1a1.PausedCondition, metav1.ConditionTrue, reasonPaused,
+			"The reconciliation is paused, the managed resources are left as they are")
+	} else {
+		meta.RemoveStatusCondition(&instance.Status.Conditions, submopv1a1.PausedCondition)
+	}
+
 	// Ensure we have a secret syncer
 	if err := r.setupSecretSyncer(ctx, instance, reqLogger, request.Namespace); err != nil {
-		return reconcile.Result{}, err
+		return r.reconcileFailed(ctx, instance, initialStatus, submopv1a1.BrokerConnectedCondition, reasonBrokerConnectionFailed, err)
 	}
 
-	initialStatus := instance.Status.DeepCopy()
+	if instance.Spec.BrokerK8sSecret == "" {
+		setCondition(instance, submopv1a1.BrokerConnectedCondition, metav1.ConditionUnknown, reasonBrokerSecretNotSet,
+			"No broker secret is configured, the operator does not connect to the broker")
+	} else if err := r.checkBrokerConnection(ctx, instance); err != nil {
+		// Not fatal, the local resources don't depend on the broker being reachable
+		setCondition(instance, submopv1a1.BrokerConnectedCondition, metav1.ConditionFalse, reasonBrokerConnectionFailed, err.Error())
+	} else {
+		setCondition(instance, submopv1a1.BrokerConnectedCondition, metav1.ConditionTrue, reasonBrokerSecretSynced,
+			"The broker secret is being synchronized from the broker")
+	}
 
 	// This has the side effect of setting the CIDRs in the Submariner instance.
-	_, err = r.discoverNetwork(ctx, instance, reqLogger)
+	clusterNetwork, err := r.discoverNetwork(ctx, instance, reqLogger)
 	if err != nil {
-		return reconcile.Result{}, err
+		return r.reconcileFailed(ctx, instance, initialStatus, submopv1a1.NetworkDiscoveredCondition, reasonNetworkDiscoveryFailed, err)
+	}
+
+	setCondition(instance, submopv1a1.NetworkDiscoveredCondition, metav1.ConditionTrue, reasonNetworkDiscovered,
+		fmt.Sprintf("Discovered network plugin %q", clusterNetwork.NetworkPlugin))
+
+	r.checkCIDROverlaps(ctx, instance)
+
+	// Not fatal, the previously reported deployment info is kept and the discovery is retried on the next reconcile
+	deploymentInfo, err := r.getDeploymentInfo(ctx)
+	if err != nil {
+		reqLogger.Error(err, "Error discovering the deployment info")
+	} else {
+		instance.Status.DeploymentInfo = *deploymentInfo
 	}
 
+	// Not fatal, the components keep using the current global CIDRs until the expansion succeeds
+	setGlobalCIDRsExpandedCondition(instance, r.reconcileGlobalCIDRs(ctx, instance))
+
 	gatewayDaemonSet, err := r.reconcileGatewayDaemonSet(ctx, instance, reqLogger)
 	if err != nil {
-		return reconcile.Result{}, err
+		return r.reconcileFailed(ctx, instance, initialStatus, submopv1a1.GatewayReadyCondition, reasonReconcileFailed, err)
 	}
 
 	var loadBalancer *corev1.Service
 	if instance.Spec.LoadBalancerEnabled {
 		loadBalancer, err = r.reconcileLoadBalancer(ctx, instance, reqLogger)
 		if err != nil {
-			return reconcile.Result{}, err
+			return r.reconcileFailed(ctx, instance, initialStatus, submopv1a1.GatewayReadyCondition, reasonReconcileFailed, err)
 		}
 	}
 
 	routeagentDaemonSet, err := r.reconcileRouteagentDaemonSet(ctx, instance, reqLogger)
 	if err != nil {
-		return reconcile.Result{}, err
+		return r.reconcileFailed(ctx, instance, initialStatus, submopv1a1.RouteAgentReadyCondition, reasonReconcileFailed, err)
 	}
 
 	var globalnetDaemonSet *appsv1.DaemonSet
 
 	if instance.Spec.GlobalCIDR != "" {
 		if globalnetDaemonSet, err = r.reconcileGlobalnetDaemonSet(ctx, instance, reqLogger); err != nil {
-			return reconcile.Result{}, err
+			return r.reconcileFailed(ctx, instance, initialStatus, submopv1a1.GlobalnetReadyCondition, reasonReconcileFailed, err)
 		}
 	}
 
 	if _, err = r.reconcileMetricsProxyDaemonSet(ctx, instance, reqLogger); err != nil {
-		return reconcile.Result{}, err
+		return r.reconcileFailed(ctx, instance, initialStatus, "", reasonReconcileFailed, err)
 	}
 
-	if err := r.removeNetworkPluginSyncerDeployment(ctx, instance); err != nil {
-		return reconcile.Result{}, err
-	}
+	if instance.IsPaused() {
+		// Only the pause is propagated, so that the ServiceDiscovery reconciler leaves the DNS configuration as is
+		if err := r.pauseServiceDiscovery(ctx, instance, reqLogger); err != nil {
+			return r.reconcileFailed(ctx, instance, initialStatus, "", reasonReconcileFailed, err)
+		}
+	} else {
+		if err := r.removeNetworkPluginSyncerDeployment(ctx, instance); err != nil {
+			return r.reconcileFailed(ctx, instance, initialStatus, "", reasonReconcileFailed, err)
+		}
 
-	if err := r.serviceDiscoveryReconciler(ctx, instance, reqLogger, instance.Spec.ServiceDiscoveryEnabled); err != nil {
-		return reconcile.Result{}, err
+		if err := r.serviceDiscoveryReconciler(ctx, instance, reqLogger, instance.Spec.ServiceDiscoveryEnabled); err != nil {
+			return r.reconcileFailed(ctx, instance, initialStatus, "", reasonReconcileFailed, err)
+		}
 	}
 
 	// Retrieve the gateway information
@@ -233,21 +309,21 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	if err != nil {
 		reqLogger.Error(err, "failed to check gateway daemonset containers")
 
-		return reconcile.Result{}, err
+		return r.reconcileFailed(ctx, instance, initialStatus, submopv1a1.GatewayReadyCondition, reasonStatusCheckFailed, err)
 	}
 
 	err = updateDaemonSetStatus(ctx, r.config.ScopedClient, routeagentDaemonSet, &instance.Status.RouteAgentDaemonSetStatus, request.Namespace)
 	if err != nil {
 		reqLogger.Error(err, "failed to check route agent daemonset containers")
 
-		return reconcile.Result{}, err
+		return r.reconcileFailed(ctx, instance, initialStatus, submopv1a1.RouteAgentReadyCondition, reasonStatusCheckFailed, err)
 	}
 
 	err = updateDaemonSetStatus(ctx, r.config.ScopedClient, globalnetDaemonSet, &instance.Status.GlobalnetDaemonSetStatus, request.Namespace)
 	if err != nil {
 		reqLogger.Error(err, "failed to check gateway daemonset containers")
 
-		return reconcile.Result{}, err
+		return r.reconcileFailed(ctx, instance, initialStatus, submopv1a1.GlobalnetReadyCondition, reasonStatusCheckFailed, err)
 	}
 
 	if loadBalancer != nil {
@@ -256,25 +332,38 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		instance.Status.LoadBalancerStatus.Status = nil
 	}
 
-	if !reflect.DeepEqual(instance.Status, initialStatus) {
-		err := r.config.ScopedClient.Status().Update(ctx, instance)
-		if apierrors.IsConflict(err) {
-			reqLogger.Info("conflict occurred on status update - requeuing")
+	setDaemonSetCondition(instance, submopv1a1.GatewayReadyCondition, gatewayDaemonSet)
+	setDaemonSetCondition(instance, submopv1a1.RouteAgentReadyCondition, routeagentDaemonSet)
 
-			return reconcile.Result{RequeueAfter: time.Millisecond * 100}, nil
-		}
+	if globalnetDaemonSet != nil {
+		setDaemonSetCondition(instance, submopv1a1.GlobalnetReadyCondition, globalnetDaemonSet)
+	} else {
+		meta.RemoveStatusCondition(&instance.Status.Conditions, submopv1a1.GlobalnetReadyCondition)
+	}
 
-		if err != nil {
-			return reconcile.Result{}, errors.Wrap(err, "failed to update the Submariner status")
-		}
+	setReadyCondition(instance)
+
+	err = r.updateStatus(ctx, instance, initialStatus)
+	if apierrors.IsConflict(err) {
+		reqLogger.Info("conflict occurred on status update - requeuing")
+
+		return reconcile.Result{RequeueAfter: time.Millisecond * 100}, nil
+	}
+
+	// The CIDRs of the other clusters on the broker aren't watched, the overlaps are checked periodically
+	if err == nil && meta.FindStatusCondition(instance.Status.Conditions, submopv1a1.CIDRsDistinctCondition) != nil {
+		return reconcile.Result{RequeueAfter: cidrOverlapCheckInterval}, nil
 	}
 
-	return reconcile.Result{}, nil
+	return reconcile.Result{}, err
 }
 
 func getImagePath(submariner *submopv1a1.Submariner, imageName, componentName string) string {
-	return images.GetImagePath(submariner.Spec.Repository, submariner.Spec.Version, imageName, componentName,
+	path := images.GetImagePath(submariner.Spec.Repository, submariner.Spec.Version, imageName, componentName,
 		submariner.Spec.ImageOverrides)
+
+	return images.ApplyRegistryMirrors(images.PinDigest(path, submariner.Spec.ImageDigests[componentName]),
+		submariner.Spec.RegistryMirrors)
 }
 
 func (r *Reconciler) getSubmariner(ctx context.Context, key types.NamespacedName) (*submopv1a1.Submariner, error) {
@@ -322,8 +411,9 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Named("submariner-controller").
 		// Watch for changes to primary resource Submariner
 		For(&submopv1a1.Submariner{}).
-		// Watch for changes to secondary resource DaemonSets and requeue the owner Submariner
+		// Watch for changes to secondary resources DaemonSets and Services and requeue the owner Submariner
 		Owns(&appsv1.DaemonSet{}).
+		Owns(&corev1.Service{}).
 		Watches(&submv1.Gateway{}, handler.EnqueueRequestsFromMapFunc(mapFn)).
 		Complete(r)
 }
@@ -388,6 +478,19 @@ func (r *Reconciler) setupSecretSyncer(ctx context.Context, instance *submopv1a1
 	return nil
 }
 
+// checkBrokerConnection verifies that the broker is reachable with the current credentials. The secret syncer only
+// contacts the broker when it starts so it doesn't detect a broker which became unreachable or credentials which were revoked.
+func (r *Reconciler) checkBrokerConnection(ctx context.Context, instance *submopv1a1.Submariner) error {
+	brokerClient, err := r.getBrokerControllerClient(ctx, instance)
+	if err != nil {
+		return err
+	}
+
+	err = brokerClient.List(ctx, &corev1.SecretList{}, client.InNamespace(instance.Spec.BrokerK8sRemoteNamespace), client.Limit(1))
+
+	return errors.Wrap(err, "error listing the secrets on the broker")
+}
+
 func (r *Reconciler) cancelSecretSyncer(instance *submopv1a1.Submariner) {
 	r.syncerMutex.Lock()
 	defer r.syncerMutex.Unlock()
@@ -401,11 +504,53 @@ func (r *Reconciler) cancelSecretSyncer(instance *submopv1a1.Submariner) {
 }
 
 func (r *Reconciler) getBrokerClient(ctx context.Context, instance *submopv1a1.Submariner) (dynamic.Interface, error) {
+	brokerToken, brokerCA, secretGVR, err := r.getBrokerCredentials(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.config.GetAuthorizedBrokerClientFor(&instance.Spec, brokerToken, brokerCA, *secretGVR)
+}
+
+func (r *Reconciler) getBrokerControllerClient(ctx context.Context, instance *submopv1a1.Submariner) (client.Client, error) {
+	brokerToken, brokerCA, secretGVR, err := r.getBrokerCredentials(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+
+	key := brokerClientKey{
+		apiServer: instance.Spec.BrokerK8sApiServer,
+		namespace: instance.Spec.BrokerK8sRemoteNamespace,
+		token:     brokerToken,
+		ca:        brokerCA,
+		insecure:  instance.Spec.BrokerK8sInsecure,
+	}
+
+	r.brokerClientMutex.Lock()
+	defer r.brokerClientMutex.Unlock()
+
+	if r.brokerClient != nil && r.brokerClientKey == key {
+		return r.brokerClient, nil
+	}
+
+	brokerClient, err := r.config.GetAuthorizedBrokerControllerClientFor(&instance.Spec, brokerToken, brokerCA, *secretGVR)
+	if err != nil {
+		return nil, err
+	}
+
+	r.brokerClient = brokerClient
+	r.brokerClientKey = key
+
+	return brokerClient, nil
+}
+
+func (r *Reconciler) getBrokerCredentials(ctx context.Context, instance *submopv1a1.Submariner,
+) (string, string, *schema.GroupVersionResource, error) {
 	spec := &instance.Spec
 
 	_, secretGVR, err := util.ToUnstructuredResource(&corev1.Secret{}, r.config.ScopedClient.RESTMapper())
 	if err != nil {
-		return nil, errors.Wrap(err, "error calculating the GVR for the Secret type")
+		return "", "", nil, errors.Wrap(err, "error calculating the GVR for the Secret type")
 	}
 
 	// We can't use files here since we don't have a mounted secret so read the broker Secret CR.
@@ -419,14 +564,39 @@ func (r *Reconciler) getBrokerClient(ctx context.Context, instance *submopv1a1.S
 		brokerToken = string(brokerSecret.Data["token"])
 		brokerCA = base64.StdEncoding.EncodeToString(brokerSecret.Data["ca.crt"])
 	} else if !apierrors.IsNotFound(err) {
-		return nil, errors.Wrapf(err, "error retrieving broker secret %q", spec.BrokerK8sSecret)
+		return "", "", nil, errors.Wrapf(err, "error retrieving broker secret %q", spec.BrokerK8sSecret)
 	}
 
-	return r.config.GetAuthorizedBrokerClientFor(spec, brokerToken, brokerCA, *secretGVR)
+	return brokerToken, brokerCA, secretGVR, nil
 }
 
 func getAuthorizedBrokerClientFor(spec *submopv1a1.SubmarinerSpec, brokerToken, brokerCA string, secretGVR schema.GroupVersionResource,
 ) (dynamic.Interface, error) {
+	brokerConfig, err := getAuthorizedBrokerConfigFor(spec, brokerToken, brokerCA, secretGVR)
+	if err != nil {
+		return nil, err
+	}
+
+	brokerClient, err := dynamic.NewForConfig(brokerConfig)
+
+	return brokerClient, errors.Wrap(err, "error building a dynamic client for the broker")
+}
+
+func getAuthorizedBrokerControllerClientFor(spec *submopv1a1.SubmarinerSpec, brokerToken, brokerCA string,
+	secretGVR schema.GroupVersionResource, scheme *runtime.Scheme,
+) (client.Client, error) {
+	brokerConfig, err := getAuthorizedBrokerConfigFor(spec, brokerToken, brokerCA, secretGVR)
+	if err != nil {
+		return nil, err
+	}
+
+	brokerClient, err := client.New(brokerConfig, client.Options{Scheme: scheme})
+
+	return brokerClient, errors.Wrap(err, "error building a client for the broker")
+}
+
+func getAuthorizedBrokerConfigFor(spec *submopv1a1.SubmarinerSpec, brokerToken, brokerCA string, secretGVR schema.GroupVersionResource,
+) (*rest.Config, error) {
 	brokerConfig, _, err := resource.GetAuthorizedRestConfigFromData(
 		spec.BrokerK8sApiServer,
 		brokerToken,
@@ -434,11 +604,6 @@ func getAuthorizedBrokerClientFor(spec *submopv1a1.SubmarinerSpec, brokerToken,
 		&rest.TLSClientConfig{Insecure: spec.BrokerK8sInsecure},
 		secretGVR,
 		spec.BrokerK8sRemoteNamespace)
-	if err != nil {
-		return nil, errors.Wrap(err, "error building an authorized RestConfig for the broker")
-	}
 
-	brokerClient, err := dynamic.NewForConfig(brokerConfig)
-
-	return brokerClient, errors.Wrap(err, "error building a dynamic client for the broker")
+	return brokerConfig, errors.Wrap(err, "error building an authorized RestConfig for the broker")
 }
@@ -20,8 +20,10 @@ package submariner_test
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"reflect"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -35,11 +37,18 @@ import (
 	"github.com/submariner-io/submariner-operator/api/v1alpha1"
 	"github.com/submariner-io/submariner-operator/internal/controllers/test"
 	"github.com/submariner-io/submariner-operator/internal/controllers/uninstall"
+	"github.com/submariner-io/submariner-operator/pkg/cidr"
+	"github.com/submariner-io/submariner-operator/pkg/cidrallocation"
+	"github.com/submariner-io/submariner-operator/pkg/discovery/globalnet"
 	opnames "github.com/submariner-io/submariner-operator/pkg/names"
+	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
 	"github.com/submariner-io/submariner/pkg/cni"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -125,6 +134,229 @@ func testSubmarinerResourceReconciliation() {
 			updated := t.getSubmariner(ctx)
 			Expect(updated.Status.ServiceCIDR).To(Equal(testConfiguredServiceCIDR))
 			Expect(updated.Status.ClusterCIDR).To(Equal(testConfiguredClusterCIDR))
+			Expect(t.receivedEvents()).To(ContainElement(ContainSubstring("CIDRMismatch")))
+
+			t.AssertReconcileSuccess(ctx)
+			Expect(t.receivedEvents()).NotTo(ContainElement(ContainSubstring("CIDRMismatch")))
+		})
+	})
+
+	When("multiple cluster CIDRs are detected", func() {
+		BeforeEach(func() {
+			t.clusterNetwork.PodCIDRs = append(t.clusterNetwork.PodCIDRs, "10.245.0.0/16")
+		})
+
+		It("should use all of them", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			updated := t.getSubmariner(ctx)
+			Expect(updated.Status.ClusterCIDRs).To(Equal([]string{testDetectedClusterCIDR, "10.245.0.0/16"}))
+			Expect(updated.Status.ClusterCIDR).To(Equal(testDetectedClusterCIDR + ",10.245.0.0/16"))
+			Expect(updated.Status.ServiceCIDRs).To(Equal([]string{testDetectedServiceCIDR}))
+
+			Expect(test.EnvMapFrom(t.AssertDaemonSet(ctx, names.GatewayComponent))).To(
+				HaveKeyWithValue("SUBMARINER_CLUSTERCIDR", updated.Status.ClusterCIDR))
+			Expect(test.EnvMapFrom(t.AssertDaemonSet(ctx, names.RouteAgentComponent))).To(
+				HaveKeyWithValue("SUBMARINER_CLUSTERCIDR", updated.Status.ClusterCIDR))
+		})
+	})
+
+	When("multiple cluster CIDRs are provided", func() {
+		It("should use all of them", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			initial := t.getSubmariner(ctx)
+			initial.Spec.ClusterCIDR = testConfiguredClusterCIDR + ", 192.168.68.0/24"
+
+			Expect(t.ScopedClient.Update(ctx, initial)).To(Succeed())
+
+			t.AssertReconcileSuccess(ctx)
+
+			updated := t.getSubmariner(ctx)
+			Expect(updated.Status.ClusterCIDRs).To(Equal([]string{testConfiguredClusterCIDR, "192.168.68.0/24"}))
+			Expect(updated.Status.ClusterCIDR).To(Equal(testConfiguredClusterCIDR + ",192.168.68.0/24"))
+		})
+	})
+
+	When("the cluster and service CIDR lists are provided", func() {
+		It("should use them over the deprecated comma-separated CIDRs", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			initial := t.getSubmariner(ctx)
+			initial.Spec.ClusterCIDR = "10.1.0.0/16"
+			initial.Spec.ClusterCIDRs = []string{testConfiguredClusterCIDR, "fd00:10:244::/56"}
+			initial.Spec.ServiceCIDRs = []string{testConfiguredServiceCIDR}
+
+			Expect(t.ScopedClient.Update(ctx, initial)).To(Succeed())
+
+			t.AssertReconcileSuccess(ctx)
+
+			updated := t.getSubmariner(ctx)
+			Expect(updated.Status.ClusterCIDRs).To(Equal([]string{testConfiguredClusterCIDR, "fd00:10:244::/56"}))
+			Expect(updated.Status.ServiceCIDRs).To(Equal([]string{testConfiguredServiceCIDR}))
+		})
+	})
+
+	When("the DaemonSets aren't scheduled yet", func() {
+		It("should report the Submariner resource as not ready", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			updated := t.getSubmariner(ctx)
+			Expect(updated.Status.ObservedGeneration).To(Equal(updated.Generation))
+			assertCondition(updated, v1alpha1.NetworkDiscoveredCondition, metav1.ConditionTrue, "NetworkDiscovered")
+			assertCondition(updated, v1alpha1.BrokerConnectedCondition, metav1.ConditionUnknown, "BrokerSecretNotConfigured")
+			assertCondition(updated, v1alpha1.GatewayReadyCondition, metav1.ConditionFalse, "NoNodesScheduled")
+			assertCondition(updated, v1alpha1.RouteAgentReadyCondition, metav1.ConditionFalse, "NoNodesScheduled")
+			assertCondition(updated, v1alpha1.GlobalnetReadyCondition, metav1.ConditionFalse, "NoNodesScheduled")
+			assertCondition(updated, v1alpha1.ReadyCondition, metav1.ConditionFalse, "NoNodesScheduled")
+		})
+	})
+
+	When("the DaemonSets are ready", func() {
+		It("should report the Submariner resource as ready", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			t.UpdateDaemonSetToReady(ctx, t.AssertDaemonSet(ctx, names.GatewayComponent))
+			t.UpdateDaemonSetToReady(ctx, t.AssertDaemonSet(ctx, names.RouteAgentComponent))
+			t.UpdateDaemonSetToReady(ctx, t.AssertDaemonSet(ctx, names.GlobalnetComponent))
+
+			t.AssertReconcileSuccess(ctx)
+
+			updated := t.getSubmariner(ctx)
+			assertCondition(updated, v1alpha1.GatewayReadyCondition, metav1.ConditionTrue, "Ready")
+			assertCondition(updated, v1alpha1.RouteAgentReadyCondition, metav1.ConditionTrue, "Ready")
+			assertCondition(updated, v1alpha1.GlobalnetReadyCondition, metav1.ConditionTrue, "Ready")
+			assertCondition(updated, v1alpha1.ReadyCondition, metav1.ConditionTrue, "Ready")
+		})
+	})
+
+	When("Globalnet isn't enabled", func() {
+		BeforeEach(func() {
+			t.submariner.Spec.GlobalCIDR = ""
+		})
+
+		It("should not report the GlobalnetReady condition", func(ctx SpecContext) {
+			t.AssertReconcileRequeue(ctx)
+
+			Expect(meta.FindStatusCondition(t.getSubmariner(ctx).Status.Conditions, v1alpha1.GlobalnetReadyCondition)).To(BeNil())
+		})
+
+		It("should report that the cluster CIDRs are distinct and re-check them periodically", func(ctx SpecContext) {
+			t.AssertReconcileRequeue(ctx)
+
+			assertCondition(t.getSubmariner(ctx), v1alpha1.CIDRsDistinctCondition, metav1.ConditionTrue, "CIDRsDistinct")
+		})
+
+		Context("and the CIDRs of other clusters overlap with the cluster CIDRs", func() {
+			BeforeEach(func() {
+				t.initBrokerObjs = []client.Object{
+					&submarinerv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{Name: "north", Namespace: t.submariner.Spec.BrokerK8sRemoteNamespace},
+						Spec: submarinerv1.ClusterSpec{
+							ClusterID:   "north",
+							ClusterCIDR: []string{"10.244.128.0/17"},
+							ServiceCIDR: []string{"100.95.0.0/16"},
+						},
+					},
+					&submarinerv1.Endpoint{
+						ObjectMeta: metav1.ObjectMeta{Name: "west-submariner", Namespace: t.submariner.Spec.BrokerK8sRemoteNamespace},
+						Spec: submarinerv1.EndpointSpec{
+							ClusterID: "west",
+							Subnets:   []string{"100.94.0.0/24"},
+						},
+					},
+					&submarinerv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{Name: t.submariner.Spec.ClusterID, Namespace: t.submariner.Spec.BrokerK8sRemoteNamespace},
+						Spec: submarinerv1.ClusterSpec{
+							ClusterID:   t.submariner.Spec.ClusterID,
+							ClusterCIDR: []string{testDetectedClusterCIDR},
+						},
+					},
+				}
+			})
+
+			It("should report the overlaps, recommending Globalnet", func(ctx SpecContext) {
+				t.AssertReconcileRequeue(ctx)
+
+				updated := t.getSubmariner(ctx)
+				assertCondition(updated, v1alpha1.CIDRsDistinctCondition, metav1.ConditionFalse, "CIDROverlap")
+
+				condition := meta.FindStatusCondition(updated.Status.Conditions, v1alpha1.CIDRsDistinctCondition)
+				Expect(condition.Message).To(ContainSubstring("pod CIDR %s overlaps with cluster(s) north", testDetectedClusterCIDR))
+				Expect(condition.Message).To(ContainSubstring("service CIDR %s overlaps with cluster(s) west", testDetectedServiceCIDR))
+				Expect(condition.Message).To(ContainSubstring("Enable Globalnet"))
+
+				Expect(t.receivedEvents()).To(ContainElement(HavePrefix("Warning CIDROverlap")))
+			})
+
+			It("should report the overlaps with clusters which joined since the last check", func(ctx SpecContext) {
+				t.AssertReconcileRequeue(ctx)
+
+				Expect(t.brokerClient.Create(ctx, &submarinerv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Name: "south", Namespace: t.submariner.Spec.BrokerK8sRemoteNamespace},
+					Spec: submarinerv1.ClusterSpec{
+						ClusterID:   "south",
+						ClusterCIDR: []string{"10.244.0.0/24"},
+					},
+				})).To(Succeed())
+
+				t.AssertReconcileRequeue(ctx)
+
+				condition := meta.FindStatusCondition(t.getSubmariner(ctx).Status.Conditions, v1alpha1.CIDRsDistinctCondition)
+				Expect(condition.Message).To(ContainSubstring("pod CIDR %s overlaps with cluster(s) north, south",
+					testDetectedClusterCIDR))
+				Expect(t.brokerClientsCreated).To(Equal(1))
+			})
+		})
+	})
+
+	When("the Submariner resource is paused", func() {
+		BeforeEach(func() {
+			t.submariner.Annotations = map[string]string{v1alpha1.PausedAnnotation: "true"}
+			t.submariner.Spec.ServiceDiscoveryEnabled = true
+		})
+
+		It("should report the Paused condition without creating any resources until it's resumed", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+			t.AssertNoDaemonSet(ctx, names.GatewayComponent)
+			t.AssertNoDaemonSet(ctx, names.RouteAgentComponent)
+			Expect(errors.IsNotFound(t.ScopedClient.Get(ctx, types.NamespacedName{Name: opnames.ServiceDiscoveryCrName,
+				Namespace: submarinerNamespace}, &v1alpha1.ServiceDiscovery{}))).To(BeTrue())
+			assertCondition(t.getSubmariner(ctx), v1alpha1.PausedCondition, metav1.ConditionTrue, "Paused")
+
+			resumed := t.getSubmariner(ctx)
+			resumed.Annotations = nil
+			Expect(t.ScopedClient.Update(ctx, resumed)).To(Succeed())
+
+			t.AssertReconcileSuccess(ctx)
+			t.assertGatewayDaemonSet(ctx)
+			Expect(t.ScopedClient.Get(ctx, types.NamespacedName{Name: opnames.ServiceDiscoveryCrName, Namespace: submarinerNamespace},
+				&v1alpha1.ServiceDiscovery{})).To(Succeed())
+			Expect(meta.FindStatusCondition(t.getSubmariner(ctx).Status.Conditions, v1alpha1.PausedCondition)).To(BeNil())
+		})
+
+		Context("after the ServiceDiscovery resource was created", func() {
+			It("should only propagate the pause to the ServiceDiscovery resource", func(ctx SpecContext) {
+				resumed := t.getSubmariner(ctx)
+				resumed.Annotations = nil
+				Expect(t.ScopedClient.Update(ctx, resumed)).To(Succeed())
+
+				t.AssertReconcileSuccess(ctx)
+
+				serviceDiscovery := t.assertServiceDiscovery(ctx)
+				Expect(serviceDiscovery.Spec.Paused).To(BeFalse())
+
+				paused := t.getSubmariner(ctx)
+				paused.Annotations = map[string]string{v1alpha1.PausedAnnotation: "true"}
+				paused.Spec.Debug = !serviceDiscovery.Spec.Debug
+				Expect(t.ScopedClient.Update(ctx, paused)).To(Succeed())
+
+				t.AssertReconcileSuccess(ctx)
+
+				pausedServiceDiscovery := t.assertServiceDiscovery(ctx)
+				Expect(pausedServiceDiscovery.Spec.Paused).To(BeTrue())
+				Expect(pausedServiceDiscovery.Spec.Debug).To(Equal(serviceDiscovery.Spec.Debug))
+			})
 		})
 	})
 
@@ -197,6 +429,61 @@ func testDaemonSetReconciliation() {
 		})
 	})
 
+	When("component overrides are configured", func() {
+		resources := corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: apiresource.MustParse("100m")},
+		}
+		tolerations := []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "gateway"}}
+
+		BeforeEach(func() {
+			t.submariner.Spec.Components = map[string]v1alpha1.ComponentSpec{
+				names.GatewayComponent: {
+					Resources:         &resources,
+					NodeSelector:      map[string]string{"pool": "gateways"},
+					Tolerations:       tolerations,
+					PriorityClassName: "system-node-critical",
+				},
+			}
+		})
+
+		It("should apply them to the component's DaemonSet only", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			podSpec := &t.AssertDaemonSet(ctx, names.GatewayComponent).Spec.Template.Spec
+			Expect(podSpec.Containers[0].Resources).To(Equal(resources))
+			Expect(podSpec.InitContainers[0].Resources).To(Equal(resources))
+			Expect(podSpec.NodeSelector).To(Equal(map[string]string{"submariner.io/gateway": "true", "pool": "gateways"}))
+			Expect(podSpec.Tolerations).To(Equal(tolerations))
+			Expect(podSpec.PriorityClassName).To(Equal("system-node-critical"))
+
+			podSpec = &t.AssertDaemonSet(ctx, names.RouteAgentComponent).Spec.Template.Spec
+			Expect(podSpec.Containers[0].Resources).To(Equal(corev1.ResourceRequirements{}))
+			Expect(podSpec.Tolerations).To(Equal([]corev1.Toleration{{Operator: corev1.TolerationOpExists}}))
+		})
+	})
+
+	When("image pull secrets and digests are configured", func() {
+		const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+		BeforeEach(func() {
+			t.submariner.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "corp-registry"}}
+			t.submariner.Spec.ImageDigests = map[string]string{names.GatewayComponent: digest}
+		})
+
+		It("should attach the secrets and pin the images", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			podSpec := &t.AssertDaemonSet(ctx, names.GatewayComponent).Spec.Template.Spec
+			Expect(podSpec.ImagePullSecrets).To(Equal(t.submariner.Spec.ImagePullSecrets))
+			Expect(podSpec.Containers[0].Image).To(Equal(
+				fmt.Sprintf("%s/%s@%s", t.submariner.Spec.Repository, opnames.GatewayImage, digest)))
+
+			podSpec = &t.AssertDaemonSet(ctx, names.RouteAgentComponent).Spec.Template.Spec
+			Expect(podSpec.ImagePullSecrets).To(Equal(t.submariner.Spec.ImagePullSecrets))
+			Expect(podSpec.Containers[0].Image).To(HaveSuffix(":" + t.submariner.Spec.Version))
+		})
+	})
+
 	When("the submariner route-agent DaemonSet doesn't exist", func() {
 		It("should create it", func(ctx SpecContext) {
 			t.AssertReconcileSuccess(ctx)
@@ -256,10 +543,69 @@ func testDaemonSetReconciliation() {
 		})
 	})
 
+	When("the global CIDR is to be expanded", func() {
+		BeforeEach(func() {
+			t.submariner.Spec.GlobalCIDRExpansions = 1
+
+			globalnetConfigMap, err := globalnet.NewGlobalnetConfigMap(true, "169.252.0.0/14", 65536, 0,
+				t.submariner.Spec.BrokerK8sRemoteNamespace)
+			Expect(err).To(Succeed())
+
+			t.initBrokerObjs = []client.Object{globalnetConfigMap}
+		})
+
+		It("should allocate an additional block on the broker and pass all the global CIDRs to the DaemonSets",
+			func(ctx SpecContext) {
+				expCIDRs := []string{t.submariner.Spec.GlobalCIDR, "169.252.0.0/16"}
+
+				t.AssertReconcileSuccess(ctx)
+
+				Expect(t.getSubmariner(ctx).Status.GlobalCIDRs).To(Equal(expCIDRs))
+
+				globalnetInfo, _, err := globalnet.GetGlobalNetworks(ctx, t.brokerClient, t.submariner.Spec.BrokerK8sRemoteNamespace)
+				Expect(err).To(Succeed())
+				Expect(globalnetInfo.Clusters).To(HaveKey(t.submariner.Spec.ClusterID))
+				Expect(globalnetInfo.Clusters[t.submariner.Spec.ClusterID].CIDRs).To(Equal(expCIDRs))
+
+				for _, name := range []string{names.GlobalnetComponent, names.GatewayComponent, names.RouteAgentComponent} {
+					Expect(test.EnvMapFrom(t.AssertDaemonSet(ctx, name))).To(HaveKeyWithValue("SUBMARINER_GLOBALCIDR",
+						strings.Join(expCIDRs, ",")), "DaemonSet %q", name)
+				}
+
+				Expect(t.receivedEvents()).To(ContainElement(HavePrefix("Normal GlobalCIDRExpanded")))
+				assertCondition(t.getSubmariner(ctx), v1alpha1.GlobalCIDRsExpandedCondition, metav1.ConditionTrue,
+					"GlobalCIDRExpanded")
+			})
+
+		Context("and the expansion fails", func() {
+			BeforeEach(func() {
+				t.initBrokerObjs = nil
+			})
+
+			It("should report the failure and keep deploying with the current global CIDRs", func(ctx SpecContext) {
+				t.AssertReconcileSuccess(ctx)
+
+				updated := t.getSubmariner(ctx)
+				Expect(updated.Status.GlobalCIDRs).To(Equal([]string{t.submariner.Spec.GlobalCIDR}))
+				assertCondition(updated, v1alpha1.GlobalCIDRsExpandedCondition, metav1.ConditionFalse, "GlobalCIDRExpansionFailed")
+
+				Expect(test.EnvMapFrom(t.AssertDaemonSet(ctx, names.GlobalnetComponent))).To(HaveKeyWithValue("SUBMARINER_GLOBALCIDR",
+					t.submariner.Spec.GlobalCIDR))
+			})
+		})
+	})
+
 	When("DaemonSet creation fails", func() {
 		BeforeEach(func() {
-			t.ScopedClient = fake.NewReactingClient(t.NewScopedClient()).AddReactor(fake.Create, &appsv1.DaemonSet{},
-				fake.FailingReaction(nil))
+			t.InterceptorFuncs.Patch = func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch,
+				opts ...client.PatchOption,
+			) error {
+				if _, ok := obj.(*appsv1.DaemonSet); ok {
+					return errors.NewBadRequest("fake error")
+				}
+
+				return test.ServerSideApply(ctx, c, obj, patch, opts...)
+			}
 		})
 
 		It("should return an error", func(ctx SpecContext) {
@@ -278,6 +624,42 @@ func testDaemonSetReconciliation() {
 			_, err := t.DoReconcile(ctx)
 			Expect(err).To(HaveOccurred())
 		})
+
+		It("should report the failure in the status conditions", func(ctx SpecContext) {
+			_, err := t.DoReconcile(ctx)
+			Expect(err).To(HaveOccurred())
+
+			updated := t.getSubmariner(ctx)
+			assertCondition(updated, v1alpha1.GatewayReadyCondition, metav1.ConditionFalse, "ReconcileFailed")
+			assertCondition(updated, v1alpha1.ReadyCondition, metav1.ConditionFalse, "ReconcileFailed")
+		})
+
+		It("should record a single warning event while the failure persists", func(ctx SpecContext) {
+			_, err := t.DoReconcile(ctx)
+			Expect(err).To(HaveOccurred())
+
+			_, err = t.DoReconcile(ctx)
+			Expect(err).To(HaveOccurred())
+
+			var failedEvents []string
+			Expect(t.receivedEvents()).To(ContainElement(HavePrefix("Warning ReconcileFailed"), &failedEvents))
+			Expect(failedEvents).To(HaveLen(1))
+		})
+	})
+
+	When("DaemonSet retrieval fails with a conflict", func() {
+		BeforeEach(func() {
+			t.ScopedClient = fake.NewReactingClient(t.NewScopedClient()).AddReactor(fake.Get, &appsv1.DaemonSet{},
+				fake.FailingReaction(errors.NewConflict(schema.GroupResource{Resource: "daemonsets"}, "submariner-gateway",
+					fmt.Errorf("fake conflict"))))
+		})
+
+		It("should requeue without recording a warning event", func(ctx SpecContext) {
+			r, err := t.DoReconcile(ctx)
+			Expect(err).To(Succeed())
+			Expect(r.Requeue).To(BeTrue())
+			Expect(t.receivedEvents()).NotTo(ContainElement(HavePrefix("Warning ReconcileFailed")))
+		})
 	})
 
 	When("proxy environment variables are set", func() {
@@ -364,7 +746,8 @@ func testLoadBalancerReconciliation() {
 
 		Context("and the Openshift platform type is AWS", func() {
 			BeforeEach(func() {
-				t.InitGeneralClientObjs = append(t.InitGeneralClientObjs, newInfrastructureCluster(v1config.AWSPlatformType))
+				t.InitGeneralClientObjs = append(t.InitGeneralClientObjs, newClusterVersion(),
+					newInfrastructureCluster(v1config.AWSPlatformType))
 			})
 
 			It("should create the correct load balancer service", func(ctx SpecContext) {
@@ -375,9 +758,58 @@ func testLoadBalancerReconciliation() {
 			})
 		})
 
+		Context("and the nodes run on AWS", func() {
+			BeforeEach(func() {
+				t.InitGeneralClientObjs = append(t.InitGeneralClientObjs, &corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+					Spec:       corev1.NodeSpec{ProviderID: "aws:///us-east-1a/i-0123"},
+				})
+			})
+
+			It("should report the cloud provider and leave the load balancer service as on other platforms", func(ctx SpecContext) {
+				t.AssertReconcileSuccess(ctx)
+
+				service := t.assertLoadBalancerService(ctx)
+				Expect(service.Annotations).NotTo(HaveKey("service.beta.kubernetes.io/aws-load-balancer-type"))
+				Expect(t.getSubmariner(ctx).Status.DeploymentInfo.CloudProvider).To(BeEquivalentTo(v1alpha1.AWS))
+			})
+		})
+
+		Context("and the deployment info discovery fails", func() {
+			BeforeEach(func() {
+				t.GeneralClient = fake.NewReactingClient(t.NewGeneralClient()).AddReactor(fake.Get, &v1config.Infrastructure{},
+					fake.FailingReaction(nil))
+			})
+
+			It("should not create the load balancer service", func(ctx SpecContext) {
+				t.AssertReconcileError(ctx)
+
+				_, err := t.getLoadBalancerService(ctx)
+				Expect(errors.IsNotFound(err)).To(BeTrue())
+			})
+
+			Context("after a previous discovery", func() {
+				BeforeEach(func() {
+					t.submariner.Status.DeploymentInfo = v1alpha1.DeploymentInfo{
+						KubernetesType: v1alpha1.OCP,
+						CloudProvider:  v1alpha1.AWS,
+					}
+				})
+
+				It("should keep the previously discovered cloud provider", func(ctx SpecContext) {
+					t.AssertReconcileSuccess(ctx)
+
+					service := t.assertLoadBalancerService(ctx)
+					Expect(service.Annotations).To(HaveKeyWithValue("service.beta.kubernetes.io/aws-load-balancer-type", "nlb"))
+					Expect(t.getSubmariner(ctx).Status.DeploymentInfo.CloudProvider).To(BeEquivalentTo(v1alpha1.AWS))
+				})
+			})
+		})
+
 		Context("and the Openshift platform type is IBMCloud", func() {
 			BeforeEach(func() {
-				t.InitGeneralClientObjs = append(t.InitGeneralClientObjs, newInfrastructureCluster(v1config.IBMCloudPlatformType))
+				t.InitGeneralClientObjs = append(t.InitGeneralClientObjs, newClusterVersion(),
+					newInfrastructureCluster(v1config.IBMCloudPlatformType))
 			})
 
 			It("should create the correct load balancer service", func(ctx SpecContext) {
@@ -442,6 +874,25 @@ func testBrokerSecretReconciliation() {
 			})
 		})
 
+		It("should report the broker as connected", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			assertCondition(t.getSubmariner(ctx), v1alpha1.BrokerConnectedCondition, metav1.ConditionTrue, "BrokerSecretSynced")
+		})
+
+		Context("and the broker isn't reachable", func() {
+			JustBeforeEach(func() {
+				t.brokerClient = fake.NewReactingClient(t.brokerClient).AddReactor(fake.List, &corev1.SecretList{},
+					fake.FailingReaction(nil))
+			})
+
+			It("should report the broker as not connected", func(ctx SpecContext) {
+				t.AssertReconcileSuccess(ctx)
+
+				assertCondition(t.getSubmariner(ctx), v1alpha1.BrokerConnectedCondition, metav1.ConditionFalse, "BrokerConnectionFailed")
+			})
+		})
+
 		Context("and the local secret already exists", func() {
 			BeforeEach(func() {
 				t.submariner.Spec.BrokerK8sSecret = "submariner-broker-secret"
@@ -482,10 +933,24 @@ func restoreOrUnsetEnv(envVar string, wasSet bool, value string) {
 	}
 }
 
-func (t *testDriver) assertLoadBalancerService(ctx context.Context) *corev1.Service {
+func (t *testDriver) assertServiceDiscovery(ctx context.Context) *v1alpha1.ServiceDiscovery {
+	serviceDiscovery := &v1alpha1.ServiceDiscovery{}
+	Expect(t.ScopedClient.Get(ctx, types.NamespacedName{Name: opnames.ServiceDiscoveryCrName, Namespace: submarinerNamespace},
+		serviceDiscovery)).To(Succeed())
+
+	return serviceDiscovery
+}
+
+func (t *testDriver) getLoadBalancerService(ctx context.Context) (*corev1.Service, error) {
 	service := &corev1.Service{}
 	err := t.ScopedClient.Get(ctx, types.NamespacedName{Name: "submariner-gateway", Namespace: submarinerNamespace},
 		service)
+
+	return service, err
+}
+
+func (t *testDriver) assertLoadBalancerService(ctx context.Context) *corev1.Service {
+	service, err := t.getLoadBalancerService(ctx)
 	Expect(err).To(Succeed())
 	Expect(service.Spec.Type).To(Equal(corev1.ServiceTypeLoadBalancer))
 
@@ -585,6 +1050,105 @@ func testDeletion() {
 			t.AssertNoDaemonSet(ctx, opnames.AppendUninstall(names.RouteAgentComponent))
 
 			t.awaitSubmarinerDeleted()
+
+			events := t.receivedEvents()
+			Expect(events).To(ContainElement(ContainSubstring("UninstallCompleted")))
+
+			var started []string
+			Expect(events).To(ContainElement(ContainSubstring("UninstallStarted"), &started))
+			Expect(started).To(HaveLen(1))
+		})
+
+		It("should release the CIDRs allocated on the broker", func(ctx SpecContext) {
+			globalnetConfigMap, err := globalnet.NewGlobalnetConfigMap(true, "168.254.0.0/16", 8192, 0,
+				t.submariner.Spec.BrokerK8sRemoteNamespace)
+			Expect(err).To(Succeed())
+
+			for _, info := range []cidr.ClusterInfo{
+				{ClusterID: t.submariner.Spec.ClusterID, CIDRs: []string{"168.254.0.0/19"}},
+				{ClusterID: "other", CIDRs: []string{"168.254.32.0/19"}},
+			} {
+				Expect(cidr.AddClusterInfoData(globalnetConfigMap, info)).To(Succeed())
+			}
+
+			Expect(t.brokerClient.Create(ctx, globalnetConfigMap)).To(Succeed())
+
+			allocation := &v1alpha1.CIDRAllocation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cidrallocation.Name(v1alpha1.ClustersetIPPool, t.submariner.Spec.ClusterID),
+					Namespace: t.submariner.Spec.BrokerK8sRemoteNamespace,
+				},
+				Spec: v1alpha1.CIDRAllocationSpec{
+					ClusterID: t.submariner.Spec.ClusterID,
+					Pool:      v1alpha1.ClustersetIPPool,
+					CIDRs:     []string{"243.0.0.0/20"},
+				},
+			}
+			Expect(t.brokerClient.Create(ctx, allocation)).To(Succeed())
+
+			t.AssertReconcileRequeue(ctx)
+
+			t.UpdateDaemonSetToReady(ctx, t.assertUninstallGatewayDaemonSet(ctx))
+			t.UpdateDaemonSetToReady(ctx, t.assertUninstallRouteAgentDaemonSet(ctx))
+
+			t.AssertReconcileSuccess(ctx)
+			t.awaitSubmarinerDeleted()
+
+			globalnetConfigMap, err = globalnet.GetConfigMap(ctx, t.brokerClient, t.submariner.Spec.BrokerK8sRemoteNamespace)
+			Expect(err).To(Succeed())
+
+			clusterInfo, err := cidr.ExtractClusterInfo(globalnetConfigMap)
+			Expect(err).To(Succeed())
+			Expect(clusterInfo).To(HaveLen(1))
+			Expect(clusterInfo).To(HaveKey("other"))
+
+			err = t.brokerClient.Get(ctx, client.ObjectKeyFromObject(allocation), allocation)
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		Context("and no broker is configured", func() {
+			BeforeEach(func() {
+				t.submariner.Spec.BrokerK8sApiServer = ""
+				t.submariner.Spec.BrokerK8sApiServerToken = ""
+				t.submariner.Spec.BrokerK8sSecret = ""
+			})
+
+			It("should not try to release the CIDRs on the broker", func(ctx SpecContext) {
+				t.AssertReconcileRequeue(ctx)
+
+				t.UpdateDaemonSetToReady(ctx, t.assertUninstallGatewayDaemonSet(ctx))
+				t.UpdateDaemonSetToReady(ctx, t.assertUninstallRouteAgentDaemonSet(ctx))
+
+				t.AssertReconcileSuccess(ctx)
+				t.awaitSubmarinerDeleted()
+
+				Expect(t.brokerClientsCreated).To(BeZero())
+				Expect(t.receivedEvents()).ToNot(ContainElement(ContainSubstring("BrokerDeregistrationFailed")))
+			})
+		})
+	})
+
+	Context("and image pull secrets and registry mirrors are configured", func() {
+		pullSecrets := []corev1.LocalObjectReference{{Name: "corp-registry"}}
+
+		BeforeEach(func() {
+			t.submariner.Spec.GlobalCIDR = ""
+			t.submariner.Spec.ImagePullSecrets = pullSecrets
+			t.submariner.Spec.RegistryMirrors = []v1alpha1.RegistryMirror{
+				{Source: t.submariner.Spec.Repository, Mirror: "registry.corp/mirror"},
+			}
+
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, t.NewDaemonSet(names.RouteAgentComponent))
+		})
+
+		It("should use them in the uninstall DaemonSets", func(ctx SpecContext) {
+			t.AssertReconcileRequeue(ctx)
+
+			podSpec := &t.AssertDaemonSet(ctx, opnames.AppendUninstall(names.RouteAgentComponent)).Spec.Template.Spec
+			Expect(podSpec.ImagePullSecrets).To(Equal(pullSecrets))
+			Expect(podSpec.InitContainers[0].Image).To(Equal(
+				fmt.Sprintf("registry.corp/mirror/%s:%s", opnames.RouteAgentImage, t.submariner.Spec.Version)))
+			Expect(podSpec.Containers[0].Image).To(HavePrefix("registry.corp/mirror/" + opnames.NettestImage + ":"))
 		})
 	})
 
@@ -633,6 +1197,130 @@ func testDeletion() {
 			t.AssertNoDaemonSet(ctx, opnames.AppendUninstall(names.RouteAgentComponent))
 
 			t.awaitSubmarinerDeleted()
+
+			Expect(t.receivedEvents()).To(ContainElement(ContainSubstring("UninstallTimedOut")))
+		})
+	})
+
+	Context("and the uninstall doesn't complete in time with the BlockUntilClean policy", func() {
+		BeforeEach(func() {
+			t.submariner.Spec.GlobalCIDR = ""
+			t.submariner.Spec.Uninstall = &v1alpha1.UninstallSpec{
+				Timeout:       &metav1.Duration{Duration: time.Second},
+				TimeoutPolicy: v1alpha1.UninstallBlockUntilClean,
+			}
+
+			deletionTimestamp = metav1.NewTime(time.Now().Add(-time.Minute))
+			t.submariner.SetDeletionTimestamp(&deletionTimestamp)
+
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, t.NewDaemonSet(names.GatewayComponent))
+		})
+
+		It("should report the pending nodes and keep waiting", func(ctx SpecContext) {
+			t.AssertReconcileRequeue(ctx)
+
+			uninstallDS := t.assertUninstallGatewayDaemonSet(ctx)
+			t.UpdateDaemonSetToScheduled(ctx, uninstallDS)
+
+			pod := t.NewPodWithLabel("app", uninstallDS.Name)
+			pod.Labels = uninstallDS.Spec.Selector.MatchLabels
+			pod.Spec.NodeName = "node-1"
+			Expect(t.ScopedClient.Create(ctx, pod)).To(Succeed())
+
+			t.AssertReconcileRequeue(ctx)
+			t.awaitFinalizer()
+
+			uninstallStatus := t.getSubmariner(ctx).Status.Uninstall
+			Expect(uninstallStatus).ToNot(BeNil())
+			Expect(uninstallStatus.TimedOut).To(BeTrue())
+			Expect(uninstallStatus.Components).To(ContainElement(v1alpha1.ComponentUninstallStatus{
+				Name:         names.GatewayComponent,
+				State:        v1alpha1.UninstallAwaitingUninstallComplete,
+				PendingNodes: []string{"node-1"},
+			}))
+			Expect(t.receivedEvents()).To(ContainElement(ContainSubstring("UninstallBlocked")))
+
+			t.UpdateDaemonSetToReady(ctx, t.assertUninstallRouteAgentDaemonSet(ctx))
+			t.UpdateDaemonSetToReady(ctx, uninstallDS)
+
+			t.AssertReconcileSuccess(ctx)
+			t.awaitSubmarinerDeleted()
+		})
+	})
+
+	Context("and the uninstall is run with Jobs", func() {
+		BeforeEach(func() {
+			t.submariner.Spec.GlobalCIDR = ""
+			t.submariner.Spec.Uninstall = &v1alpha1.UninstallSpec{Method: v1alpha1.UninstallWithJobs}
+
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs,
+				t.NewDaemonSet(names.GatewayComponent),
+				t.NewDaemonSet(names.RouteAgentComponent))
+
+			nodeStatus := func(ready corev1.ConditionStatus) corev1.NodeStatus {
+				return corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}}}
+			}
+
+			t.InitGeneralClientObjs = append(t.InitGeneralClientObjs,
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{
+					Name:   "node-1",
+					Labels: map[string]string{"submariner.io/gateway": "true"},
+				}, Status: nodeStatus(corev1.ConditionTrue)},
+				&corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: "node-2"},
+					Spec:       corev1.NodeSpec{Unschedulable: true},
+					Status:     nodeStatus(corev1.ConditionTrue),
+				},
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{
+					Name:   "node-3",
+					Labels: map[string]string{"submariner.io/gateway": "true"},
+				}, Status: nodeStatus(corev1.ConditionUnknown)})
+		})
+
+		It("should run a Job per ready node and track their completion", func(ctx SpecContext) {
+			t.AssertReconcileRequeue(ctx)
+
+			t.AssertNoDaemonSet(ctx, opnames.AppendUninstall(names.GatewayComponent))
+
+			gatewayJobs := t.assertUninstallJobs(ctx, names.GatewayComponent, "node-1")
+			routeAgentJobs := t.assertUninstallJobs(ctx, names.RouteAgentComponent, "node-1", "node-2")
+
+			t.UpdateJobToSucceeded(ctx, routeAgentJobs[0])
+
+			t.AssertReconcileRequeue(ctx)
+
+			uninstallStatus := t.getSubmariner(ctx).Status.Uninstall
+			Expect(uninstallStatus).ToNot(BeNil())
+			Expect(uninstallStatus.Components).To(ContainElement(v1alpha1.ComponentUninstallStatus{
+				Name:         names.RouteAgentComponent,
+				State:        v1alpha1.UninstallAwaitingUninstallComplete,
+				PendingNodes: []string{routeAgentJobs[1].Annotations[uninstall.NodeAnnotation]},
+				Jobs: []v1alpha1.UninstallJobStatus{
+					{
+						Name:     routeAgentJobs[0].Name,
+						Node:     routeAgentJobs[0].Annotations[uninstall.NodeAnnotation],
+						State:    v1alpha1.UninstallJobSucceeded,
+						Attempts: 1,
+					},
+					{
+						Name:  routeAgentJobs[1].Name,
+						Node:  routeAgentJobs[1].Annotations[uninstall.NodeAnnotation],
+						State: v1alpha1.UninstallJobRunning,
+					},
+				},
+			}))
+
+			t.UpdateJobToSucceeded(ctx, routeAgentJobs[1])
+			t.UpdateJobToFailed(ctx, gatewayJobs[0])
+
+			t.AssertReconcileSuccess(ctx)
+			t.awaitSubmarinerDeleted()
+
+			jobs := &batchv1.JobList{}
+			Expect(t.ScopedClient.List(ctx, jobs)).To(Succeed())
+			Expect(jobs.Items).To(BeEmpty())
+
+			Expect(t.receivedEvents()).To(ContainElement(ContainSubstring("UninstallFailed")))
 		})
 	})
 
@@ -688,6 +1376,27 @@ func testDeletion() {
 	})
 }
 
+func assertCondition(submariner *v1alpha1.Submariner, conditionType string, status metav1.ConditionStatus,
+	reason string,
+) {
+	condition := meta.FindStatusCondition(submariner.Status.Conditions, conditionType)
+	Expect(condition).ToNot(BeNil(), "Condition %q not found", conditionType)
+	Expect(condition.Status).To(Equal(status), "Unexpected status for condition %q", conditionType)
+	Expect(condition.Reason).To(Equal(reason), "Unexpected reason for condition %q", conditionType)
+	Expect(condition.ObservedGeneration).To(Equal(submariner.Generation))
+}
+
+func newClusterVersion() *v1config.ClusterVersion {
+	return &v1config.ClusterVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "version",
+		},
+		Status: v1config.ClusterVersionStatus{
+			Desired: v1config.Release{Version: "4.16.3"},
+		},
+	}
+}
+
 func newInfrastructureCluster(platformType v1config.PlatformType) *v1config.Infrastructure {
 	return &v1config.Infrastructure{
 		ObjectMeta: metav1.ObjectMeta{
@@ -20,11 +20,16 @@ package submariner
 
 import (
 	"context"
+	"slices"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	submopv1a1 "github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/discovery/deploymentinfo"
 	"github.com/submariner-io/submariner-operator/pkg/discovery/network"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 func (r *Reconciler) getClusterNetwork(ctx context.Context, submariner *submopv1a1.Submariner) (*network.ClusterNetwork, error) {
@@ -54,60 +59,91 @@ func (r *Reconciler) getClusterNetwork(ctx context.Context, submariner *submopv1
 	return r.config.ClusterNetwork, errors.Wrap(err, "error discovering cluster network")
 }
 
+func (r *Reconciler) getDeploymentInfo(ctx context.Context) (*submopv1a1.DeploymentInfo, error) {
+	// The deployment info doesn't change during the lifetime of the operator so reuse a previous discovery
+	if r.config.DeploymentInfo != nil {
+		return r.config.DeploymentInfo, nil
+	}
+
+	serverVersion, err := deploymentinfo.NewServerVersionClient(r.config.RestConfig)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // No need to wrap
+	}
+
+	deploymentInfo, err := deploymentinfo.Discover(ctx, r.config.GeneralClient, serverVersion)
+	if err != nil {
+		return nil, errors.Wrap(err, "error discovering the deployment info")
+	}
+
+	log.Info("Discovered deployment info", "kubernetesType", deploymentInfo.KubernetesType,
+		"kubernetesTypeVersion", deploymentInfo.KubernetesTypeVersion, "kubernetesVersion", deploymentInfo.KubernetesVersion,
+		"cloudProvider", deploymentInfo.CloudProvider)
+
+	r.config.DeploymentInfo = deploymentInfo
+
+	return deploymentInfo, nil
+}
+
 func (r *Reconciler) discoverNetwork(ctx context.Context, submariner *submopv1a1.Submariner, log logr.Logger,
 ) (*network.ClusterNetwork, error) {
 	clusterNetwork, err := r.getClusterNetwork(ctx, submariner)
-	submariner.Status.ClusterCIDR = getCIDR(
+	r.setNetworkStatus(submariner, clusterNetwork, log)
+
+	return clusterNetwork, err
+}
+
+// setNetworkStatus sets the CIDRs and the network plugin in the Submariner status from the configured values and the
+// given cluster network.
+func (r *Reconciler) setNetworkStatus(submariner *submopv1a1.Submariner, clusterNetwork *network.ClusterNetwork, log logr.Logger) {
+	submariner.Status.ClusterCIDRs = r.getCIDRs(
+		submariner,
 		log,
 		"Cluster",
-		submariner.Spec.ClusterCIDR,
+		submariner.Spec.GetClusterCIDRs(),
+		submariner.Status.ClusterCIDRs,
 		clusterNetwork.PodCIDRs)
+	submariner.Status.ClusterCIDR = strings.Join(submariner.Status.ClusterCIDRs, ",")
 
-	submariner.Status.ServiceCIDR = getCIDR(
+	submariner.Status.ServiceCIDRs = r.getCIDRs(
+		submariner,
 		log,
 		"Service",
-		submariner.Spec.ServiceCIDR,
+		submariner.Spec.GetServiceCIDRs(),
+		submariner.Status.ServiceCIDRs,
 		clusterNetwork.ServiceCIDRs)
+	submariner.Status.ServiceCIDR = strings.Join(submariner.Status.ServiceCIDRs, ",")
 
 	submariner.Status.NetworkPlugin = clusterNetwork.NetworkPlugin
-
-	return clusterNetwork, err
 }
 
-func getCIDR(log logr.Logger, cidrType, currentCIDR string, detectedCIDRs []string) string {
-	detected := getFirstCIDR(detectedCIDRs)
-
-	if currentCIDR == "" {
-		if detected != "" {
-			log.Info("Using detected CIDR", "type", cidrType, "CIDR", detected)
+// getCIDRs returns the configured CIDRs or, if none are configured, all the detected CIDRs.
+// A mismatch between the two is logged and, when the configured CIDRs differ from the reported ones, recorded as a warning
+// event on the Submariner resource.
+func (r *Reconciler) getCIDRs(submariner *submopv1a1.Submariner, log logr.Logger, cidrType string,
+	configured, reportedCIDRs, detectedCIDRs []string,
+) []string {
+	if len(configured) == 0 {
+		if len(detectedCIDRs) > 0 {
+			log.Info("Using detected CIDRs", "type", cidrType, "CIDRs", detectedCIDRs)
 		} else {
 			log.Info("No detected CIDR", "type", cidrType)
 		}
 
-		return detected
+		return detectedCIDRs
 	}
 
-	if detected != "" && detected != currentCIDR {
+	if len(detectedCIDRs) > 0 && !sets.New(detectedCIDRs...).Equal(sets.New(configured...)) {
 		log.Error(
 			errors.New("there is a mismatch between the detected and configured CIDRs"),
-			"The configured CIDR will take precedence",
-			"type", cidrType, "configured", currentCIDR, "detected", detected)
-	}
+			"The configured CIDRs will take precedence",
+			"type", cidrType, "configured", configured, "detected", detectedCIDRs)
 
-	return currentCIDR
-}
-
-func getFirstCIDR(detectedCIDRs []string) string {
-	CIDRlen := len(detectedCIDRs)
-
-	if CIDRlen > 1 {
-		log.Error(errors.New("detected > 1 CIDRs"),
-			"we currently support only one", "detectedCIDRs", detectedCIDRs)
-	}
-
-	if CIDRlen > 0 {
-		return detectedCIDRs[0]
+		if !slices.Equal(configured, reportedCIDRs) {
+			r.config.EventRecorder.Eventf(submariner, corev1.EventTypeWarning, reasonCIDRMismatch,
+				"The configured %s CIDRs %v don't match the detected CIDRs %v, the configured CIDRs take precedence",
+				strings.ToLower(cidrType), configured, detectedCIDRs)
+		}
 	}
 
-	return ""
+	return configured
 }
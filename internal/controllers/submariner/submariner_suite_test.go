@@ -33,10 +33,13 @@ import (
 	"github.com/submariner-io/submariner-operator/api/v1alpha1"
 	submarinerController "github.com/submariner-io/submariner-operator/internal/controllers/submariner"
 	"github.com/submariner-io/submariner-operator/internal/controllers/test"
+	"github.com/submariner-io/submariner-operator/internal/controllers/uninstall"
 	"github.com/submariner-io/submariner-operator/pkg/discovery/network"
 	opnames "github.com/submariner-io/submariner-operator/pkg/names"
 	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -44,7 +47,9 @@ import (
 	"k8s.io/client-go/dynamic"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 var _ = BeforeSuite(func() {
@@ -69,7 +74,11 @@ type testDriver struct {
 	clusterNetwork               *network.ClusterNetwork
 	dynClient                    *dynamicfake.FakeDynamicClient
 	secrets                      dynamic.NamespaceableResourceInterface
+	recorder                     *record.FakeRecorder
 	getAuthorizedBrokerClientFor func(*v1alpha1.SubmarinerSpec, string, string, schema.GroupVersionResource) (dynamic.Interface, error)
+	initBrokerObjs               []controllerClient.Object
+	brokerClient                 controllerClient.Client
+	brokerClientsCreated         int
 }
 
 func newTestDriver() *testDriver {
@@ -91,7 +100,15 @@ func newTestDriver() *testDriver {
 			PodCIDRs:      []string{testDetectedClusterCIDR},
 		}
 
+		t.recorder = record.NewFakeRecorder(100)
 		t.dynClient = dynamicfake.NewSimpleDynamicClient(scheme.Scheme)
+		t.getAuthorizedBrokerClientFor = func(_ *v1alpha1.SubmarinerSpec, _, _ string, _ schema.GroupVersionResource,
+		) (dynamic.Interface, error) {
+			return t.dynClient, nil
+		}
+
+		t.initBrokerObjs = nil
+
 		t.secrets = t.dynClient.Resource(schema.GroupVersionResource{
 			Version:  "v1",
 			Resource: "secrets",
@@ -101,13 +118,22 @@ func newTestDriver() *testDriver {
 	JustBeforeEach(func() {
 		t.JustBeforeEach()
 
+		t.brokerClient = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(t.initBrokerObjs...).Build()
+		t.brokerClientsCreated = 0
+
 		t.Controller = submarinerController.NewReconciler(&submarinerController.Config{
 			ScopedClient:                 t.ScopedClient,
 			GeneralClient:                t.GeneralClient,
 			DynClient:                    t.dynClient,
 			Scheme:                       scheme.Scheme,
 			ClusterNetwork:               t.clusterNetwork,
+			EventRecorder:                t.recorder,
 			GetAuthorizedBrokerClientFor: t.getAuthorizedBrokerClientFor,
+			GetAuthorizedBrokerControllerClientFor: func(_ *v1alpha1.SubmarinerSpec, _, _ string, _ schema.GroupVersionResource,
+			) (controllerClient.Client, error) {
+				t.brokerClientsCreated++
+				return t.brokerClient, nil
+			},
 		})
 	})
 
@@ -122,6 +148,19 @@ func (t *testDriver) awaitSubmarinerDeleted() {
 	t.AwaitNoResource(t.submariner)
 }
 
+func (t *testDriver) receivedEvents() []string {
+	var events []string
+
+	for {
+		select {
+		case event := <-t.recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
 func (t *testDriver) getSubmariner(ctx context.Context) *v1alpha1.Submariner {
 	obj := &v1alpha1.Submariner{}
 	err := t.ScopedClient.Get(ctx, types.NamespacedName{Name: submarinerName, Namespace: submarinerNamespace}, obj)
@@ -226,6 +265,7 @@ func (t *testDriver) assertUninstallGlobalnetDaemonSet(ctx context.Context) *app
 func (t *testDriver) assertGlobalnetDaemonSetEnv(submariner *v1alpha1.Submariner, envMap map[string]string) {
 	Expect(envMap).To(HaveKeyWithValue("SUBMARINER_NAMESPACE", submariner.Spec.Namespace))
 	Expect(envMap).To(HaveKeyWithValue("SUBMARINER_CLUSTERID", submariner.Spec.ClusterID))
+	Expect(envMap).To(HaveKeyWithValue("SUBMARINER_GLOBALCIDR", submariner.Spec.GlobalCIDR))
 }
 
 func assertGatewayNodeSelector(daemonSet *appsv1.DaemonSet) {
@@ -302,3 +342,35 @@ func getClustersetIPCIDR(submariner *v1alpha1.Submariner, clusterNetwork *networ
 
 	return clusterNetwork.ClustersetIPCIDR
 }
+
+func (t *testDriver) assertUninstallJobs(ctx context.Context, component string, nodes ...string) []*batchv1.Job {
+	jobList := &batchv1.JobList{}
+	Expect(t.ScopedClient.List(ctx, jobList, controllerClient.InNamespace(submarinerNamespace),
+		controllerClient.MatchingLabels{uninstall.ComponentLabel: component})).To(Succeed())
+
+	jobs := make([]*batchv1.Job, len(jobList.Items))
+	jobNodes := make([]string, len(jobList.Items))
+
+	for i := range jobList.Items {
+		jobs[i] = &jobList.Items[i]
+		jobNodes[i] = jobs[i].Annotations[uninstall.NodeAnnotation]
+
+		Expect(jobs[i].Spec.Template.Spec.NodeName).To(BeEmpty())
+		nodeAffinity := jobs[i].Spec.Template.Spec.Affinity.NodeAffinity
+		Expect(nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(Equal([]corev1.NodeSelectorTerm{{
+			MatchFields: []corev1.NodeSelectorRequirement{{
+				Key:      metav1.ObjectNameField,
+				Operator: corev1.NodeSelectorOpIn,
+				Values:   []string{jobNodes[i]},
+			}},
+		}}))
+
+		Expect(jobs[i].Spec.Template.Spec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+		Expect(jobs[i].Spec.Template.Spec.Containers[0].Env).To(ContainElement(
+			corev1.EnvVar{Name: uninstall.ContainerEnvVar, Value: "true"}))
+	}
+
+	Expect(jobNodes).To(ConsistOf(nodes))
+
+	return jobs
+}
@@ -0,0 +1,52 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"context"
+	"encoding/json"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ServerSideApply is a Patch interceptor emulating server-side apply, which isn't supported by the fake client. The
+// applied object is merge-patched into the existing object or created if it doesn't exist; field ownership isn't
+// tracked. The server-side apply semantics are covered against a real API server by the envtest tests of the apply
+// package.
+func ServerSideApply(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch,
+	opts ...client.PatchOption,
+) error {
+	if patch.Type() != types.ApplyPatchType {
+		return c.Patch(ctx, obj, patch, opts...)
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err //nolint:wrapcheck // No need to wrap
+	}
+
+	err = c.Patch(ctx, obj, client.RawPatch(types.MergePatchType, data))
+	if apierrors.IsNotFound(err) {
+		return c.Create(ctx, obj)
+	}
+
+	return err //nolint:wrapcheck // No need to wrap
+}
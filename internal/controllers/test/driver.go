@@ -28,6 +28,7 @@ import (
 	"github.com/submariner-io/submariner-operator/api/v1alpha1"
 	"github.com/submariner-io/submariner-operator/internal/controllers/uninstall"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -57,6 +58,7 @@ func (d *Driver) BeforeEach() {
 	d.InitScopedClientObjs = []client.Object{}
 	d.GeneralClient = nil
 	d.InitGeneralClientObjs = []client.Object{}
+	d.InterceptorFuncs = interceptor.Funcs{}
 	d.Controller = nil
 }
 
@@ -72,13 +74,23 @@ func (d *Driver) JustBeforeEach() {
 
 func (d *Driver) NewScopedClient() client.Client {
 	return fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(d.InitScopedClientObjs...).
-		WithStatusSubresource(&v1alpha1.Submariner{}).WithInterceptorFuncs(d.InterceptorFuncs).
+		WithStatusSubresource(&v1alpha1.Submariner{}, &v1alpha1.ServiceDiscovery{}, &v1alpha1.Broker{}).WithInterceptorFuncs(d.interceptorFuncs()).
 		WithRESTMapper(test.GetRESTMapperFor(&corev1.Secret{})).Build()
 }
 
 func (d *Driver) NewGeneralClient() client.Client {
 	return fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(d.InitGeneralClientObjs...).
-		WithStatusSubresource(&v1alpha1.Submariner{}).WithInterceptorFuncs(d.InterceptorFuncs).Build()
+		WithStatusSubresource(&v1alpha1.Submariner{}, &v1alpha1.ServiceDiscovery{}, &v1alpha1.Broker{}).WithInterceptorFuncs(d.interceptorFuncs()).Build()
+}
+
+// interceptorFuncs returns the configured interceptor functions, emulating server-side apply unless Patch is intercepted.
+func (d *Driver) interceptorFuncs() interceptor.Funcs {
+	funcs := d.InterceptorFuncs
+	if funcs.Patch == nil {
+		funcs.Patch = ServerSideApply
+	}
+
+	return funcs
 }
 
 func (d *Driver) DoReconcile(ctx context.Context) (reconcile.Result, error) {
@@ -179,9 +191,21 @@ func (d *Driver) UpdateDaemonSetToReady(ctx context.Context, daemonSet *appsv1.D
 	d.UpdateDaemonSetToScheduled(ctx, daemonSet)
 
 	daemonSet.Status.NumberReady = daemonSet.Status.DesiredNumberScheduled
+	daemonSet.Status.UpdatedNumberScheduled = daemonSet.Status.DesiredNumberScheduled
 	Expect(d.ScopedClient.Status().Update(ctx, daemonSet)).To(Succeed())
 }
 
+func (d *Driver) UpdateJobToSucceeded(ctx context.Context, job *batchv1.Job) {
+	job.Status.Succeeded = 1
+	Expect(d.ScopedClient.Status().Update(ctx, job)).To(Succeed())
+}
+
+func (d *Driver) UpdateJobToFailed(ctx context.Context, job *batchv1.Job) {
+	job.Status.Failed = *job.Spec.BackoffLimit + 1
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+	Expect(d.ScopedClient.Status().Update(ctx, job)).To(Succeed())
+}
+
 func (d *Driver) UpdateDaemonSetToObserved(ctx context.Context, daemonSet *appsv1.DaemonSet) {
 	daemonSet.Generation = 1
 	Expect(d.ScopedClient.Update(ctx, daemonSet)).To(Succeed())
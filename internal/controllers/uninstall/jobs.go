@@ -0,0 +1,461 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package uninstall
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"slices"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ComponentLabel identifies the component an uninstall Job belongs to.
+	ComponentLabel = "submariner.io/uninstall-component"
+
+	// NodeAnnotation holds the name of the node an uninstall Job runs on.
+	NodeAnnotation = "submariner.io/uninstall-node"
+
+	jobBackoffLimit = 3
+	logTailLines    = 20
+	maxLogLength    = 2048
+)
+
+// daemonPodTolerations are the tolerations the DaemonSet controller adds to the pods it creates.
+var daemonPodTolerations = []corev1.Toleration{
+	{Key: corev1.TaintNodeNotReady, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute},
+	{Key: corev1.TaintNodeUnreachable, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute},
+	{Key: corev1.TaintNodeDiskPressure, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	{Key: corev1.TaintNodeMemoryPressure, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	{Key: corev1.TaintNodePIDPressure, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	{Key: corev1.TaintNodeUnschedulable, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+}
+
+var nodeSelectorOperators = map[corev1.NodeSelectorOperator]selection.Operator{
+	corev1.NodeSelectorOpIn:           selection.In,
+	corev1.NodeSelectorOpNotIn:        selection.NotIn,
+	corev1.NodeSelectorOpExists:       selection.Exists,
+	corev1.NodeSelectorOpDoesNotExist: selection.DoesNotExist,
+	corev1.NodeSelectorOpGt:           selection.GreaterThan,
+	corev1.NodeSelectorOpLt:           selection.LessThan,
+}
+
+// PodLogReader returns the end of the log of a pod.
+type PodLogReader func(ctx context.Context, namespace, name string) (string, error)
+
+// NewPodLogReader returns a PodLogReader using the given REST config or nil if no REST config is available.
+func NewPodLogReader(config *rest.Config) (PodLogReader, error) {
+	if config == nil {
+		return nil, nil //nolint:nilnil // Intentional as the log capture is optional.
+	}
+
+	clientSet, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating the Kubernetes client")
+	}
+
+	return func(ctx context.Context, namespace, name string) (string, error) {
+		log, err := clientSet.CoreV1().Pods(namespace).GetLogs(name, &corev1.PodLogOptions{
+			TailLines: ptr.To(int64(logTailLines)),
+		}).DoRaw(ctx)
+
+		return string(log), errors.Wrapf(err, "error retrieving the log of pod %s/%s", namespace, name)
+	}, nil
+}
+
+func (i *Info) usesJobs(c *Component) bool {
+	_, isJob := c.UninstallResource.(*batchv1.Job)
+
+	return isJob || (i.Spec != nil && i.Spec.Method == v1alpha1.UninstallWithJobs)
+}
+
+// jobsFor returns the Jobs running the uninstall of the component: the Job itself if the uninstall resource is a Job,
+// a Job per node the DaemonSet can be scheduled on or a single Job for a Deployment.
+func (i *Info) jobsFor(ctx context.Context, c *Component) ([]*batchv1.Job, error) {
+	switch r := c.UninstallResource.(type) {
+	case *batchv1.Job:
+		job := r.DeepCopy()
+		job.Labels = withComponentLabel(job.Labels, c)
+
+		return []*batchv1.Job{job}, nil
+	case *appsv1.DaemonSet:
+		nodes, err := i.nodesMatching(ctx, &r.Spec.Template.Spec)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs := make([]*batchv1.Job, 0, len(nodes))
+		for _, node := range nodes {
+			jobs = append(jobs, newJob(c, r.Name, r.Namespace, &r.Spec.Template, node))
+		}
+
+		return jobs, nil
+	case *appsv1.Deployment:
+		return []*batchv1.Job{newJob(c, r.Name, r.Namespace, &r.Spec.Template, "")}, nil
+	}
+
+	return nil, errors.Errorf("unsupported uninstall resource type %T", c.UninstallResource)
+}
+
+// nodesMatching returns the ready nodes the DaemonSet controller would run a pod with the given spec on: those matching
+// its node selector and required node affinity, whose taints it tolerates. Cordoned nodes are included, as with
+// DaemonSets. The pods of Jobs can't run on NotReady nodes, the uninstall would wait for them until it times out.
+func (i *Info) nodesMatching(ctx context.Context, podSpec *corev1.PodSpec) ([]string, error) {
+	reader := client.Reader(i.Client)
+	if i.GeneralClient != nil {
+		reader = i.GeneralClient
+	}
+
+	nodes := &corev1.NodeList{}
+
+	err := reader.List(ctx, nodes, client.MatchingLabels(podSpec.NodeSelector))
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing the nodes")
+	}
+
+	tolerations := append(podSpec.DeepCopy().Tolerations, daemonPodTolerations...)
+
+	names := make([]string, 0, len(nodes.Items))
+
+	for j := range nodes.Items {
+		node := &nodes.Items[j]
+
+		if !isNodeReady(node) {
+			i.Log.Info("Not running an uninstall Job on a NotReady node", "node", node.Name)
+			continue
+		}
+
+		if matchesRequiredNodeAffinity(podSpec.Affinity, node) && toleratesTaints(tolerations, node.Spec.Taints) {
+			names = append(names, node.Name)
+		}
+	}
+
+	return names, nil
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+func toleratesTaints(tolerations []corev1.Toleration, taints []corev1.Taint) bool {
+	for j := range taints {
+		if taints[j].Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+
+		tolerated := false
+
+		for k := range tolerations {
+			if tolerations[k].ToleratesTaint(&taints[j]) {
+				tolerated = true
+				break
+			}
+		}
+
+		if !tolerated {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchesRequiredNodeAffinity returns whether the node matches any of the required node selector terms, if any.
+func matchesRequiredNodeAffinity(affinity *corev1.Affinity, node *corev1.Node) bool {
+	if affinity == nil || affinity.NodeAffinity == nil ||
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return true
+	}
+
+	for _, term := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		if (len(term.MatchExpressions) > 0 || len(term.MatchFields) > 0) &&
+			matchesNodeSelectorRequirements(term.MatchExpressions, node.Labels) &&
+			matchesNodeFieldRequirements(term.MatchFields, node) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesNodeSelectorRequirements(requirements []corev1.NodeSelectorRequirement, nodeLabels labels.Set) bool {
+	for _, r := range requirements {
+		operator, ok := nodeSelectorOperators[r.Operator]
+		if !ok {
+			return false
+		}
+
+		requirement, err := labels.NewRequirement(r.Key, operator, r.Values)
+		if err != nil || !requirement.Matches(nodeLabels) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchesNodeFieldRequirements matches the node field requirements; as in the scheduler, only metadata.name is
+// supported.
+func matchesNodeFieldRequirements(requirements []corev1.NodeSelectorRequirement, node *corev1.Node) bool {
+	for _, r := range requirements {
+		if r.Key != metav1.ObjectNameField {
+			return false
+		}
+
+		found := slices.Contains(r.Values, node.Name)
+
+		switch r.Operator {
+		case corev1.NodeSelectorOpIn:
+			if !found {
+				return false
+			}
+		case corev1.NodeSelectorOpNotIn:
+			if found {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// newJob returns a Job running the given pod template. If a node is given, the pod is pinned to it with a required
+// node affinity, as the DaemonSet controller does, so that it goes through the scheduler instead of being bound to
+// the node regardless of its resources and taints.
+func newJob(c *Component, name, namespace string, template *corev1.PodTemplateSpec, nodeName string) *batchv1.Job {
+	podTemplate := template.DeepCopy()
+	podTemplate.Spec.RestartPolicy = corev1.RestartPolicyNever
+
+	if len(podTemplate.Spec.Containers) > 0 {
+		podTemplate.Spec.Containers[0].Env = append(podTemplate.Spec.Containers[0].Env,
+			corev1.EnvVar{Name: ContainerEnvVar, Value: "true"})
+	}
+
+	var annotations map[string]string
+
+	if nodeName != "" {
+		pinToNode(&podTemplate.Spec, nodeName)
+
+		annotations = map[string]string{NodeAnnotation: nodeName}
+
+		// Node names can be too long to be part of the Job name
+		hash := fnv.New32a()
+		_, _ = hash.Write([]byte(nodeName))
+		name = fmt.Sprintf("%s-%08x", name, hash.Sum32())
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Labels:      withComponentLabel(nil, c),
+			Annotations: annotations,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To(int32(jobBackoffLimit)),
+			Template:     *podTemplate,
+		},
+	}
+}
+
+// pinToNode replaces the required node affinity of the pod with one matching the given node only and adds the
+// tolerations of DaemonSet pods.
+func pinToNode(podSpec *corev1.PodSpec, nodeName string) {
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+
+	if podSpec.Affinity.NodeAffinity == nil {
+		podSpec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+
+	podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{
+		NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+			MatchFields: []corev1.NodeSelectorRequirement{{
+				Key:      metav1.ObjectNameField,
+				Operator: corev1.NodeSelectorOpIn,
+				Values:   []string{nodeName},
+			}},
+		}},
+	}
+
+	podSpec.Tolerations = append(podSpec.Tolerations, daemonPodTolerations...)
+}
+
+func withComponentLabel(labels map[string]string, c *Component) map[string]string {
+	result := map[string]string{ComponentLabel: c.Resource.GetName()}
+	for k, v := range labels {
+		result[k] = v
+	}
+
+	return result
+}
+
+func (i *Info) createUninstallJobs(ctx context.Context, c *Component) error {
+	jobs, err := i.jobsFor(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	created := 0
+
+	for _, job := range jobs {
+		err := i.Client.Create(ctx, job)
+		if apierrors.IsAlreadyExists(err) {
+			continue
+		}
+
+		if err != nil {
+			return errors.Wrapf(err, "error creating Job %s/%s", job.Namespace, job.Name)
+		}
+
+		i.Log.Info("Created Job:", "name", job.Name, "namespace", job.Namespace, "node", job.Annotations[NodeAnnotation])
+
+		created++
+	}
+
+	if created > 0 {
+		i.started = append(i.started, c.Resource.GetName())
+	}
+
+	c.uninstallJobs = jobs
+
+	return nil
+}
+
+// ensureJobsComplete tracks the uninstall Jobs of the component and returns whether any is still running. Failed Jobs,
+// which exhausted their retries, are reported with the end of the log of their last pod.
+func (i *Info) ensureJobsComplete(ctx context.Context, c *Component) (bool, error) {
+	running := false
+	c.jobStatuses = nil
+	c.pendingNodes = nil
+
+	for _, job := range c.uninstallJobs {
+		current := &batchv1.Job{}
+
+		err := i.Client.Get(ctx, client.ObjectKeyFromObject(job), current)
+		if err != nil {
+			return false, errors.Wrapf(err, "error getting Job %s/%s", job.Namespace, job.Name)
+		}
+
+		status := v1alpha1.UninstallJobStatus{
+			Name:     current.Name,
+			Node:     current.Annotations[NodeAnnotation],
+			Attempts: current.Status.Succeeded + current.Status.Failed,
+		}
+
+		switch {
+		case current.Status.Succeeded > 0:
+			status.State = v1alpha1.UninstallJobSucceeded
+		case isJobFailed(current):
+			status.State = v1alpha1.UninstallJobFailed
+			status.Log = i.failedPodLog(ctx, current)
+			c.failed = true
+
+			i.Log.Info("Job failed:", "name", current.Name, "namespace", current.Namespace, "node", status.Node)
+		default:
+			status.State = v1alpha1.UninstallJobRunning
+			running = true
+
+			if status.Node != "" {
+				c.pendingNodes = append(c.pendingNodes, status.Node)
+			}
+		}
+
+		c.jobStatuses = append(c.jobStatuses, status)
+	}
+
+	return running, nil
+}
+
+func isJobFailed(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+
+	return false
+}
+
+// failedPodLog returns the end of the log of the last failed pod of the Job, if a PodLogReader is available.
+func (i *Info) failedPodLog(ctx context.Context, job *batchv1.Job) string {
+	if i.PodLogs == nil {
+		return ""
+	}
+
+	pods, err := findPodsBySelector(ctx, i.Client, job.Namespace, &metav1.LabelSelector{
+		MatchLabels: map[string]string{batchv1.JobNameLabel: job.Name},
+	})
+	if err != nil {
+		return err.Error()
+	}
+
+	var lastFailed *corev1.Pod
+
+	for j := range pods {
+		if pods[j].Status.Phase == corev1.PodFailed &&
+			(lastFailed == nil || lastFailed.CreationTimestamp.Before(&pods[j].CreationTimestamp)) {
+			lastFailed = &pods[j]
+		}
+	}
+
+	if lastFailed == nil {
+		return ""
+	}
+
+	log, err := i.PodLogs(ctx, lastFailed.Namespace, lastFailed.Name)
+	if err != nil {
+		return err.Error()
+	}
+
+	if len(log) > maxLogLength {
+		log = log[len(log)-maxLogLength:]
+	}
+
+	return log
+}
+
+func (i *Info) deleteJobs(ctx context.Context, c *Component) error {
+	return errors.Wrap(i.Client.DeleteAllOf(ctx, &batchv1.Job{}, client.InNamespace(c.UninstallResource.GetNamespace()),
+		client.MatchingLabels{ComponentLabel: c.Resource.GetName()},
+		client.PropagationPolicy(metav1.DeletePropagationBackground)), "error deleting the uninstall Jobs")
+}
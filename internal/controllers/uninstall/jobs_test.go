@@ -0,0 +1,238 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package uninstall_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/internal/controllers/uninstall"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("Uninstall Jobs", func() {
+	t := newTestDriver()
+
+	When("the uninstall resource is a DaemonSet", func() {
+		BeforeEach(func() {
+			t.uninstallDaemonSet.Spec.Template.Spec.Tolerations = []corev1.Toleration{
+				{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "gateway", Effect: corev1.TaintEffectNoSchedule},
+			}
+
+			t.initObjs = append(t.initObjs, newNode("not-ready", false))
+		})
+
+		It("should run a Job on each ready node, pinned to it with a node affinity on its name", func(ctx SpecContext) {
+			requeue, _, err := t.run(ctx)
+			Expect(err).To(Succeed())
+			Expect(requeue).To(BeTrue())
+
+			jobs := t.jobs(ctx)
+			Expect(jobs).To(HaveLen(2))
+
+			for _, node := range []string{"node1", "node2"} {
+				podSpec := &t.jobOn(ctx, node).Spec.Template.Spec
+				Expect(podSpec.NodeName).To(BeEmpty())
+				Expect(podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(Equal(&corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchFields: []corev1.NodeSelectorRequirement{{
+							Key:      metav1.ObjectNameField,
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   []string{node},
+						}},
+					}},
+				}))
+			}
+
+			Expect(t.status.Components).To(HaveLen(1))
+			Expect(t.status.Components[0].State).To(Equal(v1alpha1.UninstallAwaitingUninstallComplete))
+			Expect(t.status.Components[0].PendingNodes).To(ConsistOf("node1", "node2"))
+		})
+
+		It("should copy the tolerations of the DaemonSet and add those of DaemonSet pods", func(ctx SpecContext) {
+			_, _, err := t.run(ctx)
+			Expect(err).To(Succeed())
+
+			tolerations := t.jobOn(ctx, "node1").Spec.Template.Spec.Tolerations
+			Expect(tolerations).To(ContainElement(t.uninstallDaemonSet.Spec.Template.Spec.Tolerations[0]))
+			Expect(tolerations).To(ContainElement(corev1.Toleration{
+				Key: corev1.TaintNodeNotReady, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute,
+			}))
+			Expect(tolerations).To(ContainElement(corev1.Toleration{
+				Key: corev1.TaintNodeUnschedulable, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule,
+			}))
+		})
+
+		It("should limit the retries and run the uninstall once", func(ctx SpecContext) {
+			_, _, err := t.run(ctx)
+			Expect(err).To(Succeed())
+
+			job := t.jobOn(ctx, "node1")
+			Expect(job.Spec.BackoffLimit).To(Equal(ptr.To(int32(3))))
+			Expect(job.Spec.Template.Spec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+			Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(
+				corev1.EnvVar{Name: uninstall.ContainerEnvVar, Value: "true"}))
+		})
+
+		Context("with a node affinity on the node names", func() {
+			BeforeEach(func() {
+				t.uninstallDaemonSet.Spec.Template.Spec.Affinity = &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+							NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+								MatchFields: []corev1.NodeSelectorRequirement{{
+									Key:      metav1.ObjectNameField,
+									Operator: corev1.NodeSelectorOpNotIn,
+									Values:   []string{"node2"},
+								}},
+							}},
+						},
+					},
+				}
+			})
+
+			It("should only run Jobs on the matching nodes", func(ctx SpecContext) {
+				_, _, err := t.run(ctx)
+				Expect(err).To(Succeed())
+
+				jobs := t.jobs(ctx)
+				Expect(jobs).To(HaveLen(1))
+				Expect(jobs[0].Annotations).To(HaveKeyWithValue(uninstall.NodeAnnotation, "node1"))
+			})
+		})
+
+		Context("and a node has a taint the DaemonSet doesn't tolerate", func() {
+			BeforeEach(func() {
+				t.initObjs = append(t.initObjs, newNode("tainted", true,
+					corev1.Taint{Key: "dedicated", Value: "storage", Effect: corev1.TaintEffectNoSchedule}))
+			})
+
+			It("should not run a Job on it", func(ctx SpecContext) {
+				_, _, err := t.run(ctx)
+				Expect(err).To(Succeed())
+				Expect(t.jobs(ctx)).To(HaveLen(2))
+			})
+		})
+	})
+
+	When("an uninstall Job fails", func() {
+		var log string
+
+		BeforeEach(func() {
+			log = ""
+
+			t.podLogs = func(_ context.Context, namespace, name string) (string, error) {
+				Expect(namespace).To(Equal(testNamespace))
+				Expect(name).To(Equal("failed-pod"))
+
+				return log, nil
+			}
+		})
+
+		JustBeforeEach(func(ctx SpecContext) {
+			_, _, err := t.run(ctx)
+			Expect(err).To(Succeed())
+
+			job := t.jobOn(ctx, "node1")
+			t.failJob(ctx, job)
+			t.completeJob(ctx, t.jobOn(ctx, "node2"))
+
+			Expect(t.client.Create(ctx, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "failed-pod",
+					Namespace: testNamespace,
+					Labels:    map[string]string{batchv1.JobNameLabel: job.Name},
+				},
+				Status: corev1.PodStatus{Phase: corev1.PodFailed},
+			})).To(Succeed())
+		})
+
+		It("should capture the end of the log of its failed pod", func(ctx SpecContext) {
+			log = "error: unable to delete the iptables chains"
+
+			_, _, err := t.run(ctx)
+			Expect(err).To(Succeed())
+
+			jobStatus := findJobStatus(t.status.Components[0].Jobs, "node1")
+			Expect(jobStatus.State).To(Equal(v1alpha1.UninstallJobFailed))
+			Expect(jobStatus.Attempts).To(Equal(int32(4)))
+			Expect(jobStatus.Log).To(Equal(log))
+
+			Expect(findJobStatus(t.status.Components[0].Jobs, "node2").Log).To(BeEmpty())
+		})
+
+		Context("and the log is too long", func() {
+			It("should truncate it to its last 2048 characters", func(ctx SpecContext) {
+				log = strings.Repeat("a", 1000) + strings.Repeat("b", 2048)
+
+				_, _, err := t.run(ctx)
+				Expect(err).To(Succeed())
+
+				Expect(findJobStatus(t.status.Components[0].Jobs, "node1").Log).To(Equal(strings.Repeat("b", 2048)))
+			})
+		})
+	})
+
+	Describe("NewPodLogReader", func() {
+		It("should retrieve the last lines of the pod's log", func(ctx SpecContext) {
+			var request *http.Request
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				request = r
+
+				_, _ = w.Write([]byte("the log"))
+			}))
+			DeferCleanup(server.Close)
+
+			podLogs, err := uninstall.NewPodLogReader(&rest.Config{Host: server.URL})
+			Expect(err).To(Succeed())
+
+			Expect(podLogs(ctx, testNamespace, "test-pod")).To(Equal("the log"))
+			Expect(request.URL.Path).To(Equal("/api/v1/namespaces/" + testNamespace + "/pods/test-pod/log"))
+			Expect(request.URL.Query().Get("tailLines")).To(Equal("20"))
+		})
+
+		When("there is no REST config", func() {
+			It("should return nil", func() {
+				Expect(uninstall.NewPodLogReader(nil)).To(BeNil())
+			})
+		})
+	})
+})
+
+func findJobStatus(jobs []v1alpha1.UninstallJobStatus, node string) *v1alpha1.UninstallJobStatus {
+	for i := range jobs {
+		if jobs[i].Node == node {
+			return &jobs[i]
+		}
+	}
+
+	Fail("No uninstall Job status found for node " + node)
+
+	return nil
+}
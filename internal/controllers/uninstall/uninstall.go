@@ -21,25 +21,40 @@ package uninstall
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
 	"time"
 
 	"github.com/coreos/go-semver/semver"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	"github.com/submariner-io/admiral/pkg/names"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
 	opnames "github.com/submariner-io/submariner-operator/pkg/names"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
+	// ComponentReadyTimeout is the default time to wait for the uninstall of the components to complete.
 	ComponentReadyTimeout = time.Minute * 2
 	ContainerEnvVar       = "SUBMARINER_UNINSTALL"
 )
 
+const (
+	reasonComponentDeleted   = "ComponentDeleted"
+	reasonUninstallStarted   = "UninstallStarted"
+	reasonUninstallCompleted = "UninstallCompleted"
+	reasonUninstallTimedOut  = "UninstallTimedOut"
+	reasonUninstallBlocked   = "UninstallBlocked"
+	reasonUninstallFailed    = "UninstallFailed"
+)
+
 type stateType int
 
 const (
@@ -48,8 +63,18 @@ const (
 	createUninstallComponent
 	awaitUninstallComplete
 	uninstallComplete
+	uninstallFailed
 )
 
+var uninstallStates = map[stateType]v1alpha1.UninstallState{
+	deleteComponent:          v1alpha1.UninstallDeletingComponent,
+	awaitPodsDeleted:         v1alpha1.UninstallAwaitingPodsDeleted,
+	createUninstallComponent: v1alpha1.UninstallCreatingUninstallComponent,
+	awaitUninstallComplete:   v1alpha1.UninstallAwaitingUninstallComplete,
+	uninstallComplete:        v1alpha1.UninstallComplete,
+	uninstallFailed:          v1alpha1.UninstallFailed,
+}
+
 var minComponentUninstallVersion = semver.New("0.12.0")
 
 type Component struct {
@@ -57,6 +82,10 @@ type Component struct {
 	UninstallResource client.Object
 	CheckInstalled    func() bool
 	state             stateType
+	pendingNodes      []string
+	uninstallJobs     []*batchv1.Job
+	jobStatuses       []v1alpha1.UninstallJobStatus
+	failed            bool
 }
 
 type Info struct {
@@ -65,32 +94,194 @@ type Info struct {
 	GetImageInfo func(imageName, componentName string) (string, corev1.PullPolicy)
 	StartTime    time.Time
 	Log          logr.Logger
+	// Recorder and Instance are optional; if set, the uninstall progress is recorded as events on the Instance.
+	Recorder record.EventRecorder
+	Instance client.Object
+	// Spec configures the timeout and what happens when it's reached; it's optional.
+	Spec *v1alpha1.UninstallSpec
+	// Status is optional; if set, it's updated with the progress of the uninstall of each component.
+	Status *v1alpha1.UninstallStatus
+	// GeneralClient is used to list the nodes on which per-node uninstall Jobs are run; it defaults to Client.
+	GeneralClient client.Reader
+	// PodLogs is optional; if set, the end of the log of failed uninstall Jobs is captured in the Status.
+	PodLogs PodLogReader
+	// started records the components whose uninstall resources were created by this run.
+	started []string
 }
 
 func (c *Component) isInstalled() bool {
 	return c.CheckInstalled == nil || c.CheckInstalled()
 }
 
+// Run runs the uninstall of the components and returns whether it should be requeued and whether it timed out and was
+// given up on. If the timeout policy is to block until clean, the uninstall never times out but is reported as blocked,
+// and an error is returned while components failed to uninstall; their failed Jobs are kept until they're deleted, which
+// retries them.
 func (i *Info) Run(ctx context.Context) (bool, bool, error) {
-	timedOut := time.Since(i.StartTime) >= ComponentReadyTimeout
-	if timedOut {
+	timeout := i.timeout()
+
+	timedOut := time.Since(i.StartTime) >= timeout
+	if timedOut && !i.blockUntilClean() {
 		i.Log.Info("Timed out waiting for components to complete - aborting")
+		i.event(corev1.EventTypeWarning, reasonUninstallTimedOut,
+			"Timed out after %v waiting for the uninstall of the components to complete%s", timeout, i.pendingSummary())
 
 		i.cleanup(ctx)
 
+		if i.Status != nil {
+			i.Status.TimedOut = true
+		}
+
 		return false, true, nil
 	}
 
+	if timedOut && (i.Status == nil || !i.Status.TimedOut) {
+		i.Log.Info("Timed out waiting for components to complete - still waiting")
+		i.event(corev1.EventTypeWarning, reasonUninstallBlocked,
+			"The uninstall of the components didn't complete after %v, waiting until it does%s", timeout, i.pendingSummary())
+	}
+
+	startedBefore := i.uninstallStarted()
+	failedBefore := i.uninstallFailed()
+
 	requeue, err := i.processComponents(ctx)
+	i.updateStatus(timedOut)
+
+	if len(i.started) > 0 && !startedBefore {
+		i.event(corev1.EventTypeNormal, reasonUninstallStarted, "Started the uninstall of components %v", i.started)
+	}
+
 	if requeue || err != nil {
 		return requeue, false, err
 	}
 
+	if failed := i.failedComponents(); len(failed) > 0 && i.blockUntilClean() {
+		if !failedBefore {
+			i.event(corev1.EventTypeWarning, reasonUninstallFailed,
+				"The uninstall of components %v failed, waiting until their failed Jobs are deleted to retry", failed)
+		}
+
+		return false, false, errors.Errorf("the uninstall of components %v failed", failed)
+	}
+
 	i.cleanup(ctx)
 
+	if failed := i.failedComponents(); len(failed) > 0 {
+		i.event(corev1.EventTypeWarning, reasonUninstallFailed, "The uninstall of components %v failed", failed)
+	} else {
+		i.event(corev1.EventTypeNormal, reasonUninstallCompleted, "The uninstall of the components completed")
+	}
+
 	return false, false, nil
 }
 
+func (i *Info) timeout() time.Duration {
+	if i.Spec == nil || i.Spec.Timeout == nil {
+		return ComponentReadyTimeout
+	}
+
+	return i.Spec.Timeout.Duration
+}
+
+func (i *Info) blockUntilClean() bool {
+	return i.Spec != nil && i.Spec.TimeoutPolicy == v1alpha1.UninstallBlockUntilClean
+}
+
+func (i *Info) updateStatus(timedOut bool) {
+	if i.Status == nil {
+		return
+	}
+
+	i.Status.TimedOut = timedOut
+	i.Status.Components = nil
+
+	for _, c := range i.Components {
+		if !c.isInstalled() {
+			continue
+		}
+
+		i.Status.Components = append(i.Status.Components, v1alpha1.ComponentUninstallStatus{
+			Name:         c.Resource.GetName(),
+			State:        uninstallStates[c.state],
+			PendingNodes: c.pendingNodes,
+			Jobs:         c.jobStatuses,
+		})
+	}
+}
+
+// uninstallStarted returns whether the uninstall resources of any component were created by a previous run, as last
+// reported in the status.
+func (i *Info) uninstallStarted() bool {
+	if i.Status == nil {
+		return false
+	}
+
+	for _, c := range i.Status.Components {
+		if c.State != v1alpha1.UninstallDeletingComponent && c.State != v1alpha1.UninstallAwaitingPodsDeleted {
+			return true
+		}
+	}
+
+	return false
+}
+
+// uninstallFailed returns whether the uninstall of any component failed, as last reported in the status.
+func (i *Info) uninstallFailed() bool {
+	if i.Status == nil {
+		return false
+	}
+
+	for _, c := range i.Status.Components {
+		if c.State == v1alpha1.UninstallFailed {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (i *Info) failedComponents() []string {
+	var failed []string
+
+	for _, c := range i.Components {
+		if c.isInstalled() && c.failed {
+			failed = append(failed, c.Resource.GetName())
+		}
+	}
+
+	return failed
+}
+
+// pendingSummary describes the components whose uninstall didn't complete, as last reported in the status.
+func (i *Info) pendingSummary() string {
+	if i.Status == nil {
+		return ""
+	}
+
+	summary := ""
+
+	for _, c := range i.Status.Components {
+		if c.State == v1alpha1.UninstallComplete {
+			continue
+		}
+
+		summary += fmt.Sprintf("; %s: %s", c.Name, c.State)
+		if len(c.PendingNodes) > 0 {
+			summary += fmt.Sprintf(" on nodes %v", c.PendingNodes)
+		}
+	}
+
+	return summary
+}
+
+func (i *Info) event(eventType, reason, messageFmt string, args ...interface{}) {
+	if i.Recorder == nil || i.Instance == nil {
+		return
+	}
+
+	i.Recorder.Eventf(i.Instance, eventType, reason, messageFmt, args...)
+}
+
 func (i *Info) processComponents(ctx context.Context) (bool, error) {
 	requeue := false
 
@@ -100,11 +291,16 @@ func (i *Info) processComponents(ctx context.Context) (bool, error) {
 		}
 
 		if c.state == deleteComponent {
-			err := i.ensureDeleted(ctx, c.Resource)
+			deleted, err := i.ensureDeleted(ctx, c.Resource)
 			if err != nil {
 				return false, err
 			}
 
+			if deleted {
+				i.event(corev1.EventTypeNormal, reasonComponentDeleted, "Deleted %s %s/%s",
+					reflect.TypeOf(c.Resource).Elem().Name(), c.Resource.GetNamespace(), c.Resource.GetName())
+			}
+
 			c.state = awaitPodsDeleted
 		}
 
@@ -123,7 +319,14 @@ func (i *Info) processComponents(ctx context.Context) (bool, error) {
 		}
 
 		if c.state == createUninstallComponent {
-			err := i.createUninstallResource(ctx, c)
+			var err error
+
+			if i.usesJobs(c) {
+				err = i.createUninstallJobs(ctx, c)
+			} else {
+				err = i.createUninstallResource(ctx, c)
+			}
+
 			if err != nil {
 				return false, err
 			}
@@ -132,7 +335,15 @@ func (i *Info) processComponents(ctx context.Context) (bool, error) {
 		}
 
 		if c.state == awaitUninstallComplete {
-			uninstallIncomplete, err := i.ensureUninstallResourceComplete(ctx, c)
+			var uninstallIncomplete bool
+			var err error
+
+			if i.usesJobs(c) {
+				uninstallIncomplete, err = i.ensureJobsComplete(ctx, c)
+			} else {
+				uninstallIncomplete, err = i.ensureUninstallResourceComplete(ctx, c)
+			}
+
 			if err != nil {
 				return false, err
 			}
@@ -143,23 +354,28 @@ func (i *Info) processComponents(ctx context.Context) (bool, error) {
 			}
 
 			c.state = uninstallComplete
+			if c.failed {
+				c.state = uninstallFailed
+			}
 		}
 	}
 
 	return requeue, nil
 }
 
-func (i *Info) ensureDeleted(ctx context.Context, obj client.Object) error {
+func (i *Info) ensureDeleted(ctx context.Context, obj client.Object) (bool, error) {
 	err := i.Client.Delete(ctx, obj)
 	if apierrors.IsNotFound(err) {
-		return nil
+		return false, nil
 	}
 
-	if err == nil {
-		i.Log.Info(fmt.Sprintf("Deleted %T:", obj), "name", obj.GetName(), "namespace", obj.GetNamespace())
+	if err != nil {
+		return false, errors.Wrapf(err, "error deleting %#v", obj)
 	}
 
-	return errors.Wrapf(err, "error deleting %#v", obj)
+	i.Log.Info(fmt.Sprintf("Deleted %T:", obj), "name", obj.GetName(), "namespace", obj.GetNamespace())
+
+	return true, nil
 }
 
 func (i *Info) ensurePodsDeleted(ctx context.Context, c *Component) (bool, error) {
@@ -182,53 +398,62 @@ func (i *Info) ensurePodsDeleted(ctx context.Context, c *Component) (bool, error
 }
 
 func (i *Info) createUninstallResource(ctx context.Context, c *Component) error {
+	var created bool
 	var err error
 
 	switch d := c.UninstallResource.(type) {
 	case *appsv1.DaemonSet:
-		err = i.createUninstallDaemonSetFrom(ctx, d)
+		created, err = i.createUninstallDaemonSetFrom(ctx, d)
 	case *appsv1.Deployment:
-		err = i.createUninstallDeploymentFrom(ctx, d)
+		created, err = i.createUninstallDeploymentFrom(ctx, d)
 	default:
-		panic(fmt.Sprintf("Unknown type: %T", d))
+		return errors.Errorf("unsupported uninstall resource type %T", d)
 	}
 
 	if err != nil {
 		return err
 	}
 
+	if created {
+		i.started = append(i.started, c.Resource.GetName())
+	}
+
 	return nil
 }
 
-func (i *Info) createUninstallDeploymentFrom(ctx context.Context, deployment *appsv1.Deployment) error {
+func (i *Info) createUninstallDeploymentFrom(ctx context.Context, deployment *appsv1.Deployment) (bool, error) {
 	i.convertPodSpecContainersToUninstall(&deployment.Spec.Template.Spec)
 
 	err := i.Client.Create(ctx, deployment)
+	if apierrors.IsAlreadyExists(err) {
+		return false, nil
+	}
+
 	if err != nil {
-		if !apierrors.IsAlreadyExists(err) {
-			return errors.Wrapf(err, "error creating %#v", deployment)
-		}
-	} else {
-		i.Log.Info("Created Deployment:", "name", deployment.Name, "namespace", deployment.Namespace)
+		return false, errors.Wrapf(err, "error creating %#v", deployment)
 	}
 
-	return nil
+	i.Log.Info("Created Deployment:", "name", deployment.Name, "namespace", deployment.Namespace)
+
+	return true, nil
 }
 
-func (i *Info) createUninstallDaemonSetFrom(ctx context.Context, daemonSet *appsv1.DaemonSet) error {
+func (i *Info) createUninstallDaemonSetFrom(ctx context.Context, daemonSet *appsv1.DaemonSet) (bool, error) {
 	i.convertPodSpecContainersToUninstall(&daemonSet.Spec.Template.Spec)
 
 	err := i.Client.Create(ctx, daemonSet)
+	if apierrors.IsAlreadyExists(err) {
+		return false, nil
+	}
+
 	if err != nil {
-		if !apierrors.IsAlreadyExists(err) {
-			return errors.Wrapf(err, "error creating %#v", daemonSet)
-		}
-	} else {
-		i.Log.Info("Created DaemonSet:", "name", daemonSet.Name, "namespace", daemonSet.Namespace,
-			"Image", daemonSet.Spec.Template.Spec.InitContainers[0].Image)
+		return false, errors.Wrapf(err, "error creating %#v", daemonSet)
 	}
 
-	return nil
+	i.Log.Info("Created DaemonSet:", "name", daemonSet.Name, "namespace", daemonSet.Namespace,
+		"Image", daemonSet.Spec.Template.Spec.InitContainers[0].Image)
+
+	return true, nil
 }
 
 func (i *Info) ensureUninstallResourceComplete(ctx context.Context, c *Component) (bool, error) {
@@ -237,11 +462,11 @@ func (i *Info) ensureUninstallResourceComplete(ctx context.Context, c *Component
 
 	switch d := c.UninstallResource.(type) {
 	case *appsv1.DaemonSet:
-		requeue, err = i.ensureDaemonSetReady(ctx, client.ObjectKeyFromObject(d))
+		requeue, err = i.ensureDaemonSetReady(ctx, c, client.ObjectKeyFromObject(d))
 	case *appsv1.Deployment:
-		requeue, err = i.ensureDeploymentReady(ctx, client.ObjectKeyFromObject(d))
+		requeue, err = i.ensureDeploymentReady(ctx, c, client.ObjectKeyFromObject(d))
 	default:
-		panic(fmt.Sprintf("Unknown type: %T", d))
+		return false, errors.Errorf("unsupported uninstall resource type %T", d)
 	}
 
 	if err != nil {
@@ -251,7 +476,7 @@ func (i *Info) ensureUninstallResourceComplete(ctx context.Context, c *Component
 	return requeue, nil
 }
 
-func (i *Info) ensureDaemonSetReady(ctx context.Context, key client.ObjectKey) (bool, error) {
+func (i *Info) ensureDaemonSetReady(ctx context.Context, c *Component, key client.ObjectKey) (bool, error) {
 	daemonSet := &appsv1.DaemonSet{}
 
 	err := i.Client.Get(ctx, key, daemonSet)
@@ -270,7 +495,10 @@ func (i *Info) ensureDaemonSetReady(ctx context.Context, key client.ObjectKey) (
 	} else if daemonSet.Status.DesiredNumberScheduled != daemonSet.Status.NumberReady {
 		i.Log.Info("DaemonSet not ready yet:", "name", daemonSet.Name, "namespace", daemonSet.Namespace,
 			"DesiredNumberScheduled", daemonSet.Status.DesiredNumberScheduled, "NumberReady", daemonSet.Status.NumberReady)
-		return true, nil
+
+		c.pendingNodes, err = i.nodesWithUnreadyPods(ctx, daemonSet.Namespace, daemonSet.Spec.Selector)
+
+		return true, err
 	} else {
 		i.Log.Info("DaemonSet is ready:", "name", daemonSet.Name, "namespace", daemonSet.Namespace)
 	}
@@ -278,7 +506,7 @@ func (i *Info) ensureDaemonSetReady(ctx context.Context, key client.ObjectKey) (
 	return false, nil
 }
 
-func (i *Info) ensureDeploymentReady(ctx context.Context, key client.ObjectKey) (bool, error) {
+func (i *Info) ensureDeploymentReady(ctx context.Context, c *Component, key client.ObjectKey) (bool, error) {
 	deployment := &appsv1.Deployment{}
 
 	err := i.Client.Get(ctx, key, deployment)
@@ -294,7 +522,10 @@ func (i *Info) ensureDeploymentReady(ctx context.Context, key client.ObjectKey)
 	if deployment.Status.AvailableReplicas != replicas {
 		i.Log.Info("Deployment not ready yet:", "name", deployment.Name, "namespace", deployment.Namespace,
 			"AvailableReplicas", deployment.Status.AvailableReplicas, "DesiredReplicas", replicas)
-		return true, nil
+
+		c.pendingNodes, err = i.nodesWithUnreadyPods(ctx, deployment.Namespace, deployment.Spec.Selector)
+
+		return true, err
 	}
 
 	i.Log.Info("Deployment is ready:", "name", deployment.Name, "namespace", deployment.Namespace)
@@ -302,9 +533,50 @@ func (i *Info) ensureDeploymentReady(ctx context.Context, key client.ObjectKey)
 	return false, nil
 }
 
+// nodesWithUnreadyPods returns the nodes on which the pods matching the selector are scheduled but not ready.
+func (i *Info) nodesWithUnreadyPods(ctx context.Context, namespace string, selector *metav1.LabelSelector) ([]string, error) {
+	if selector == nil {
+		return nil, nil
+	}
+
+	pods, err := findPodsBySelector(ctx, i.Client, namespace, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []string
+
+	for j := range pods {
+		if pods[j].Spec.NodeName != "" && !isPodReady(&pods[j]) {
+			nodes = append(nodes, pods[j].Spec.NodeName)
+		}
+	}
+
+	sort.Strings(nodes)
+
+	return nodes, nil
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
 func (i *Info) cleanup(ctx context.Context) {
 	for _, c := range i.Components {
-		err := i.ensureDeleted(ctx, c.UninstallResource)
+		var err error
+
+		if i.usesJobs(c) {
+			err = i.deleteJobs(ctx, c)
+		} else {
+			_, err = i.ensureDeleted(ctx, c.UninstallResource)
+		}
+
 		if err != nil {
 			i.Log.Error(err, "Unable to delete uninstall resource", "name", c.UninstallResource.GetName(),
 				"namespace", c.UninstallResource.GetNamespace())
@@ -0,0 +1,187 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package uninstall_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/admiral/pkg/log/kzerolog"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/internal/controllers/uninstall"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	testNamespace = "submariner-operator"
+	componentName = "submariner-routeagent"
+)
+
+var _ = BeforeSuite(func() {
+	Expect(v1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
+})
+
+var _ = Describe("", func() {
+	kzerolog.InitK8sLogging()
+})
+
+func TestUninstall(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Uninstall Suite")
+}
+
+type testDriver struct {
+	client             controllerClient.Client
+	initObjs           []controllerClient.Object
+	recorder           *record.FakeRecorder
+	spec               *v1alpha1.UninstallSpec
+	status             *v1alpha1.UninstallStatus
+	podLogs            uninstall.PodLogReader
+	uninstallDaemonSet *appsv1.DaemonSet
+	startTime          time.Time
+}
+
+func newTestDriver() *testDriver {
+	t := &testDriver{}
+
+	BeforeEach(func() {
+		t.initObjs = []controllerClient.Object{newNode("node1", true), newNode("node2", true)}
+		t.recorder = record.NewFakeRecorder(50)
+		t.spec = &v1alpha1.UninstallSpec{Method: v1alpha1.UninstallWithJobs}
+		t.status = &v1alpha1.UninstallStatus{}
+		t.podLogs = nil
+		t.startTime = time.Now()
+
+		t.uninstallDaemonSet = &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      componentName + "-uninstall",
+				Namespace: testNamespace,
+			},
+			Spec: appsv1.DaemonSetSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "routeagent", Image: "submariner-route-agent:devel"}},
+					},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		t.client = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(t.initObjs...).Build()
+	})
+
+	return t
+}
+
+// run runs the uninstall as a reconcile would, with components in their initial state and the status of the previous runs.
+func (t *testDriver) run(ctx context.Context) (bool, bool, error) {
+	info := &uninstall.Info{
+		Client: t.client,
+		Components: []*uninstall.Component{{
+			Resource: &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{
+				Name:      componentName,
+				Namespace: testNamespace,
+			}},
+			UninstallResource: t.uninstallDaemonSet.DeepCopy(),
+		}},
+		GetImageInfo: func(imageName, _ string) (string, corev1.PullPolicy) {
+			return imageName, corev1.PullIfNotPresent
+		},
+		StartTime: t.startTime,
+		Log:       logf.Log.WithName("test"),
+		Recorder:  t.recorder,
+		Instance:  &v1alpha1.Submariner{ObjectMeta: metav1.ObjectMeta{Name: "submariner", Namespace: testNamespace}},
+		Spec:      t.spec,
+		Status:    t.status,
+		PodLogs:   t.podLogs,
+	}
+
+	return info.Run(ctx)
+}
+
+func (t *testDriver) jobs(ctx context.Context) []batchv1.Job {
+	jobs := &batchv1.JobList{}
+	Expect(t.client.List(ctx, jobs, controllerClient.InNamespace(testNamespace),
+		controllerClient.MatchingLabels{uninstall.ComponentLabel: componentName})).To(Succeed())
+
+	return jobs.Items
+}
+
+func (t *testDriver) jobOn(ctx context.Context, node string) *batchv1.Job {
+	for _, job := range t.jobs(ctx) {
+		if job.Annotations[uninstall.NodeAnnotation] == node {
+			return &job
+		}
+	}
+
+	Fail("No uninstall Job found for node " + node)
+
+	return nil
+}
+
+func (t *testDriver) completeJob(ctx context.Context, job *batchv1.Job) {
+	job.Status.Succeeded = 1
+	Expect(t.client.Status().Update(ctx, job)).To(Succeed())
+}
+
+func (t *testDriver) failJob(ctx context.Context, job *batchv1.Job) {
+	job.Status.Failed = 4
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+	Expect(t.client.Status().Update(ctx, job)).To(Succeed())
+}
+
+func (t *testDriver) receivedEvents() []string {
+	var events []string
+
+	for {
+		select {
+		case event := <-t.recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func newNode(name string, ready bool, taints ...corev1.Taint) *corev1.Node {
+	status := corev1.ConditionTrue
+	if !ready {
+		status = corev1.ConditionFalse
+	}
+
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       corev1.NodeSpec{Taints: taints},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+		},
+	}
+}
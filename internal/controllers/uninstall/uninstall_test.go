@@ -0,0 +1,175 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package uninstall_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/internal/controllers/uninstall"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Uninstall", func() {
+	t := newTestDriver()
+
+	JustBeforeEach(func(ctx SpecContext) {
+		requeue, timedOut, err := t.run(ctx)
+		Expect(err).To(Succeed())
+		Expect(requeue).To(BeTrue())
+		Expect(timedOut).To(BeFalse())
+		Expect(t.jobs(ctx)).To(HaveLen(2))
+	})
+
+	When("the uninstall Jobs complete", func() {
+		It("should delete them and report the uninstall as completed", func(ctx SpecContext) {
+			t.completeJob(ctx, t.jobOn(ctx, "node1"))
+
+			requeue, _, err := t.run(ctx)
+			Expect(err).To(Succeed())
+			Expect(requeue).To(BeTrue())
+			Expect(t.status.Components[0].PendingNodes).To(Equal([]string{"node2"}))
+
+			t.completeJob(ctx, t.jobOn(ctx, "node2"))
+
+			requeue, timedOut, err := t.run(ctx)
+			Expect(err).To(Succeed())
+			Expect(requeue).To(BeFalse())
+			Expect(timedOut).To(BeFalse())
+			Expect(t.status.Components[0].State).To(Equal(v1alpha1.UninstallComplete))
+			Expect(t.jobs(ctx)).To(BeEmpty())
+
+			events := t.receivedEvents()
+			Expect(events).To(ContainElement(ContainSubstring("UninstallCompleted")))
+
+			var started []string
+			Expect(events).To(ContainElement(ContainSubstring("UninstallStarted"), &started))
+			Expect(started).To(HaveLen(1))
+		})
+	})
+
+	When("an uninstall Job fails", func() {
+		JustBeforeEach(func(ctx SpecContext) {
+			t.failJob(ctx, t.jobOn(ctx, "node1"))
+			t.completeJob(ctx, t.jobOn(ctx, "node2"))
+		})
+
+		It("should delete the Jobs and report the uninstall as failed", func(ctx SpecContext) {
+			requeue, timedOut, err := t.run(ctx)
+			Expect(err).To(Succeed())
+			Expect(requeue).To(BeFalse())
+			Expect(timedOut).To(BeFalse())
+			Expect(t.status.Components[0].State).To(Equal(v1alpha1.UninstallFailed))
+			Expect(t.jobs(ctx)).To(BeEmpty())
+			Expect(t.receivedEvents()).To(ContainElement(ContainSubstring("UninstallFailed")))
+		})
+
+		Context("and the timeout policy is to block until clean", func() {
+			BeforeEach(func() {
+				t.spec.TimeoutPolicy = v1alpha1.UninstallBlockUntilClean
+			})
+
+			It("should return an error and keep the Jobs until the failed ones are deleted", func(ctx SpecContext) {
+				_, _, err := t.run(ctx)
+				Expect(err).To(HaveOccurred())
+				Expect(t.status.Components[0].State).To(Equal(v1alpha1.UninstallFailed))
+				Expect(t.jobs(ctx)).To(HaveLen(2))
+
+				var failed []string
+				Expect(t.receivedEvents()).To(ContainElement(ContainSubstring("UninstallFailed"), &failed))
+				Expect(failed).To(HaveLen(1))
+
+				_, _, err = t.run(ctx)
+				Expect(err).To(HaveOccurred())
+				Expect(t.jobs(ctx)).To(HaveLen(2))
+				Expect(t.receivedEvents()).ToNot(ContainElement(ContainSubstring("UninstallFailed")))
+
+				By("Deleting the failed Job")
+
+				Expect(t.client.Delete(ctx, t.jobOn(ctx, "node1"))).To(Succeed())
+
+				requeue, _, err := t.run(ctx)
+				Expect(err).To(Succeed())
+				Expect(requeue).To(BeTrue())
+				Expect(t.status.Components[0].State).To(Equal(v1alpha1.UninstallAwaitingUninstallComplete))
+				Expect(t.status.Components[0].PendingNodes).To(Equal([]string{"node1"}))
+				Expect(t.jobs(ctx)).To(HaveLen(2))
+			})
+		})
+	})
+
+	When("the uninstall Jobs don't complete in time", func() {
+		JustBeforeEach(func() {
+			t.startTime = time.Now().Add(-uninstall.ComponentReadyTimeout)
+		})
+
+		It("should give up on them and delete them", func(ctx SpecContext) {
+			requeue, timedOut, err := t.run(ctx)
+			Expect(err).To(Succeed())
+			Expect(requeue).To(BeFalse())
+			Expect(timedOut).To(BeTrue())
+			Expect(t.status.TimedOut).To(BeTrue())
+			Expect(t.jobs(ctx)).To(BeEmpty())
+			Expect(t.receivedEvents()).To(ContainElement(ContainSubstring("UninstallTimedOut")))
+		})
+
+		Context("and the timeout policy is to block until clean", func() {
+			BeforeEach(func() {
+				t.spec.TimeoutPolicy = v1alpha1.UninstallBlockUntilClean
+			})
+
+			It("should keep waiting for them", func(ctx SpecContext) {
+				requeue, timedOut, err := t.run(ctx)
+				Expect(err).To(Succeed())
+				Expect(requeue).To(BeTrue())
+				Expect(timedOut).To(BeFalse())
+				Expect(t.status.TimedOut).To(BeTrue())
+				Expect(t.jobs(ctx)).To(HaveLen(2))
+
+				var blocked []string
+				Expect(t.receivedEvents()).To(ContainElement(ContainSubstring("UninstallBlocked"), &blocked))
+				Expect(blocked).To(HaveLen(1))
+
+				_, _, err = t.run(ctx)
+				Expect(err).To(Succeed())
+				Expect(t.receivedEvents()).ToNot(ContainElement(ContainSubstring("UninstallBlocked")))
+			})
+		})
+	})
+
+	When("a timeout is specified", func() {
+		BeforeEach(func() {
+			t.spec.Timeout = &metav1.Duration{Duration: time.Minute * 5}
+		})
+
+		JustBeforeEach(func() {
+			t.startTime = time.Now().Add(-uninstall.ComponentReadyTimeout)
+		})
+
+		It("should use it", func(ctx SpecContext) {
+			requeue, timedOut, err := t.run(ctx)
+			Expect(err).To(Succeed())
+			Expect(requeue).To(BeTrue())
+			Expect(timedOut).To(BeFalse())
+			Expect(t.jobs(ctx)).To(HaveLen(2))
+		})
+	})
+})
@@ -0,0 +1,107 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/cidr"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+//nolint:lll // Markers can't be wrapped
+// +kubebuilder:webhook:path=/validate-submariner-io-v1alpha1-broker,mutating=false,failurePolicy=fail,sideEffects=None,groups=submariner.io,resources=brokers,verbs=create;update,versions=v1alpha1,name=vbroker.submariner.io,admissionReviewVersions=v1
+
+// brokerComponents are the components which can be installed by the broker.
+var brokerComponents = sets.New("service-discovery", "connectivity")
+
+// BrokerValidator validates Broker resources.
+type BrokerValidator struct{}
+
+var _ admission.CustomValidator = &BrokerValidator{}
+
+func (v *BrokerValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(obj)
+}
+
+func (v *BrokerValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldBroker, oldOK := oldObj.(*v1alpha1.Broker)
+	newBroker, newOK := newObj.(*v1alpha1.Broker)
+
+	if oldOK && newOK && !updateNeedsValidation(newBroker, &oldBroker.Spec, &newBroker.Spec) {
+		return nil, nil
+	}
+
+	return v.validate(newObj)
+}
+
+func (v *BrokerValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *BrokerValidator) validate(obj runtime.Object) (admission.Warnings, error) {
+	broker, ok := obj.(*v1alpha1.Broker)
+	if !ok {
+		return nil, fmt.Errorf("expected a Broker but got %T", obj)
+	}
+
+	return nil, invalid("Broker", broker.Name, ValidateBrokerSpec(&broker.Spec, field.NewPath("spec")))
+}
+
+// ValidateBrokerSpec returns the validation errors in the given BrokerSpec.
+func ValidateBrokerSpec(spec *v1alpha1.BrokerSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := validateDomains(spec.DefaultCustomDomains, fldPath.Child("defaultCustomDomains"))
+	allErrs = append(allErrs, validateAllocatableCIDR(spec.GlobalnetCIDRRange, fldPath.Child("globalnetCIDRRange"))...)
+	allErrs = append(allErrs, validateAllocatableCIDR(spec.ClustersetIPCIDRRange, fldPath.Child("clustersetIPCIDRRange"))...)
+
+	for i, component := range spec.Components {
+		if !brokerComponents.Has(component) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("components").Index(i), component, sets.List(brokerComponents)))
+		}
+	}
+
+	if spec.GlobalnetEnabled {
+		ipv4Range, ipv6Range, _ := cidr.SplitByFamily(spec.GlobalnetCIDRRange)
+
+		allErrs = append(allErrs, validateClusterSize(ipv4Range, spec.DefaultGlobalnetClusterSize,
+			fldPath.Child("defaultGlobalnetClusterSize"))...)
+		allErrs = append(allErrs, validateClusterSize(ipv6Range, spec.DefaultGlobalnetIPv6ClusterSize,
+			fldPath.Child("defaultGlobalnetIPv6ClusterSize"))...)
+	}
+
+	return allErrs
+}
+
+// validateClusterSize checks that the given cluster size, if set, can be allocated from the given range, if any.
+func validateClusterSize(cidrRange string, clusterSize uint, fldPath *field.Path) field.ErrorList {
+	if cidrRange == "" || clusterSize == 0 {
+		return nil
+	}
+
+	if _, err := cidr.GetValidAllocationSize(cidrRange, clusterSize); err != nil {
+		return field.ErrorList{field.Invalid(fldPath, clusterSize, err.Error())}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+//nolint:lll // Markers can't be wrapped
+// +kubebuilder:webhook:path=/validate-submariner-io-v1alpha1-servicediscovery,mutating=false,failurePolicy=fail,sideEffects=None,groups=submariner.io,resources=servicediscoveries,verbs=create;update,versions=v1alpha1,name=vservicediscovery.submariner.io,admissionReviewVersions=v1
+
+// ServiceDiscoveryValidator validates ServiceDiscovery resources.
+type ServiceDiscoveryValidator struct{}
+
+var _ admission.CustomValidator = &ServiceDiscoveryValidator{}
+
+func (v *ServiceDiscoveryValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(obj)
+}
+
+func (v *ServiceDiscoveryValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldServiceDiscovery, oldOK := oldObj.(*v1alpha1.ServiceDiscovery)
+	newServiceDiscovery, newOK := newObj.(*v1alpha1.ServiceDiscovery)
+
+	if oldOK && newOK && !updateNeedsValidation(newServiceDiscovery, &oldServiceDiscovery.Spec, &newServiceDiscovery.Spec) {
+		return nil, nil
+	}
+
+	return v.validate(newObj)
+}
+
+func (v *ServiceDiscoveryValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *ServiceDiscoveryValidator) validate(obj runtime.Object) (admission.Warnings, error) {
+	serviceDiscovery, ok := obj.(*v1alpha1.ServiceDiscovery)
+	if !ok {
+		return nil, fmt.Errorf("expected a ServiceDiscovery but got %T", obj)
+	}
+
+	return nil, invalid("ServiceDiscovery", serviceDiscovery.Name,
+		ValidateServiceDiscoverySpec(&serviceDiscovery.Spec, field.NewPath("spec")))
+}
+
+// ValidateServiceDiscoverySpec returns the validation errors in the given ServiceDiscoverySpec.
+func ValidateServiceDiscoverySpec(spec *v1alpha1.ServiceDiscoverySpec, fldPath *field.Path) field.ErrorList {
+	allErrs := validateClusterID(spec.ClusterID, fldPath.Child("clusterID"))
+	allErrs = append(allErrs, validateAllocatableCIDR(spec.ClustersetIPCIDR, fldPath.Child("clustersetIPCIDR"))...)
+	allErrs = append(allErrs, validateDomains(spec.CustomDomains, fldPath.Child("customDomains"))...)
+	allErrs = append(allErrs, validateImageOverrides(spec.ImageOverrides, fldPath.Child("imageOverrides"))...)
+	allErrs = append(allErrs, validateImageSources(spec.ImagePullSecrets, spec.RegistryMirrors, spec.ImageDigests, fldPath)...)
+	allErrs = append(allErrs, validateComponents(spec.Components, fldPath.Child("components"))...)
+
+	return allErrs
+}
@@ -0,0 +1,101 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+//nolint:lll // Markers can't be wrapped
+// +kubebuilder:webhook:path=/validate-submariner-io-v1alpha1-submariner,mutating=false,failurePolicy=fail,sideEffects=None,groups=submariner.io,resources=submariners,verbs=create;update,versions=v1alpha1,name=vsubmariner.submariner.io,admissionReviewVersions=v1
+
+// cableDrivers are the supported cable drivers; an empty value selects the default driver.
+var cableDrivers = sets.New("", "libreswan", "wireguard", "vxlan")
+
+// SubmarinerValidator validates Submariner resources.
+type SubmarinerValidator struct{}
+
+var _ admission.CustomValidator = &SubmarinerValidator{}
+
+func (v *SubmarinerValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(obj)
+}
+
+func (v *SubmarinerValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldSubmariner, oldOK := oldObj.(*v1alpha1.Submariner)
+	newSubmariner, newOK := newObj.(*v1alpha1.Submariner)
+
+	if oldOK && newOK && !updateNeedsValidation(newSubmariner, &oldSubmariner.Spec, &newSubmariner.Spec) {
+		return nil, nil
+	}
+
+	return v.validate(newObj)
+}
+
+func (v *SubmarinerValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *SubmarinerValidator) validate(obj runtime.Object) (admission.Warnings, error) {
+	submariner, ok := obj.(*v1alpha1.Submariner)
+	if !ok {
+		return nil, fmt.Errorf("expected a Submariner but got %T", obj)
+	}
+
+	return nil, invalid("Submariner", submariner.Name, ValidateSubmarinerSpec(&submariner.Spec, field.NewPath("spec")))
+}
+
+// ValidateSubmarinerSpec returns the validation errors in the given SubmarinerSpec.
+func ValidateSubmarinerSpec(spec *v1alpha1.SubmarinerSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := validateClusterID(spec.ClusterID, fldPath.Child("clusterID"))
+	allErrs = append(allErrs, validateCIDRs(spec.ClusterCIDR, fldPath.Child("clusterCIDR"))...)
+	allErrs = append(allErrs, validateCIDRs(spec.ServiceCIDR, fldPath.Child("serviceCIDR"))...)
+	allErrs = append(allErrs, validateCIDRList(spec.ClusterCIDRs, fldPath.Child("clusterCIDRs"))...)
+	allErrs = append(allErrs, validateCIDRList(spec.ServiceCIDRs, fldPath.Child("serviceCIDRs"))...)
+	allErrs = append(allErrs, validateAllocatableCIDR(spec.GlobalCIDR, fldPath.Child("globalCIDR"))...)
+	allErrs = append(allErrs, validateAllocatableCIDR(spec.ClustersetIPCIDR, fldPath.Child("clustersetIPCIDR"))...)
+	allErrs = append(allErrs, validateDomains(spec.CustomDomains, fldPath.Child("customDomains"))...)
+	allErrs = append(allErrs, validateImageOverrides(spec.ImageOverrides, fldPath.Child("imageOverrides"))...)
+	allErrs = append(allErrs, validateImageSources(spec.ImagePullSecrets, spec.RegistryMirrors, spec.ImageDigests, fldPath)...)
+	allErrs = append(allErrs, validateComponents(spec.Components, fldPath.Child("components"))...)
+
+	if !cableDrivers.Has(spec.CableDriver) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("cableDriver"), spec.CableDriver,
+			sets.List(cableDrivers.Clone().Delete(""))))
+	}
+
+	if spec.ConnectionHealthCheck != nil && spec.ConnectionHealthCheck.Enabled && spec.ConnectionHealthCheck.IntervalSeconds == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("connectionHealthCheck", "intervalSeconds"),
+			spec.ConnectionHealthCheck.IntervalSeconds, "must be greater than 0 when the health check is enabled"))
+	}
+
+	if spec.GlobalCIDRExpansions > 0 && spec.GlobalCIDR == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("globalCIDRExpansions"), spec.GlobalCIDRExpansions,
+			"requires a global CIDR"))
+	}
+
+	return allErrs
+}
@@ -0,0 +1,263 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/names"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/cidr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// imageComponents are the components whose images can be overridden.
+var imageComponents = sets.New(
+	names.GatewayComponent,
+	names.RouteAgentComponent,
+	names.GlobalnetComponent,
+	names.ServiceDiscoveryComponent,
+	names.LighthouseCoreDNSComponent,
+	names.MetricsProxyComponent,
+	names.NetworkPluginSyncerComponent,
+	names.OperatorComponent,
+	names.NettestComponent,
+	names.SubctlComponent,
+)
+
+// digestPattern matches the image digests that can be pinned.
+var digestPattern = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// tagPattern matches the tags allowed in image references.
+var tagPattern = regexp.MustCompile(`^\w[\w.-]{0,127}$`)
+
+// podComponents are the components whose pod templates can be overridden.
+var podComponents = sets.New(
+	names.GatewayComponent,
+	names.RouteAgentComponent,
+	names.GlobalnetComponent,
+	names.MetricsProxyComponent,
+	names.ServiceDiscoveryComponent,
+	names.LighthouseCoreDNSComponent,
+)
+
+// SetupWithManager registers the validating webhooks for the Submariner, ServiceDiscovery and Broker resources. Since the
+// v1alpha1 Submariner and ServiceDiscovery are conversion hubs, this also registers the conversion webhook serving the
+// other versions in the manager's scheme.
+func SetupWithManager(mgr ctrl.Manager) error {
+	err := ctrl.NewWebhookManagedBy(mgr).For(&v1alpha1.Submariner{}).WithValidator(&SubmarinerValidator{}).Complete()
+	if err != nil {
+		return errors.Wrap(err, "error setting up the Submariner webhook")
+	}
+
+	err = ctrl.NewWebhookManagedBy(mgr).For(&v1alpha1.ServiceDiscovery{}).WithValidator(&ServiceDiscoveryValidator{}).Complete()
+	if err != nil {
+		return errors.Wrap(err, "error setting up the ServiceDiscovery webhook")
+	}
+
+	err = ctrl.NewWebhookManagedBy(mgr).For(&v1alpha1.Broker{}).WithValidator(&BrokerValidator{}).Complete()
+
+	return errors.Wrap(err, "error setting up the Broker webhook")
+}
+
+// updateNeedsValidation returns whether an update must be validated. Updates which leave the spec as is, such as the
+// finalizer changes, and updates of resources being deleted are accepted so that existing resources can be cleaned up.
+func updateNeedsValidation(newObj metav1.Object, oldSpec, newSpec any) bool {
+	return newObj.GetDeletionTimestamp().IsZero() && !equality.Semantic.DeepEqual(oldSpec, newSpec)
+}
+
+func invalid(kind, name string, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return apierrors.NewInvalid(v1alpha1.GroupVersion.WithKind(kind).GroupKind(), name, allErrs)
+}
+
+func validateClusterID(clusterID string, fldPath *field.Path) field.ErrorList {
+	if clusterID == "" {
+		return field.ErrorList{field.Required(fldPath, "the cluster ID must be set")}
+	}
+
+	allErrs := field.ErrorList{}
+
+	for _, msg := range validation.IsDNS1123Label(clusterID) {
+		allErrs = append(allErrs, field.Invalid(fldPath, clusterID, msg))
+	}
+
+	return allErrs
+}
+
+// validateCIDRs checks that the given comma-separated list of CIDRs is well-formed. Empty values are allowed since the
+// CIDRs are then discovered.
+func validateCIDRs(cidrs string, fldPath *field.Path) field.ErrorList {
+	if cidrs == "" {
+		return nil
+	}
+
+	allErrs := field.ErrorList{}
+
+	for _, c := range strings.Split(cidrs, ",") {
+		if _, _, err := net.ParseCIDR(strings.TrimSpace(c)); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath, cidrs, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// validateCIDRList checks that each of the given CIDRs is well-formed.
+func validateCIDRList(cidrs []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, c := range cidrs {
+		if _, _, err := net.ParseCIDR(c); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), c, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// validateAllocatableCIDR checks that the given CIDRs, at most one per IP family and comma-separated, can be used to
+// allocate addresses, if set.
+func validateAllocatableCIDR(c string, fldPath *field.Path) field.ErrorList {
+	ipv4CIDR, ipv6CIDR, err := cidr.SplitByFamily(c)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, c, err.Error())}
+	}
+
+	for _, familyCIDR := range []string{ipv4CIDR, ipv6CIDR} {
+		if familyCIDR == "" {
+			continue
+		}
+
+		if err := cidr.IsValid(familyCIDR); err != nil {
+			return field.ErrorList{field.Invalid(fldPath, c, err.Error())}
+		}
+	}
+
+	return nil
+}
+
+func validateDomains(domains []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, domain := range domains {
+		for _, msg := range validation.IsDNS1123Subdomain(domain) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), domain, msg))
+		}
+	}
+
+	return allErrs
+}
+
+func validateImageOverrides(overrides map[string]string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for component, image := range overrides {
+		if !imageComponents.Has(component) {
+			allErrs = append(allErrs, field.NotSupported(fldPath, component, sets.List(imageComponents)))
+			continue
+		}
+
+		if msg := validateImageReference(image); msg != "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(component), image, msg))
+		}
+	}
+
+	return allErrs
+}
+
+// validateImageReference returns why the given image reference is invalid, or an empty string if it's valid. The tag
+// follows the last path component, so a registry port isn't mistaken for a tag.
+func validateImageReference(image string) string {
+	if image == "" || strings.ContainsAny(image, " \t\n") {
+		return "must be a valid image reference"
+	}
+
+	name, digest, hasDigest := strings.Cut(image, "@")
+	if hasDigest && !digestPattern.MatchString(digest) {
+		return "the image digest must be a sha256 digest"
+	}
+
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") && !tagPattern.MatchString(name[i+1:]) {
+		return "the image tag must be a valid tag"
+	}
+
+	return ""
+}
+
+func validateComponents(components map[string]v1alpha1.ComponentSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for component := range components {
+		if !podComponents.Has(component) {
+			allErrs = append(allErrs, field.NotSupported(fldPath, component, sets.List(podComponents)))
+		}
+	}
+
+	return allErrs
+}
+
+func validateImageSources(pullSecrets []corev1.LocalObjectReference, mirrors []v1alpha1.RegistryMirror,
+	digests map[string]string, fldPath *field.Path,
+) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i := range pullSecrets {
+		if pullSecrets[i].Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("imagePullSecrets").Index(i).Child("name"), ""))
+		}
+	}
+
+	for i := range mirrors {
+		if mirrors[i].Source == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("registryMirrors").Index(i).Child("source"), ""))
+		}
+
+		if mirrors[i].Mirror == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("registryMirrors").Index(i).Child("mirror"), ""))
+		}
+	}
+
+	for component, digest := range digests {
+		if !imageComponents.Has(component) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("imageDigests"), component, sets.List(imageComponents)))
+			continue
+		}
+
+		if !digestPattern.MatchString(digest) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("imageDigests").Key(component), digest,
+				"must be a sha256 digest, e.g. sha256:<64 hex characters>"))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,444 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/admiral/pkg/names"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/api/v1beta1"
+	"github.com/submariner-io/submariner-operator/internal/webhook"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+)
+
+var _ = Describe("SubmarinerValidator", func() {
+	var submariner *v1alpha1.Submariner
+
+	BeforeEach(func() {
+		submariner = &v1alpha1.Submariner{
+			ObjectMeta: metav1.ObjectMeta{Name: "submariner"},
+			Spec: v1alpha1.SubmarinerSpec{
+				ClusterID:   "east",
+				ClusterCIDR: "10.244.0.0/16",
+				ServiceCIDR: "10.96.0.0/16",
+				GlobalCIDR:  "242.0.0.0/16",
+				CableDriver: "libreswan",
+				ImageOverrides: map[string]string{
+					names.GatewayComponent: "quay.io/custom/submariner-gateway:v1.0",
+				},
+				ConnectionHealthCheck: &v1alpha1.HealthCheckSpec{
+					Enabled:         true,
+					IntervalSeconds: 1,
+				},
+			},
+		}
+	})
+
+	validate := func() error {
+		return validateCreateAndUpdate(&webhook.SubmarinerValidator{}, submariner)
+	}
+
+	When("the spec is valid", func() {
+		It("should accept it", func() {
+			Expect(validate()).To(Succeed())
+		})
+	})
+
+	When("the CIDRs are left for discovery", func() {
+		BeforeEach(func() {
+			submariner.Spec.ClusterCIDR = ""
+			submariner.Spec.ServiceCIDR = ""
+			submariner.Spec.GlobalCIDR = ""
+			submariner.Spec.CableDriver = ""
+		})
+
+		It("should accept it", func() {
+			Expect(validate()).To(Succeed())
+		})
+	})
+
+	When("the cluster CIDR is malformed", func() {
+		BeforeEach(func() {
+			submariner.Spec.ClusterCIDR = "10.244.0.0/33"
+		})
+
+		It("should reject it with the field path", func() {
+			assertInvalid(validate(), "spec.clusterCIDR")
+		})
+	})
+
+	When("a cluster CIDR of the list is malformed", func() {
+		BeforeEach(func() {
+			submariner.Spec.ClusterCIDRs = []string{"10.244.0.0/16", "10.245.0.0"}
+		})
+
+		It("should reject it with the field path", func() {
+			assertInvalid(validate(), "spec.clusterCIDRs[1]")
+		})
+	})
+
+	When("the global CIDR is not valid", func() {
+		BeforeEach(func() {
+			submariner.Spec.GlobalCIDR = "127.0.0.0/16"
+		})
+
+		It("should reject it with the field path", func() {
+			assertInvalid(validate(), "spec.globalCIDR")
+		})
+	})
+
+	When("the cluster ID is not a DNS-1123 label", func() {
+		BeforeEach(func() {
+			submariner.Spec.ClusterID = "East_Cluster"
+		})
+
+		It("should reject it with the field path", func() {
+			assertInvalid(validate(), "spec.clusterID")
+		})
+	})
+
+	When("the cable driver is unknown", func() {
+		BeforeEach(func() {
+			submariner.Spec.CableDriver = "strongswan"
+		})
+
+		It("should reject it with the field path", func() {
+			assertInvalid(validate(), "spec.cableDriver")
+		})
+	})
+
+	When("the health check is enabled with a zero interval", func() {
+		BeforeEach(func() {
+			submariner.Spec.ConnectionHealthCheck.IntervalSeconds = 0
+		})
+
+		It("should reject it with the field path", func() {
+			assertInvalid(validate(), "spec.connectionHealthCheck.intervalSeconds")
+		})
+	})
+
+	When("the global CIDR expansions are set without a global CIDR", func() {
+		BeforeEach(func() {
+			submariner.Spec.GlobalCIDR = ""
+			submariner.Spec.GlobalCIDRExpansions = 1
+		})
+
+		It("should reject it with the field path", func() {
+			assertInvalid(validate(), "spec.globalCIDRExpansions")
+		})
+	})
+
+	When("an image override doesn't match any component", func() {
+		BeforeEach(func() {
+			submariner.Spec.ImageOverrides["submariner-gw"] = "quay.io/custom/submariner-gateway:v1.0"
+		})
+
+		It("should reject it with the field path", func() {
+			assertInvalid(validate(), "spec.imageOverrides")
+		})
+	})
+
+	When("component overrides are set for an unknown component", func() {
+		BeforeEach(func() {
+			submariner.Spec.Components = map[string]v1alpha1.ComponentSpec{
+				names.GatewayComponent: {PriorityClassName: "system-node-critical"},
+				"submariner-operator":  {PriorityClassName: "system-node-critical"},
+			}
+		})
+
+		It("should reject it with the field path", func() {
+			assertInvalid(validate(), "spec.components")
+		})
+	})
+
+	When("an image digest is not a sha256 digest", func() {
+		BeforeEach(func() {
+			submariner.Spec.ImageDigests = map[string]string{names.GatewayComponent: "v0.20.0"}
+		})
+
+		It("should reject it with the field path", func() {
+			assertInvalid(validate(), "spec.imageDigests[submariner-gateway]")
+		})
+	})
+
+	When("a registry mirror has no source", func() {
+		BeforeEach(func() {
+			submariner.Spec.RegistryMirrors = []v1alpha1.RegistryMirror{{Mirror: "registry.corp/mirror"}}
+		})
+
+		It("should reject it with the field path", func() {
+			assertInvalid(validate(), "spec.registryMirrors[0].source")
+		})
+	})
+
+	When("an image override has an empty tag", func() {
+		BeforeEach(func() {
+			submariner.Spec.ImageOverrides[names.RouteAgentComponent] = "quay.io/custom/submariner-route-agent:"
+		})
+
+		It("should reject it with the field path", func() {
+			assertInvalid(validate(), "spec.imageOverrides[submariner-routeagent]")
+		})
+	})
+
+	When("an image override has an invalid tag", func() {
+		BeforeEach(func() {
+			submariner.Spec.ImageOverrides[names.RouteAgentComponent] = "quay.io/custom/submariner-route-agent:v1+dev"
+		})
+
+		It("should reject it with the field path", func() {
+			assertInvalid(validate(), "spec.imageOverrides[submariner-routeagent]")
+		})
+	})
+
+	When("an image override has a registry port and a digest", func() {
+		BeforeEach(func() {
+			submariner.Spec.ImageOverrides[names.RouteAgentComponent] = "registry.local:5000/submariner-route-agent@sha256:" +
+				strings.Repeat("a", 64)
+		})
+
+		It("should accept it", func() {
+			Expect(validate()).To(Succeed())
+		})
+	})
+
+	Context("on update", func() {
+		var oldSubmariner *v1alpha1.Submariner
+
+		BeforeEach(func() {
+			submariner.Spec.ClusterID = "not a label"
+			oldSubmariner = submariner.DeepCopy()
+		})
+
+		When("the spec of an invalid resource is changed", func() {
+			It("should reject it", func() {
+				submariner.Spec.CableDriver = "vxlan"
+				_, err := (&webhook.SubmarinerValidator{}).ValidateUpdate(context.TODO(), oldSubmariner, submariner)
+				assertInvalid(err, "spec.clusterID")
+			})
+		})
+
+		When("only the metadata of an invalid resource is changed", func() {
+			It("should accept it", func() {
+				submariner.Finalizers = []string{"submariner.io/cleanup"}
+				_, err := (&webhook.SubmarinerValidator{}).ValidateUpdate(context.TODO(), oldSubmariner, submariner)
+				Expect(err).To(Succeed())
+			})
+		})
+
+		When("an invalid resource is being deleted", func() {
+			It("should accept it", func() {
+				submariner.Spec.CableDriver = "vxlan"
+				submariner.DeletionTimestamp = ptr.To(metav1.Now())
+				_, err := (&webhook.SubmarinerValidator{}).ValidateUpdate(context.TODO(), oldSubmariner, submariner)
+				Expect(err).To(Succeed())
+			})
+		})
+	})
+})
+
+var _ = Describe("ServiceDiscoveryValidator", func() {
+	var serviceDiscovery *v1alpha1.ServiceDiscovery
+
+	BeforeEach(func() {
+		serviceDiscovery = &v1alpha1.ServiceDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Name: "service-discovery"},
+			Spec: v1alpha1.ServiceDiscoverySpec{
+				ClusterID:     "east",
+				CustomDomains: []string{"supercluster.local"},
+			},
+		}
+	})
+
+	validate := func() error {
+		return validateCreateAndUpdate(&webhook.ServiceDiscoveryValidator{}, serviceDiscovery)
+	}
+
+	When("the spec is valid", func() {
+		It("should accept it", func() {
+			Expect(validate()).To(Succeed())
+		})
+	})
+
+	When("the cluster ID is missing", func() {
+		BeforeEach(func() {
+			serviceDiscovery.Spec.ClusterID = ""
+		})
+
+		It("should reject it with the field path", func() {
+			assertInvalid(validate(), "spec.clusterID")
+		})
+	})
+
+	When("a custom domain is invalid", func() {
+		BeforeEach(func() {
+			serviceDiscovery.Spec.CustomDomains = append(serviceDiscovery.Spec.CustomDomains, "not a domain")
+		})
+
+		It("should reject it with the field path", func() {
+			assertInvalid(validate(), "spec.customDomains[1]")
+		})
+	})
+
+	When("only the metadata of an invalid resource is updated", func() {
+		It("should accept it", func() {
+			serviceDiscovery.Spec.ClusterID = ""
+			oldServiceDiscovery := serviceDiscovery.DeepCopy()
+			oldServiceDiscovery.Finalizers = []string{"submariner.io/cleanup"}
+
+			_, err := (&webhook.ServiceDiscoveryValidator{}).ValidateUpdate(context.TODO(), oldServiceDiscovery, serviceDiscovery)
+			Expect(err).To(Succeed())
+		})
+	})
+})
+
+var _ = Describe("BrokerValidator", func() {
+	var broker *v1alpha1.Broker
+
+	BeforeEach(func() {
+		broker = &v1alpha1.Broker{
+			ObjectMeta: metav1.ObjectMeta{Name: "broker"},
+			Spec: v1alpha1.BrokerSpec{
+				Components:                  []string{"service-discovery", "connectivity"},
+				GlobalnetEnabled:            true,
+				GlobalnetCIDRRange:          "242.0.0.0/8",
+				DefaultGlobalnetClusterSize: 65536,
+			},
+		}
+	})
+
+	validate := func() error {
+		return validateCreateAndUpdate(&webhook.BrokerValidator{}, broker)
+	}
+
+	When("the spec is valid", func() {
+		It("should accept it", func() {
+			Expect(validate()).To(Succeed())
+		})
+	})
+
+	When("a component is unknown", func() {
+		BeforeEach(func() {
+			broker.Spec.Components = append(broker.Spec.Components, "globalnet")
+		})
+
+		It("should reject it with the field path", func() {
+			assertInvalid(validate(), "spec.components[2]")
+		})
+	})
+
+	When("the Globalnet CIDR range is dual-stack", func() {
+		BeforeEach(func() {
+			broker.Spec.GlobalnetCIDRRange = "242.0.0.0/8,fd00:242::/48"
+		})
+
+		It("should accept it", func() {
+			Expect(validate()).To(Succeed())
+		})
+	})
+
+	When("the Globalnet CIDR range has more than one CIDR of the same IP family", func() {
+		BeforeEach(func() {
+			broker.Spec.GlobalnetCIDRRange = "242.0.0.0/8,241.0.0.0/8"
+		})
+
+		It("should reject it with the field path", func() {
+			assertInvalid(validate(), "spec.globalnetCIDRRange")
+		})
+	})
+
+	When("the Globalnet cluster size doesn't fit in the CIDR range", func() {
+		BeforeEach(func() {
+			broker.Spec.GlobalnetCIDRRange = "242.0.0.0/16"
+		})
+
+		It("should reject it with the field path", func() {
+			assertInvalid(validate(), "spec.defaultGlobalnetClusterSize")
+		})
+	})
+
+	When("the Globalnet IPv6 cluster size doesn't fit in the IPv6 CIDR range", func() {
+		BeforeEach(func() {
+			broker.Spec.GlobalnetCIDRRange = "242.0.0.0/8,fd00:242::/112"
+			broker.Spec.DefaultGlobalnetIPv6ClusterSize = 65536
+		})
+
+		It("should reject it with the field path", func() {
+			assertInvalid(validate(), "spec.defaultGlobalnetIPv6ClusterSize")
+		})
+	})
+
+	When("an invalid resource is being deleted", func() {
+		It("should accept its updates", func() {
+			broker.Spec.Components = []string{"globalnet"}
+			oldBroker := broker.DeepCopy()
+			broker.DeletionTimestamp = ptr.To(metav1.Now())
+			broker.Spec.GlobalnetEnabled = false
+
+			_, err := (&webhook.BrokerValidator{}).ValidateUpdate(context.TODO(), oldBroker, broker)
+			Expect(err).To(Succeed())
+		})
+	})
+})
+
+var _ = Describe("Conversion", func() {
+	It("should be supported for the Submariner and ServiceDiscovery hubs", func() {
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(v1beta1.AddToScheme(scheme)).To(Succeed())
+
+		Expect(conversion.IsConvertible(scheme, &v1alpha1.Submariner{})).To(BeTrue())
+		Expect(conversion.IsConvertible(scheme, &v1alpha1.ServiceDiscovery{})).To(BeTrue())
+		Expect(conversion.IsConvertible(scheme, &v1alpha1.Broker{})).To(BeFalse())
+	})
+})
+
+func validateCreateAndUpdate(validator admission.CustomValidator, obj runtime.Object) error {
+	_, createErr := validator.ValidateCreate(context.TODO(), obj)
+	// The previous object has an empty spec so that the update changes it
+	oldObj := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(runtime.Object)
+	_, updateErr := validator.ValidateUpdate(context.TODO(), oldObj, obj)
+	if createErr == nil {
+		Expect(updateErr).To(Succeed())
+	} else {
+		Expect(updateErr).To(Equal(createErr))
+	}
+
+	return createErr
+}
+
+func assertInvalid(err error, fieldPath string) {
+	Expect(apierrors.IsInvalid(err)).To(BeTrue(), "Expected an Invalid error but got %v", err)
+
+	statusErr := &apierrors.StatusError{}
+	Expect(err).To(BeAssignableToTypeOf(statusErr))
+
+	causes := err.(*apierrors.StatusError).ErrStatus.Details.Causes //nolint:errorlint // The type is checked above
+	Expect(causes).To(ContainElement(HaveField("Field", fieldPath)))
+}
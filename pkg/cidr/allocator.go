@@ -0,0 +1,237 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cidr
+
+import (
+	"fmt"
+	"math/big"
+	"net/netip"
+	"sort"
+)
+
+// Strategy selects the free block a CIDR is allocated from.
+type Strategy string
+
+const (
+	// FirstFit allocates the lowest free block that fits. This is the default.
+	FirstFit Strategy = "FirstFit"
+	// BestFit allocates from the smallest free block that fits, keeping the larger free blocks for larger allocations.
+	BestFit Strategy = "BestFit"
+	// Aligned allocates the lowest free block that fits at or after the address of the Hint, wrapping around to the
+	// start of the range if there's none.
+	Aligned Strategy = "Aligned"
+)
+
+// RangeUsage describes how a CIDR range is used.
+type RangeUsage struct {
+	// Allocated are the allocated CIDRs which overlap with the range, sorted by address.
+	Allocated []string
+	// Free are the unallocated parts of the range as the largest aligned CIDRs, sorted by address.
+	Free []string
+	// LargestFree is the largest free CIDR, the lowest one if there are several. It's empty if the range is full.
+	LargestFree string
+}
+
+// block is an aligned block of 2^hostBits addresses.
+type block struct {
+	start    *big.Int
+	hostBits int
+}
+
+func (b block) end() *big.Int {
+	return new(big.Int).Sub(new(big.Int).Add(b.start, hostCount(b.hostBits)), big.NewInt(1))
+}
+
+func (b block) prefix(network netip.Prefix) netip.Prefix {
+	return netip.PrefixFrom(intToAddr(b.start, network.Addr().Is6()), network.Addr().BitLen()-b.hostBits)
+}
+
+// Usage returns the allocated and free blocks of the CIDR range. Allocated CIDRs of the other IP family are ignored.
+func Usage(info *Info) (*RangeUsage, error) {
+	network, err := parsePrefix(info.CIDR)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse CIDR %q", info.CIDR)
+	}
+
+	allocated, err := allocatedInFamily(info.Clusters, network)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(allocated, func(i, j int) bool {
+		if c := allocated[i].Addr().Compare(allocated[j].Addr()); c != 0 {
+			return c < 0
+		}
+
+		return allocated[i].Bits() < allocated[j].Bits()
+	})
+
+	usage := &RangeUsage{}
+
+	for i, prefix := range allocated {
+		if prefix.Overlaps(network) && (i == 0 || prefix != allocated[i-1]) {
+			usage.Allocated = append(usage.Allocated, prefix.String())
+		}
+	}
+
+	blocks := freeBlocks(network, allocated)
+	largest := 0
+
+	for i, free := range blocks {
+		usage.Free = append(usage.Free, free.prefix(network).String())
+
+		if free.hostBits > blocks[largest].hostBits {
+			largest = i
+		}
+	}
+
+	if len(blocks) > 0 {
+		usage.LargestFree = usage.Free[largest]
+	}
+
+	return usage, nil
+}
+
+// freeBlocks returns the parts of the network which don't overlap with the allocated CIDRs as the largest aligned
+// blocks, sorted by address.
+func freeBlocks(network netip.Prefix, allocated []netip.Prefix) []block {
+	rangeStart, rangeEnd := addrToInt(network.Addr()), addrToInt(lastAddr(network))
+	maxHostBits := network.Addr().BitLen() - network.Bits()
+
+	var intervals [][2]*big.Int
+
+	for _, prefix := range allocated {
+		start, end := bigMax(addrToInt(prefix.Addr()), rangeStart), bigMin(addrToInt(lastAddr(prefix)), rangeEnd)
+
+		if start.Cmp(end) <= 0 {
+			intervals = append(intervals, [2]*big.Int{start, end})
+		}
+	}
+
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i][0].Cmp(intervals[j][0]) < 0
+	})
+
+	one := big.NewInt(1)
+	next := rangeStart
+
+	var blocks []block
+
+	for _, interval := range intervals {
+		if interval[0].Cmp(next) > 0 {
+			blocks = appendBlocks(blocks, next, new(big.Int).Sub(interval[0], one), maxHostBits)
+		}
+
+		next = bigMax(next, new(big.Int).Add(interval[1], one))
+	}
+
+	if next.Cmp(rangeEnd) <= 0 {
+		blocks = appendBlocks(blocks, next, rangeEnd, maxHostBits)
+	}
+
+	return blocks
+}
+
+// appendBlocks splits the addresses from start to end, inclusive, in the largest aligned blocks.
+func appendBlocks(blocks []block, start, end *big.Int, maxHostBits int) []block {
+	size := new(big.Int).Add(new(big.Int).Sub(end, start), big.NewInt(1))
+
+	for size.Sign() > 0 {
+		hostBits := maxHostBits
+		if start.Sign() != 0 {
+			hostBits = min(hostBits, int(start.TrailingZeroBits())) //nolint:gosec // The trailing zeros fit in an int
+		}
+
+		for hostCount(hostBits).Cmp(size) > 0 {
+			hostBits--
+		}
+
+		blocks = append(blocks, block{start: start, hostBits: hostBits})
+		start = new(big.Int).Add(start, hostCount(hostBits))
+		size.Sub(size, hostCount(hostBits))
+	}
+
+	return blocks
+}
+
+// allocateFrom returns the start of the block of 2^hostBits addresses the strategy selects among the free blocks, or
+// nil if none fits.
+func allocateFrom(blocks []block, hostBits int, strategy Strategy, hint *big.Int) (*big.Int, error) {
+	var selected *block
+
+	for i := range blocks {
+		free := &blocks[i]
+		if free.hostBits < hostBits {
+			continue
+		}
+
+		switch strategy {
+		case "", FirstFit:
+			return free.start, nil
+		case BestFit:
+			if selected == nil || free.hostBits < selected.hostBits {
+				selected = free
+			}
+		case Aligned:
+			if start := alignedAtOrAfter(free, hostBits, hint); start != nil {
+				return start, nil
+			}
+
+			if selected == nil {
+				selected = free
+			}
+		default:
+			return nil, fmt.Errorf("unknown allocation strategy %q", strategy)
+		}
+	}
+
+	if selected == nil {
+		return nil, nil
+	}
+
+	return selected.start, nil
+}
+
+// alignedAtOrAfter returns the start of the first block of 2^hostBits addresses in the free block that starts at or
+// after the hint, or nil if there's none.
+func alignedAtOrAfter(free *block, hostBits int, hint *big.Int) *big.Int {
+	start := bigMax(free.start, hint)
+
+	size := hostCount(hostBits)
+	if remainder := new(big.Int).Mod(start, size); remainder.Sign() != 0 {
+		start = new(big.Int).Add(new(big.Int).Sub(start, remainder), size)
+	}
+
+	if new(big.Int).Sub(new(big.Int).Add(start, size), big.NewInt(1)).Cmp(free.end()) > 0 {
+		return nil
+	}
+
+	return start
+}
+
+func intToAddr(n *big.Int, is6 bool) netip.Addr {
+	ip := make([]byte, 4)
+	if is6 {
+		ip = make([]byte, 16)
+	}
+
+	addr, _ := netip.AddrFromSlice(n.FillBytes(ip))
+
+	return addr
+}
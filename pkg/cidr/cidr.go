@@ -19,11 +19,14 @@ limitations under the License.
 package cidr
 
 import (
-	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"math"
+	"math/big"
 	"math/bits"
-	"net"
+	"net/netip"
+	"slices"
+	"strings"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -31,6 +34,8 @@ import (
 
 const ClusterInfoKey = "clusterinfo"
 
+// ClusterInfo holds the CIDRs allocated to a cluster. The first CIDR of each IP family is the cluster's CIDR, any further
+// CIDRs of the same family expand it.
 type ClusterInfo struct {
 	ClusterID string   `json:"cluster_id"`
 	CIDRs     []string `json:"global_cidr"`
@@ -40,12 +45,10 @@ type Info struct {
 	CIDR           string
 	AllocationSize uint
 	Clusters       map[string]*ClusterInfo
-}
-
-type allocationInfo struct {
-	network *net.IPNet
-	size    int
-	lastIP  uint32
+	// Strategy selects the free block new CIDRs are allocated from, FirstFit by default.
+	Strategy Strategy
+	// Hint is a CIDR whose address the Aligned strategy allocates at or after.
+	Hint string
 }
 
 func unmarshalClusterInfo(fromConfigMap *corev1.ConfigMap) ([]ClusterInfo, error) {
@@ -109,12 +112,44 @@ func AddClusterInfoData(toConfigMap *corev1.ConfigMap, newCluster ClusterInfo) e
 	return nil
 }
 
+// RemoveClusterInfoData removes the CIDRs allocated to the given cluster from the ConfigMap and returns whether the
+// cluster had any.
+func RemoveClusterInfoData(fromConfigMap *corev1.ConfigMap, clusterID string) (bool, error) {
+	existingInfo, err := unmarshalClusterInfo(fromConfigMap)
+	if err != nil {
+		return false, err
+	}
+
+	remainingInfo := make([]ClusterInfo, 0, len(existingInfo))
+
+	for _, value := range existingInfo {
+		if value.ClusterID != clusterID {
+			remainingInfo = append(remainingInfo, value)
+		}
+	}
+
+	if len(remainingInfo) == len(existingInfo) {
+		return false, nil
+	}
+
+	data, err := json.MarshalIndent(remainingInfo, "", "\t")
+	if err != nil {
+		return false, errors.Wrapf(err, "error marshalling ClusterInfo")
+	}
+
+	fromConfigMap.Data[ClusterInfoKey] = string(data)
+
+	return true, nil
+}
+
 func IsValid(cidr string) error {
-	ip, _, err := net.ParseCIDR(cidr)
+	prefix, err := netip.ParsePrefix(cidr)
 	if err != nil {
 		return err //nolint:wrapcheck // No need to wrap here
 	}
 
+	ip := prefix.Addr()
+
 	if ip.IsUnspecified() {
 		return fmt.Errorf("%s can't be unspecified", cidr)
 	}
@@ -134,122 +169,204 @@ func IsValid(cidr string) error {
 	return nil
 }
 
-func CheckForOverlappingCIDRs(infoMap map[string]*ClusterInfo, cidr, clusterID string) error {
-	for _, ci := range infoMap {
-		overlap, err := isOverlappingCIDR(ci.CIDRs, cidr)
+// SplitByFamily splits a comma-separated list of CIDRs into its IPv4 and IPv6 CIDR. At most one CIDR per IP family
+// is allowed; either may be empty.
+func SplitByFamily(cidrs string) (string, string, error) {
+	var ipv4CIDR, ipv6CIDR string
+
+	if cidrs == "" {
+		return "", "", nil
+	}
+
+	for _, c := range strings.Split(cidrs, ",") {
+		c = strings.TrimSpace(c)
+
+		prefix, err := netip.ParsePrefix(c)
 		if err != nil {
-			return errors.Wrap(err, "unable to validate overlapping CIDRs")
+			return "", "", err //nolint:wrapcheck // No need to wrap here
 		}
 
-		if overlap && ci.ClusterID != clusterID {
-			return fmt.Errorf("invalid CIDR %q overlaps with cluster %q", cidr, ci.ClusterID)
+		familyCIDR := &ipv4CIDR
+		if prefix.Addr().Is6() {
+			familyCIDR = &ipv6CIDR
 		}
+
+		if *familyCIDR != "" {
+			return "", "", fmt.Errorf("only one CIDR per IP family is allowed in %q", cidrs)
+		}
+
+		*familyCIDR = c
 	}
 
-	return nil
+	return ipv4CIDR, ipv6CIDR, nil
 }
 
-func Allocate(info *Info) (string, error) {
-	_, network, err := net.ParseCIDR(info.CIDR)
-	if err != nil {
-		return "", fmt.Errorf("unable to parse CIDR %q", info.CIDR)
+// InFamilyOf returns the first CIDR in the given list that has the same IP family as the given CIDR range, or an empty
+// string if there's none.
+func InFamilyOf(cidrs []string, cidrRange string) string {
+	if inFamily := AllInFamilyOf(cidrs, cidrRange); len(inFamily) > 0 {
+		return inFamily[0]
 	}
 
-	var allocated []allocationInfo
+	return ""
+}
 
-	for _, cluster := range info.Clusters {
-		for _, cidr := range cluster.CIDRs {
-			_, n, err := net.ParseCIDR(cidr)
-			if err != nil {
-				return "", fmt.Errorf("unable to parse CIDR %q", cidr)
-			}
+// AllInFamilyOf returns the CIDRs in the given list that have the same IP family as the given CIDR range.
+func AllInFamilyOf(cidrs []string, cidrRange string) []string {
+	network, err := parsePrefix(cidrRange)
+	if err != nil {
+		return nil
+	}
 
-			allocated = append(allocated, newAllocationInfo(n))
+	var inFamily []string
+
+	for _, c := range cidrs {
+		prefix, err := parsePrefix(c)
+		if err == nil && prefix.Addr().Is6() == network.Addr().Is6() {
+			inFamily = append(inFamily, c)
 		}
 	}
 
-	return allocateBySize(info.AllocationSize, network, allocated)
+	return inFamily
 }
 
-func allocateBySize(size uint, network *net.IPNet, allocated []allocationInfo) (string, error) {
-	bitSize := bits.LeadingZeros(0) - bits.LeadingZeros(size-1)
-	_, totalbits := network.Mask.Size()
-	clusterPrefix := totalbits - bitSize
-	mask := net.CIDRMask(clusterPrefix, totalbits)
+func CheckForOverlappingCIDRs(infoMap map[string]*ClusterInfo, cidr, clusterID string) error {
+	overlaps, err := FindOverlaps(infoMap, cidr, clusterID)
+	if err != nil {
+		return err
+	}
 
-	cidr := fmt.Sprintf("%s/%d", network.IP, clusterPrefix)
+	if len(overlaps) > 0 {
+		return fmt.Errorf("invalid CIDR %q overlaps with cluster %q", cidr, overlaps[0])
+	}
 
-	for {
-		last, err := allocateByCIDR(cidr, network, allocated)
-		if err == nil {
-			break
+	return nil
+}
+
+// FindOverlaps returns the IDs, sorted, of the clusters other than the given one which have a CIDR overlapping with the
+// given CIDR.
+func FindOverlaps(infoMap map[string]*ClusterInfo, cidr, clusterID string) ([]string, error) {
+	var overlaps []string
+
+	for _, ci := range infoMap {
+		overlap, err := isOverlappingCIDR(ci.CIDRs, cidr)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to validate overlapping CIDRs")
 		}
 
-		if last == 0 {
-			return "", err
+		if overlap && ci.ClusterID != clusterID {
+			overlaps = append(overlaps, ci.ClusterID)
 		}
+	}
+
+	slices.Sort(overlaps)
+
+	return overlaps, nil
+}
+
+// Allocate allocates a block of AllocationSize addresses from the CIDR range that doesn't overlap with the CIDRs
+// already allocated to the clusters, using the Strategy. The CIDR range may be IPv4 or IPv6; allocated CIDRs of the
+// other IP family are ignored.
+func Allocate(info *Info) (string, error) {
+	network, err := parsePrefix(info.CIDR)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse CIDR %q", info.CIDR)
+	}
+
+	allocated, err := allocatedInFamily(info.Clusters, network)
+	if err != nil {
+		return "", err
+	}
 
-		nextNet := net.IPNet{
-			IP:   uintToIP(last + 1),
-			Mask: mask,
+	hostBits := bits.Len(info.AllocationSize - 1)
+	if hostBits > network.Addr().BitLen()-network.Bits() {
+		return "", fmt.Errorf("allocation size %d doesn't fit in %q", info.AllocationSize, network)
+	}
+
+	hint := addrToInt(network.Addr())
+
+	if info.Strategy == Aligned && info.Hint != "" {
+		hintPrefix, err := parsePrefix(info.Hint)
+		if err != nil || hintPrefix.Addr().Is6() != network.Addr().Is6() {
+			return "", fmt.Errorf("invalid allocation hint %q for %q", info.Hint, network)
 		}
 
-		cidr = nextNet.String()
+		hint = addrToInt(hintPrefix.Addr())
 	}
 
-	return cidr, nil
+	start, err := allocateFrom(freeBlocks(network, allocated), hostBits, info.Strategy, hint)
+	if err != nil {
+		return "", err
+	}
+
+	if start == nil {
+		return "", fmt.Errorf("no more allocations available in %q", network)
+	}
+
+	return block{start: start, hostBits: hostBits}.prefix(network).String(), nil
 }
 
-func allocateByCIDR(cidr string, network *net.IPNet, allocatedCIDRs []allocationInfo) (uint32, error) {
-	requestedIP, requestedNetwork, _ := net.ParseCIDR(cidr)
-	if !network.Contains(requestedIP) {
-		return 0, fmt.Errorf("no more allocations available in %q", network)
+// Capacity returns the number of addresses in the CIDR range that aren't allocated to any cluster and the number of
+// additional blocks of AllocationSize addresses that can still be allocated. The latter is zero if no AllocationSize
+// is set. Both saturate at math.MaxUint64, which large IPv6 ranges may exceed.
+func Capacity(info *Info) (uint64, uint64, error) {
+	network, err := parsePrefix(info.CIDR)
+	if err != nil {
+		return 0, 0, fmt.Errorf("unable to parse CIDR %q", info.CIDR)
 	}
 
-	newAllocation := newAllocationInfo(requestedNetwork)
-	if !network.Contains(uintToIP(newAllocation.lastIP)) {
-		return 0, fmt.Errorf("%s not a valid subnet of %v", uintToIP(newAllocation.lastIP), network)
+	allocated, err := allocatedInFamily(info.Clusters, network)
+	if err != nil {
+		return 0, 0, err
 	}
 
-	for _, allocated := range allocatedCIDRs {
-		if allocated.network.Contains(requestedIP) {
-			// subset of already allocated, try next
-			return allocated.lastIP, fmt.Errorf("%s is a subset of already allocated CIDR %v", cidr, allocated.network)
-		}
+	freeAddresses := new(big.Int)
+	freeAllocations := new(big.Int)
+
+	for _, free := range freeBlocks(network, allocated) {
+		freeAddresses.Add(freeAddresses, hostCount(free.hostBits))
 
-		if requestedNetwork.Contains(allocated.network.IP) {
-			// already allocated is subset of requested, no valid lastIP
-			return newAllocation.lastIP, fmt.Errorf("%s overlaps with already allocated globalCidr %s", cidr, allocated.network)
+		// Free blocks are aligned so they hold a whole number of aligned allocations.
+		if hostBits := bits.Len(info.AllocationSize - 1); info.AllocationSize > 0 && free.hostBits >= hostBits {
+			freeAllocations.Add(freeAllocations, hostCount(free.hostBits-hostBits))
 		}
 	}
 
-	return 0, nil
+	return saturatedUint64(freeAddresses), saturatedUint64(freeAllocations), nil
 }
 
-func newAllocationInfo(network *net.IPNet) allocationInfo {
-	ones, total := network.Mask.Size()
-	size := total - ones
+func allocatedInFamily(clusters map[string]*ClusterInfo, network netip.Prefix) ([]netip.Prefix, error) {
+	var allocated []netip.Prefix
 
-	return allocationInfo{
-		network: network,
-		size:    size,
-		lastIP:  ipToUint(network.IP) + 1<<size - 1,
+	for _, cluster := range clusters {
+		for _, cidr := range cluster.CIDRs {
+			prefix, err := parsePrefix(cidr)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse CIDR %q", cidr)
+			}
+
+			if prefix.Addr().Is6() == network.Addr().Is6() {
+				allocated = append(allocated, prefix)
+			}
+		}
 	}
+
+	return allocated, nil
 }
 
 func isOverlappingCIDR(cidrList []string, cidr string) (bool, error) {
-	_, newNet, err := net.ParseCIDR(cidr)
+	newNet, err := parsePrefix(cidr)
 	if err != nil {
 		return false, err //nolint:wrapcheck // No need to wrap here
 	}
 
 	for _, v := range cidrList {
-		_, baseNet, err := net.ParseCIDR(v)
+		baseNet, err := parsePrefix(v)
 		if err != nil {
 			return false, err //nolint:wrapcheck // No need to wrap here
 		}
 
-		if baseNet.Contains(newNet.IP) || newNet.Contains(baseNet.IP) {
+		if baseNet.Overlaps(newNet) {
 			return true, nil
 		}
 	}
@@ -257,35 +374,75 @@ func isOverlappingCIDR(cidrList []string, cidr string) (bool, error) {
 	return false, nil
 }
 
-func ipToUint(ip net.IP) uint32 {
-	intIP := ip
-	if len(ip) == 16 {
-		intIP = ip[12:16]
+// parsePrefix parses the given CIDR, clearing the host bits like net.ParseCIDR does.
+func parsePrefix(cidr string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+
+	return prefix.Masked(), err //nolint:wrapcheck // No need to wrap here
+}
+
+// lastAddr returns the last address in the given prefix.
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	ip := prefix.Masked().Addr().AsSlice()
+	for i := prefix.Bits(); i < len(ip)*8; i++ {
+		ip[i/8] |= 1 << (7 - i%8)
 	}
 
-	return binary.BigEndian.Uint32(intIP)
+	addr, _ := netip.AddrFromSlice(ip)
+
+	return addr
 }
 
-func uintToIP(ip uint32) net.IP {
-	netIP := make(net.IP, 4)
-	binary.BigEndian.PutUint32(netIP, ip)
+func addrToInt(addr netip.Addr) *big.Int {
+	return new(big.Int).SetBytes(addr.AsSlice())
+}
 
-	return netIP
+// hostCount returns the number of addresses in a block with the given number of host bits.
+func hostCount(hostBits int) *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), uint(hostBits)) //nolint:gosec // Ignore overflow conversion int -> uint
+}
+
+func bigMax(x, y *big.Int) *big.Int {
+	if x.Cmp(y) >= 0 {
+		return x
+	}
+
+	return y
+}
+
+func bigMin(x, y *big.Int) *big.Int {
+	if x.Cmp(y) <= 0 {
+		return x
+	}
+
+	return y
+}
+
+func saturatedUint64(n *big.Int) uint64 {
+	if !n.IsUint64() {
+		return math.MaxUint64
+	}
+
+	return n.Uint64()
 }
 
 func GetValidAllocationSize(cidrRange string, allocationSize uint) (uint, error) {
-	_, network, err := net.ParseCIDR(cidrRange)
+	network, err := parsePrefix(cidrRange)
 	if err != nil {
 		return 0, err //nolint:wrapcheck // No need to wrap here
 	}
 
-	ones, totalbits := network.Mask.Size()
-	availableSize := uint(1) << uint(totalbits-ones) //nolint:gosec // Ignore overflow conversion int -> uint
+	// Half the range, capped to what a uint can hold for large IPv6 ranges.
+	maxSize := uint(math.MaxUint)
+	if hostBits := network.Addr().BitLen() - network.Bits(); hostBits <= bits.UintSize {
+		maxSize = uint(new(big.Int).Rsh(hostCount(hostBits), 1).Uint64())
+	}
+
 	userClusterSize := allocationSize
 	allocationSize = nextPowerOf2(allocationSize)
 
-	if allocationSize > (availableSize / 2) {
-		return 0, fmt.Errorf("cluster size %d, should be <= %d", userClusterSize, availableSize/2)
+	if allocationSize > maxSize || (allocationSize == 0 && userClusterSize != 0) {
+		return 0, fmt.Errorf("cluster size %d, should be <= %d", userClusterSize, maxSize)
 	}
 
 	if allocationSize == 0 {
@@ -295,17 +452,9 @@ func GetValidAllocationSize(cidrRange string, allocationSize uint) (uint, error)
 	return allocationSize, nil
 }
 
-// Refer: https://graphics.stanford.edu/~seander/bithacks.html#RoundUpPowerOf2
+// nextPowerOf2 rounds n up to the next power of 2; it returns 0 for 0 and if the result overflows.
 func nextPowerOf2(n uint) uint {
-	n--
-	n |= n >> 1
-	n |= n >> 2
-	n |= n >> 4
-	n |= n >> 8
-	n |= n >> 16
-	n++
-
-	return n
+	return uint(1) << bits.Len(n-1)
 }
 
 func IsCIDRPreConfigured(clusterID string, clustersetIPNetworks map[string]*ClusterInfo) bool {
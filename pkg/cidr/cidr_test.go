@@ -19,6 +19,8 @@ limitations under the License.
 package cidr_test
 
 import (
+	"math"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/submariner-io/submariner-operator/pkg/cidr"
@@ -137,6 +139,309 @@ var _ = Describe("Allocate", func() {
 			Expect(err).To(HaveOccurred())
 		})
 	})
+
+	When("the CIDR range is IPv6", func() {
+		BeforeEach(func() {
+			cidrInfo.CIDR = "fd00:242::/48"
+			cidrInfo.AllocationSize = 65536
+		})
+
+		It("should allocate IPv6 CIDRs in sequence", func() {
+			result, err := cidr.Allocate(&cidrInfo)
+			Expect(err).To(Succeed())
+			Expect(result).To(Equal("fd00:242::/112"))
+
+			cidrInfo.Clusters["cluster1"] = &cidr.ClusterInfo{
+				ClusterID: "cluster1",
+				CIDRs:     []string{result},
+			}
+
+			result, err = cidr.Allocate(&cidrInfo)
+			Expect(err).To(Succeed())
+			Expect(result).To(Equal("fd00:242::1:0/112"))
+		})
+
+		It("should skip an allocated block that is larger than the allocation size", func() {
+			cidrInfo.Clusters["cluster1"] = &cidr.ClusterInfo{
+				ClusterID: "cluster1",
+				CIDRs:     []string{"fd00:242::/96"},
+			}
+
+			result, err := cidr.Allocate(&cidrInfo)
+			Expect(err).To(Succeed())
+			Expect(result).To(Equal("fd00:242::1:0:0/112"))
+		})
+	})
+
+	When("clusters have CIDRs allocated in both IP families", func() {
+		BeforeEach(func() {
+			cidrInfo.Clusters["cluster1"] = &cidr.ClusterInfo{
+				ClusterID: "cluster1",
+				CIDRs:     []string{"169.254.0.0/19", "fd00:242::/115"},
+			}
+		})
+
+		It("should only consider the CIDRs of the range's IP family", func() {
+			result, err := cidr.Allocate(&cidrInfo)
+			Expect(err).To(Succeed())
+			Expect(result).To(Equal("169.254.32.0/19"))
+
+			cidrInfo.CIDR = "fd00:242::/48"
+
+			result, err = cidr.Allocate(&cidrInfo)
+			Expect(err).To(Succeed())
+			Expect(result).To(Equal("fd00:242::2000/115"))
+		})
+	})
+
+	When("the allocation size is larger than the CIDR range", func() {
+		It("should return an error", func() {
+			cidrInfo.AllocationSize = 131072
+
+			_, err := cidr.Allocate(&cidrInfo)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("Allocate with a strategy", func() {
+	var cidrInfo cidr.Info
+
+	BeforeEach(func() {
+		cidrInfo = cidr.Info{
+			CIDR:           "169.254.0.0/16",
+			AllocationSize: 4096,
+			Clusters: map[string]*cidr.ClusterInfo{
+				"cluster1": {ClusterID: "cluster1", CIDRs: []string{"169.254.64.0/19"}},
+				"cluster2": {ClusterID: "cluster2", CIDRs: []string{"169.254.112.0/20"}},
+			},
+		}
+	})
+
+	Context("FirstFit", func() {
+		It("should allocate from the lowest free block", func() {
+			cidrInfo.Strategy = cidr.FirstFit
+
+			Expect(cidr.Allocate(&cidrInfo)).To(Equal("169.254.0.0/20"))
+		})
+	})
+
+	Context("BestFit", func() {
+		It("should allocate from the smallest free block that fits", func() {
+			cidrInfo.Strategy = cidr.BestFit
+
+			Expect(cidr.Allocate(&cidrInfo)).To(Equal("169.254.96.0/20"))
+		})
+	})
+
+	Context("Aligned", func() {
+		BeforeEach(func() {
+			cidrInfo.Strategy = cidr.Aligned
+		})
+
+		When("the hint is in a free block", func() {
+			It("should allocate the first aligned block after the hint", func() {
+				cidrInfo.Hint = "169.254.130.0/24"
+
+				Expect(cidr.Allocate(&cidrInfo)).To(Equal("169.254.144.0/20"))
+			})
+		})
+
+		When("the hint is in an allocated block", func() {
+			It("should allocate the next free block", func() {
+				cidrInfo.Hint = "169.254.64.0/24"
+
+				Expect(cidr.Allocate(&cidrInfo)).To(Equal("169.254.96.0/20"))
+			})
+		})
+
+		When("there's no free block after the hint", func() {
+			It("should wrap around to the start of the range", func() {
+				cidrInfo.Clusters["cluster3"] = &cidr.ClusterInfo{ClusterID: "cluster3", CIDRs: []string{"169.254.128.0/17"}}
+				cidrInfo.Hint = "169.254.120.0/21"
+
+				Expect(cidr.Allocate(&cidrInfo)).To(Equal("169.254.0.0/20"))
+			})
+		})
+
+		When("the hint is of the other IP family", func() {
+			It("should return an error", func() {
+				cidrInfo.Hint = "fd00::/64"
+
+				_, err := cidr.Allocate(&cidrInfo)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	When("the strategy is unknown", func() {
+		It("should return an error", func() {
+			cidrInfo.Strategy = "WorstFit"
+
+			_, err := cidr.Allocate(&cidrInfo)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("Usage", func() {
+	var cidrInfo cidr.Info
+
+	BeforeEach(func() {
+		cidrInfo = cidr.Info{
+			CIDR:           "169.254.0.0/16",
+			AllocationSize: 4096,
+			Clusters: map[string]*cidr.ClusterInfo{
+				"cluster1": {ClusterID: "cluster1", CIDRs: []string{"169.254.112.0/20", "fd00::/64"}},
+				"cluster2": {ClusterID: "cluster2", CIDRs: []string{"169.254.64.0/19"}},
+				"cluster3": {ClusterID: "cluster3", CIDRs: []string{"10.0.0.0/8"}},
+			},
+		}
+	})
+
+	It("should return the allocated and free blocks of the range", func() {
+		usage, err := cidr.Usage(&cidrInfo)
+		Expect(err).To(Succeed())
+		Expect(*usage).To(Equal(cidr.RangeUsage{
+			Allocated:   []string{"169.254.64.0/19", "169.254.112.0/20"},
+			Free:        []string{"169.254.0.0/18", "169.254.96.0/20", "169.254.128.0/17"},
+			LargestFree: "169.254.128.0/17",
+		}))
+	})
+
+	When("the range is fully allocated", func() {
+		It("should return no free blocks", func() {
+			cidrInfo.Clusters["cluster3"].CIDRs = []string{"169.254.0.0/16"}
+
+			usage, err := cidr.Usage(&cidrInfo)
+			Expect(err).To(Succeed())
+			Expect(usage.Free).To(BeEmpty())
+			Expect(usage.LargestFree).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("Capacity", func() {
+	var cidrInfo cidr.Info
+
+	BeforeEach(func() {
+		cidrInfo = cidr.Info{
+			CIDR:           "169.254.0.0/16",
+			AllocationSize: 8192,
+			Clusters:       map[string]*cidr.ClusterInfo{},
+		}
+	})
+
+	When("no CIDRs are allocated", func() {
+		It("should return the whole range as free", func() {
+			freeAddresses, freeAllocations, err := cidr.Capacity(&cidrInfo)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(freeAddresses).To(Equal(uint64(65536)))
+			Expect(freeAllocations).To(Equal(uint64(8)))
+		})
+	})
+
+	When("CIDRs are allocated", func() {
+		BeforeEach(func() {
+			cidrInfo.Clusters["cluster1"] = &cidr.ClusterInfo{
+				ClusterID: "cluster1",
+				CIDRs:     []string{"169.254.0.0/19"},
+			}
+
+			cidrInfo.Clusters["cluster2"] = &cidr.ClusterInfo{
+				ClusterID: "cluster2",
+				CIDRs:     []string{"169.254.64.0/19", "169.254.160.0/24"},
+			}
+		})
+
+		It("should exclude them and the blocks they use", func() {
+			freeAddresses, freeAllocations, err := cidr.Capacity(&cidrInfo)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(freeAddresses).To(Equal(uint64(65536 - 2*8192 - 256)))
+			Expect(freeAllocations).To(Equal(uint64(5)))
+		})
+	})
+
+	When("allocated CIDRs overlap", func() {
+		BeforeEach(func() {
+			cidrInfo.Clusters["cluster1"] = &cidr.ClusterInfo{
+				ClusterID: "cluster1",
+				CIDRs:     []string{"169.254.0.0/19"},
+			}
+
+			cidrInfo.Clusters["cluster2"] = &cidr.ClusterInfo{
+				ClusterID: "cluster2",
+				CIDRs:     []string{"169.254.16.0/20"},
+			}
+		})
+
+		It("should count the overlapping addresses once", func() {
+			freeAddresses, freeAllocations, err := cidr.Capacity(&cidrInfo)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(freeAddresses).To(Equal(uint64(65536 - 8192)))
+			Expect(freeAllocations).To(Equal(uint64(7)))
+		})
+	})
+
+	When("no allocation size is set", func() {
+		BeforeEach(func() {
+			cidrInfo.AllocationSize = 0
+		})
+
+		It("should return no free allocations", func() {
+			freeAddresses, freeAllocations, err := cidr.Capacity(&cidrInfo)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(freeAddresses).To(Equal(uint64(65536)))
+			Expect(freeAllocations).To(BeZero())
+		})
+	})
+
+	When("an allocated CIDR is invalid", func() {
+		BeforeEach(func() {
+			cidrInfo.Clusters["cluster1"] = &cidr.ClusterInfo{
+				ClusterID: "cluster1",
+				CIDRs:     []string{"169.254.0.0/40"},
+			}
+		})
+
+		It("should return an error", func() {
+			_, _, err := cidr.Capacity(&cidrInfo)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("the CIDR range is IPv6", func() {
+		BeforeEach(func() {
+			cidrInfo.CIDR = "fd00:242::/96"
+			cidrInfo.AllocationSize = 65536
+
+			cidrInfo.Clusters["cluster1"] = &cidr.ClusterInfo{
+				ClusterID: "cluster1",
+				CIDRs:     []string{"169.254.0.0/19", "fd00:242::/112"},
+			}
+		})
+
+		It("should only count the IPv6 allocations", func() {
+			freeAddresses, freeAllocations, err := cidr.Capacity(&cidrInfo)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(freeAddresses).To(Equal(uint64(1<<32 - 65536)))
+			Expect(freeAllocations).To(Equal(uint64(65535)))
+		})
+	})
+
+	When("the number of free addresses exceeds 64 bits", func() {
+		BeforeEach(func() {
+			cidrInfo.CIDR = "fd00:242::/48"
+			cidrInfo.AllocationSize = 65536
+		})
+
+		It("should saturate the free addresses", func() {
+			freeAddresses, freeAllocations, err := cidr.Capacity(&cidrInfo)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(freeAddresses).To(Equal(uint64(math.MaxUint64)))
+			Expect(freeAllocations).To(Equal(uint64(math.MaxUint64)))
+		})
+	})
 })
 
 var _ = Describe("CheckForOverlappingCIDRs", func() {
@@ -237,6 +542,64 @@ var _ = Describe("CheckForOverlappingCIDRs", func() {
 	})
 })
 
+var _ = Describe("SplitByFamily", func() {
+	Specify("a dual-stack list should be split by IP family", func() {
+		ipv4CIDR, ipv6CIDR, err := cidr.SplitByFamily("fd00:242::/48, 242.0.0.0/8")
+		Expect(err).To(Succeed())
+		Expect(ipv4CIDR).To(Equal("242.0.0.0/8"))
+		Expect(ipv6CIDR).To(Equal("fd00:242::/48"))
+	})
+
+	Specify("a single-stack CIDR should leave the other IP family empty", func() {
+		ipv4CIDR, ipv6CIDR, err := cidr.SplitByFamily("242.0.0.0/8")
+		Expect(err).To(Succeed())
+		Expect(ipv4CIDR).To(Equal("242.0.0.0/8"))
+		Expect(ipv6CIDR).To(BeEmpty())
+	})
+
+	Specify("more than one CIDR of the same IP family should return an error", func() {
+		_, _, err := cidr.SplitByFamily("242.0.0.0/8,243.0.0.0/8")
+		Expect(err).To(HaveOccurred())
+	})
+
+	Specify("an invalid CIDR should return an error", func() {
+		_, _, err := cidr.SplitByFamily("242.0.0.0/8,fd00::/129")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("InFamilyOf", func() {
+	Specify("the CIDR of the range's IP family should be returned", func() {
+		cidrs := []string{"242.0.0.0/16", "fd00:242::/112"}
+
+		Expect(cidr.InFamilyOf(cidrs, "242.0.0.0/8")).To(Equal("242.0.0.0/16"))
+		Expect(cidr.InFamilyOf(cidrs, "fd00:242::/48")).To(Equal("fd00:242::/112"))
+		Expect(cidr.InFamilyOf(cidrs[:1], "fd00:242::/48")).To(BeEmpty())
+	})
+})
+
+var _ = Describe("GetValidAllocationSize", func() {
+	Specify("the size should be rounded up to the next power of 2", func() {
+		Expect(cidr.GetValidAllocationSize("242.0.0.0/8", 5000)).To(BeEquivalentTo(8192))
+		Expect(cidr.GetValidAllocationSize("fd00:242::/48", 5000)).To(BeEquivalentTo(8192))
+	})
+
+	Specify("a size larger than half the range should return an error", func() {
+		_, err := cidr.GetValidAllocationSize("242.0.0.0/16", 65536)
+		Expect(err).To(HaveOccurred())
+	})
+
+	Specify("a size larger than 64 bits can hold should return an error for an IPv6 range", func() {
+		_, err := cidr.GetValidAllocationSize("fd00:242::/48", 1<<63+1)
+		Expect(err).To(HaveOccurred())
+	})
+
+	Specify("a zero size should return an error", func() {
+		_, err := cidr.GetValidAllocationSize("242.0.0.0/8", 0)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
 var _ = Describe("IsValid", func() {
 	Specify("a valid CIDR should succeed", func() {
 		Expect(cidr.IsValid("10.10.20.128/24")).To(Succeed())
@@ -258,6 +621,14 @@ var _ = Describe("IsValid", func() {
 	Specify("Link-Local Multicast CIDR should return an error", func() {
 		Expect(cidr.IsValid("224.0.0.0/24")).ToNot(Succeed())
 	})
+
+	Specify("a valid IPv6 CIDR should succeed", func() {
+		Expect(cidr.IsValid("fd00:242::/48")).To(Succeed())
+	})
+
+	Specify("an IPv6 Link-Local CIDR should return an error", func() {
+		Expect(cidr.IsValid("fe80::/64")).ToNot(Succeed())
+	})
 })
 
 var _ = Describe("AddClusterInfoData", func() {
@@ -318,3 +689,81 @@ var _ = Describe("AddClusterInfoData", func() {
 		}))
 	})
 })
+
+var _ = Describe("RemoveClusterInfoData", func() {
+	var configMap *corev1.ConfigMap
+
+	clusterInfo1 := cidr.ClusterInfo{
+		ClusterID: "east",
+		CIDRs:     []string{"169.254.0.0/19"},
+	}
+
+	clusterInfo2 := cidr.ClusterInfo{
+		ClusterID: "west",
+		CIDRs:     []string{"169.254.32.0/19"},
+	}
+
+	BeforeEach(func() {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+		}
+
+		Expect(cidr.AddClusterInfoData(configMap, clusterInfo1)).To(Succeed())
+		Expect(cidr.AddClusterInfoData(configMap, clusterInfo2)).To(Succeed())
+	})
+
+	It("should remove the cluster's CIDRs", func() {
+		removed, err := cidr.RemoveClusterInfoData(configMap, clusterInfo1.ClusterID)
+		Expect(err).To(Succeed())
+		Expect(removed).To(BeTrue())
+
+		infoMap, err := cidr.ExtractClusterInfo(configMap)
+		Expect(err).To(Succeed())
+
+		Expect(infoMap).To(Equal(map[string]*cidr.ClusterInfo{
+			clusterInfo2.ClusterID: &clusterInfo2,
+		}))
+	})
+
+	Context("for an unknown cluster", func() {
+		It("should not change the ConfigMap", func() {
+			data := configMap.Data[cidr.ClusterInfoKey]
+
+			removed, err := cidr.RemoveClusterInfoData(configMap, "north")
+			Expect(err).To(Succeed())
+			Expect(removed).To(BeFalse())
+			Expect(configMap.Data[cidr.ClusterInfoKey]).To(Equal(data))
+		})
+	})
+})
+
+var _ = Describe("FindOverlaps", func() {
+	clusters := map[string]*cidr.ClusterInfo{
+		"east":  {ClusterID: "east", CIDRs: []string{"10.10.0.0/16"}},
+		"west":  {ClusterID: "west", CIDRs: []string{"10.20.0.0/16", "10.10.10.0/24"}},
+		"north": {ClusterID: "north", CIDRs: []string{"10.30.0.0/16"}},
+	}
+
+	It("should return the sorted IDs of the other overlapping clusters", func() {
+		overlaps, err := cidr.FindOverlaps(clusters, "10.10.0.0/20", "north")
+		Expect(err).To(Succeed())
+		Expect(overlaps).To(Equal([]string{"east", "west"}))
+
+		overlaps, err = cidr.FindOverlaps(clusters, "10.10.0.0/20", "east")
+		Expect(err).To(Succeed())
+		Expect(overlaps).To(Equal([]string{"west"}))
+	})
+
+	It("should return no IDs if no other cluster overlaps", func() {
+		overlaps, err := cidr.FindOverlaps(clusters, "10.30.1.0/24", "north")
+		Expect(err).To(Succeed())
+		Expect(overlaps).To(BeEmpty())
+	})
+
+	It("should return an error for an invalid CIDR", func() {
+		_, err := cidr.FindOverlaps(clusters, "10.10.0.0", "north")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,342 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cidrallocation stores the CIDRs allocated to the clusters from the broker's Globalnet and ClustersetIP ranges
+// as CIDRAllocation resources, one per cluster per pool. Brokers which predate the CIDRAllocation CRD recorded the
+// allocations as a JSON array in the pool's ConfigMap; that format is still read, migrated by the broker, and kept in
+// sync with the CIDRAllocations so that the operators and subctl versions which only know about it, in a clusterset
+// being upgraded, don't allocate overlapping CIDRs.
+package cidrallocation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/resource"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/cidr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GroupVersionResource is the resource of the CIDRAllocations, for dynamic clients.
+var GroupVersionResource = v1alpha1.GroupVersion.WithResource("cidrallocations")
+
+// Name returns the name of the CIDRAllocation of the given cluster in the given pool.
+func Name(pool v1alpha1.CIDRAllocationPool, clusterID string) string {
+	return resource.EnsureValidName(strings.ToLower(string(pool)) + "-" + clusterID)
+}
+
+// List returns the CIDRs allocated to each cluster from the given pool. Allocations still recorded in the pool's
+// ConfigMap, if given, are included unless the cluster has a CIDRAllocation.
+func List(ctx context.Context, client controllerClient.Client, namespace string, pool v1alpha1.CIDRAllocationPool,
+	configMap *corev1.ConfigMap,
+) (map[string]*cidr.ClusterInfo, error) {
+	clusters := map[string]*cidr.ClusterInfo{}
+
+	if configMap != nil {
+		var err error
+
+		clusters, err = cidr.ExtractClusterInfo(configMap)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // Errors are already wrapped
+		}
+	}
+
+	allocations, err := list(ctx, client, namespace, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range allocations {
+		clusters[allocations[i].Spec.ClusterID] = &cidr.ClusterInfo{
+			ClusterID: allocations[i].Spec.ClusterID,
+			CIDRs:     allocations[i].Spec.CIDRs,
+		}
+	}
+
+	return clusters, nil
+}
+
+func list(ctx context.Context, client controllerClient.Client, namespace string, pool v1alpha1.CIDRAllocationPool,
+) ([]v1alpha1.CIDRAllocation, error) {
+	allocations := &v1alpha1.CIDRAllocationList{}
+
+	err := client.List(ctx, allocations, controllerClient.InNamespace(namespace),
+		controllerClient.MatchingLabels{v1alpha1.CIDRAllocationPoolLabel: strings.ToLower(string(pool))})
+	if isCRDMissing(err) {
+		return nil, nil
+	}
+
+	return allocations.Items, errors.Wrapf(err, "error listing the %s CIDRAllocations", pool)
+}
+
+// Set records the CIDRs allocated to the cluster, in its CIDRAllocation and in the pool's ConfigMap. A conflict error is
+// returned if a CIDRAllocation created or updated concurrently for another cluster overlaps, or if the ConfigMap was
+// updated concurrently; the allocation should then be retried. If the broker doesn't have the CIDRAllocation CRD, the
+// allocation is only recorded in the ConfigMap.
+func Set(ctx context.Context, client controllerClient.Client, configMap *corev1.ConfigMap, pool v1alpha1.CIDRAllocationPool,
+	info cidr.ClusterInfo,
+) error {
+	allocation := newCIDRAllocation(configMap.Namespace, pool, info)
+
+	existing := &v1alpha1.CIDRAllocation{}
+
+	err := client.Get(ctx, controllerClient.ObjectKeyFromObject(allocation), existing)
+	if isCRDMissing(err) {
+		return setInConfigMap(ctx, client, configMap, info)
+	}
+
+	switch {
+	case err == nil:
+		previousCIDRs := existing.Spec.CIDRs
+		existing.Spec.CIDRs = info.CIDRs
+
+		// The update fails with a conflict if the CIDRAllocation was updated since it was retrieved
+		err = client.Update(ctx, existing)
+		if err != nil {
+			return errors.Wrapf(err, "error updating CIDRAllocation %q", existing.Name)
+		}
+
+		err = checkConcurrentUpdate(ctx, client, existing, previousCIDRs)
+	case apierrors.IsNotFound(err):
+		err = client.Create(ctx, allocation)
+		if err != nil {
+			return errors.Wrapf(err, "error creating CIDRAllocation %q", allocation.Name)
+		}
+
+		err = checkConcurrentAllocations(ctx, client, allocation)
+	default:
+		return errors.Wrapf(err, "error retrieving CIDRAllocation %q", allocation.Name)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// The allocation is mirrored in the ConfigMap for the older versions
+	return setInConfigMap(ctx, client, configMap, info)
+}
+
+// checkConcurrentAllocations deletes the given newly created allocation and returns a conflict error if it overlaps
+// with an allocation of another cluster which was created first.
+func checkConcurrentAllocations(ctx context.Context, client controllerClient.Client, allocation *v1alpha1.CIDRAllocation) error {
+	c, other, err := findOverlap(ctx, client, allocation, allocation.Spec.CIDRs, func(other *v1alpha1.CIDRAllocation) bool {
+		return createdBefore(other, allocation)
+	})
+	if err != nil || other == "" {
+		return err
+	}
+
+	if err := client.Delete(ctx, allocation); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "error deleting CIDRAllocation %q", allocation.Name)
+	}
+
+	return apierrors.NewConflict(GroupVersionResource.GroupResource(),
+		allocation.Name, fmt.Errorf("CIDR %q was concurrently allocated to cluster %q", c, other))
+}
+
+// checkConcurrentUpdate restores the previous CIDRs of the given updated allocation and returns a conflict error if the
+// CIDRs it added overlap with an allocation of another cluster. Unlike creations, updates can't be ordered, so all the
+// allocations involved in a race back off and the winner is decided by the retries.
+func checkConcurrentUpdate(ctx context.Context, client controllerClient.Client, allocation *v1alpha1.CIDRAllocation,
+	previousCIDRs []string,
+) error {
+	var added []string
+
+	for _, c := range allocation.Spec.CIDRs {
+		if !slices.Contains(previousCIDRs, c) {
+			added = append(added, c)
+		}
+	}
+
+	if len(added) == 0 {
+		return nil
+	}
+
+	c, other, err := findOverlap(ctx, client, allocation, added, func(_ *v1alpha1.CIDRAllocation) bool {
+		return true
+	})
+	if err != nil || other == "" {
+		return err
+	}
+
+	allocation.Spec.CIDRs = previousCIDRs
+
+	if err := client.Update(ctx, allocation); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "error restoring CIDRAllocation %q", allocation.Name)
+	}
+
+	return apierrors.NewConflict(GroupVersionResource.GroupResource(),
+		allocation.Name, fmt.Errorf("CIDR %q was concurrently allocated to cluster %q", c, other))
+}
+
+// findOverlap returns the first of the given CIDRs of the allocation which overlaps with an allocation of another cluster
+// selected by the given function, along with that cluster's ID, or an empty cluster ID if there is no overlap.
+func findOverlap(ctx context.Context, client controllerClient.Client, allocation *v1alpha1.CIDRAllocation, cidrs []string,
+	selected func(*v1alpha1.CIDRAllocation) bool,
+) (string, string, error) {
+	allocations, err := list(ctx, client, allocation.Namespace, allocation.Spec.Pool)
+	if err != nil {
+		return "", "", err
+	}
+
+	for i := range allocations {
+		other := &allocations[i]
+		if other.Spec.ClusterID == allocation.Spec.ClusterID || !selected(other) {
+			continue
+		}
+
+		for _, c := range cidrs {
+			if cidr.CheckForOverlappingCIDRs(map[string]*cidr.ClusterInfo{other.Spec.ClusterID: {
+				ClusterID: other.Spec.ClusterID, CIDRs: other.Spec.CIDRs,
+			}}, c, allocation.Spec.ClusterID) != nil {
+				return c, other.Spec.ClusterID, nil
+			}
+		}
+	}
+
+	return "", "", nil
+}
+
+func createdBefore(a, b *v1alpha1.CIDRAllocation) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+
+	return a.Name < b.Name
+}
+
+// Remove removes the CIDRs allocated to the cluster, from its CIDRAllocation and from the pool's ConfigMap, if given, and
+// returns whether it had any.
+func Remove(ctx context.Context, client controllerClient.Client, namespace string, configMap *corev1.ConfigMap,
+	pool v1alpha1.CIDRAllocationPool, clusterID string,
+) (bool, error) {
+	removed := false
+
+	if configMap != nil {
+		var err error
+
+		removed, err = cidr.RemoveClusterInfoData(configMap, clusterID)
+		if err != nil {
+			return false, err //nolint:wrapcheck // Errors are already wrapped
+		}
+
+		if removed {
+			if err := client.Update(ctx, configMap); err != nil {
+				return false, err //nolint:wrapcheck // Conflicts are retried by the caller
+			}
+		}
+	}
+
+	err := client.Delete(ctx, &v1alpha1.CIDRAllocation{ObjectMeta: metav1.ObjectMeta{
+		Name:      Name(pool, clusterID),
+		Namespace: namespace,
+	}})
+	if apierrors.IsNotFound(err) || isCRDMissing(err) {
+		return removed, nil
+	}
+
+	if err != nil {
+		return false, errors.Wrapf(err, "error deleting the %s CIDRAllocation of cluster %q", pool, clusterID)
+	}
+
+	return true, nil
+}
+
+// Migrate records the allocations of the pool's ConfigMap which don't have a CIDRAllocation as CIDRAllocations, and
+// mirrors the CIDRAllocations in the ConfigMap: they take precedence, as in List. The ConfigMap is only updated if it
+// changed.
+func Migrate(ctx context.Context, client controllerClient.Client, configMap *corev1.ConfigMap, pool v1alpha1.CIDRAllocationPool,
+) error {
+	clusters, err := cidr.ExtractClusterInfo(configMap)
+	if err != nil {
+		return err //nolint:wrapcheck // Errors are already wrapped
+	}
+
+	allocations, err := list(ctx, client, configMap.Namespace, pool)
+	if err != nil {
+		return err
+	}
+
+	allocated := map[string]bool{}
+	mirrored := configMap.DeepCopy()
+
+	for i := range allocations {
+		allocated[allocations[i].Spec.ClusterID] = true
+
+		if err := cidr.AddClusterInfoData(mirrored, cidr.ClusterInfo{
+			ClusterID: allocations[i].Spec.ClusterID,
+			CIDRs:     allocations[i].Spec.CIDRs,
+		}); err != nil {
+			return errors.Wrapf(err, "error adding ClusterInfo")
+		}
+	}
+
+	for _, info := range clusters {
+		if allocated[info.ClusterID] {
+			continue
+		}
+
+		err := client.Create(ctx, newCIDRAllocation(configMap.Namespace, pool, *info))
+		if err != nil && !apierrors.IsAlreadyExists(err) {
+			return errors.Wrapf(err, "error migrating the %s allocation of cluster %q", pool, info.ClusterID)
+		}
+	}
+
+	if mirrored.Data[cidr.ClusterInfoKey] == configMap.Data[cidr.ClusterInfoKey] {
+		return nil
+	}
+
+	return errors.Wrapf(client.Update(ctx, mirrored), "error mirroring the %s CIDRAllocations in ConfigMap %q", pool, configMap.Name)
+}
+
+func setInConfigMap(ctx context.Context, client controllerClient.Client, configMap *corev1.ConfigMap, info cidr.ClusterInfo) error {
+	err := cidr.AddClusterInfoData(configMap, info)
+	if err != nil {
+		return errors.Wrapf(err, "error adding ClusterInfo")
+	}
+
+	return client.Update(ctx, configMap) //nolint:wrapcheck // Conflicts are retried by the caller
+}
+
+func newCIDRAllocation(namespace string, pool v1alpha1.CIDRAllocationPool, info cidr.ClusterInfo) *v1alpha1.CIDRAllocation {
+	return &v1alpha1.CIDRAllocation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      Name(pool, info.ClusterID),
+			Namespace: namespace,
+			Labels:    map[string]string{v1alpha1.CIDRAllocationPoolLabel: strings.ToLower(string(pool))},
+		},
+		Spec: v1alpha1.CIDRAllocationSpec{
+			ClusterID: info.ClusterID,
+			Pool:      pool,
+			CIDRs:     info.CIDRs,
+		},
+	}
+}
+
+// isCRDMissing returns whether the error reports that the CIDRAllocation CRD isn't deployed.
+func isCRDMissing(err error) bool {
+	return meta.IsNoMatchError(err)
+}
@@ -0,0 +1,272 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cidrallocation_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/cidr"
+	"github.com/submariner-io/submariner-operator/pkg/cidrallocation"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const (
+	namespace = "test-ns"
+	pool      = v1alpha1.GlobalnetPool
+)
+
+var _ = Describe("CIDRAllocations", func() {
+	var (
+		client    controllerClient.Client
+		configMap *corev1.ConfigMap
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		client = fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-info",
+				Namespace: namespace,
+			},
+			Data: map[string]string{},
+		}
+
+		Expect(cidr.AddClusterInfoData(configMap, cidr.ClusterInfo{ClusterID: "east", CIDRs: []string{"242.0.0.0/16"}})).To(Succeed())
+		Expect(cidr.AddClusterInfoData(configMap, cidr.ClusterInfo{ClusterID: "west", CIDRs: []string{"242.1.0.0/16"}})).To(Succeed())
+		Expect(client.Create(ctx, configMap)).To(Succeed())
+	})
+
+	Context("List", func() {
+		It("should return the allocations recorded in the ConfigMap and as CIDRAllocations", func(ctx SpecContext) {
+			Expect(cidrallocation.Set(ctx, client, configMap, pool,
+				cidr.ClusterInfo{ClusterID: "west", CIDRs: []string{"242.2.0.0/16"}})).To(Succeed())
+			Expect(cidrallocation.Set(ctx, client, configMap, pool,
+				cidr.ClusterInfo{ClusterID: "north", CIDRs: []string{"242.3.0.0/16"}})).To(Succeed())
+
+			clustersetIPConfigMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-clustersetip-info",
+					Namespace: namespace,
+				},
+				Data: map[string]string{},
+			}
+			Expect(client.Create(ctx, clustersetIPConfigMap)).To(Succeed())
+			Expect(cidrallocation.Set(ctx, client, clustersetIPConfigMap, v1alpha1.ClustersetIPPool,
+				cidr.ClusterInfo{ClusterID: "south", CIDRs: []string{"243.0.0.0/16"}})).To(Succeed())
+
+			clusters, err := cidrallocation.List(ctx, client, namespace, pool, configMap)
+			Expect(err).To(Succeed())
+			Expect(clusters).To(Equal(map[string]*cidr.ClusterInfo{
+				"east":  {ClusterID: "east", CIDRs: []string{"242.0.0.0/16"}},
+				"west":  {ClusterID: "west", CIDRs: []string{"242.2.0.0/16"}},
+				"north": {ClusterID: "north", CIDRs: []string{"242.3.0.0/16"}},
+			}))
+		})
+	})
+
+	Context("Set", func() {
+		It("should create or update the cluster's CIDRAllocation", func(ctx SpecContext) {
+			Expect(cidrallocation.Set(ctx, client, configMap, pool,
+				cidr.ClusterInfo{ClusterID: "north", CIDRs: []string{"242.3.0.0/16"}})).To(Succeed())
+			Expect(cidrallocation.Set(ctx, client, configMap, pool,
+				cidr.ClusterInfo{ClusterID: "north", CIDRs: []string{"242.4.0.0/16"}})).To(Succeed())
+
+			allocation := &v1alpha1.CIDRAllocation{}
+			Expect(client.Get(ctx, controllerClient.ObjectKey{Namespace: namespace, Name: "globalnet-north"}, allocation)).To(Succeed())
+			Expect(allocation.Spec).To(Equal(v1alpha1.CIDRAllocationSpec{
+				ClusterID: "north",
+				Pool:      pool,
+				CIDRs:     []string{"242.4.0.0/16"},
+			}))
+			Expect(allocation.Labels).To(HaveKeyWithValue(v1alpha1.CIDRAllocationPoolLabel, "globalnet"))
+
+			Expect(client.Get(ctx, controllerClient.ObjectKeyFromObject(configMap), configMap)).To(Succeed())
+			Expect(cidr.ExtractClusterInfo(configMap)).To(HaveKeyWithValue("north",
+				&cidr.ClusterInfo{ClusterID: "north", CIDRs: []string{"242.4.0.0/16"}}))
+		})
+
+		When("another cluster concurrently allocated an overlapping CIDR", func() {
+			It("should return a conflict error and delete the CIDRAllocation", func(ctx SpecContext) {
+				Expect(cidrallocation.Set(ctx, client, configMap, pool,
+					cidr.ClusterInfo{ClusterID: "north", CIDRs: []string{"242.3.0.0/16"}})).To(Succeed())
+
+				err := cidrallocation.Set(ctx, client, configMap, pool,
+					cidr.ClusterInfo{ClusterID: "south", CIDRs: []string{"242.3.0.0/17"}})
+				Expect(apierrors.IsConflict(err)).To(BeTrue(), "Expected a conflict error, got %v", err)
+
+				Expect(apierrors.IsNotFound(client.Get(ctx, controllerClient.ObjectKey{Namespace: namespace, Name: "globalnet-south"},
+					&v1alpha1.CIDRAllocation{}))).To(BeTrue())
+			})
+		})
+
+		When("other clusters concurrently update their CIDRAllocations with overlapping CIDRs", func() {
+			BeforeEach(func(ctx SpecContext) {
+				Expect(cidrallocation.Set(ctx, client, configMap, pool,
+					cidr.ClusterInfo{ClusterID: "east", CIDRs: []string{"242.0.0.0/16"}})).To(Succeed())
+				Expect(cidrallocation.Set(ctx, client, configMap, pool,
+					cidr.ClusterInfo{ClusterID: "west", CIDRs: []string{"242.1.0.0/16"}})).To(Succeed())
+			})
+
+			It("should not keep the overlapping CIDRs", func(ctx SpecContext) {
+				// Run the overlap checks once both updates are written, and let them proceed once both listed the allocations
+				var updated, listed sync.WaitGroup
+
+				updated.Add(2)
+				listed.Add(2)
+
+				var lists atomic.Int32
+
+				racingClient := interceptor.NewClient(client.(controllerClient.WithWatch), interceptor.Funcs{
+					List: func(ctx context.Context, c controllerClient.WithWatch, list controllerClient.ObjectList,
+						opts ...controllerClient.ListOption,
+					) error {
+						_, racing := list.(*v1alpha1.CIDRAllocationList)
+						racing = racing && lists.Add(1) <= 2
+
+						if racing {
+							updated.Done()
+							updated.Wait()
+						}
+
+						err := c.List(ctx, list, opts...)
+
+						if racing {
+							listed.Done()
+							listed.Wait()
+						}
+
+						return err
+					},
+				})
+
+				infos := []cidr.ClusterInfo{
+					{ClusterID: "east", CIDRs: []string{"242.0.0.0/16", "242.2.0.0/16"}},
+					{ClusterID: "west", CIDRs: []string{"242.1.0.0/16", "242.2.0.0/16"}},
+				}
+
+				errs := make([]error, len(infos))
+
+				var done sync.WaitGroup
+
+				for i := range infos {
+					done.Add(1)
+
+					go func() {
+						defer GinkgoRecover()
+						defer done.Done()
+
+						errs[i] = cidrallocation.Set(ctx, racingClient, configMap.DeepCopy(), pool, infos[i])
+					}()
+				}
+
+				done.Wait()
+
+				for _, err := range errs {
+					Expect(apierrors.IsConflict(err)).To(BeTrue(), "Expected a conflict error, got %v", err)
+				}
+
+				clusters, err := cidrallocation.List(ctx, client, namespace, pool, nil)
+				Expect(err).To(Succeed())
+				Expect(clusters).To(Equal(map[string]*cidr.ClusterInfo{
+					"east": {ClusterID: "east", CIDRs: []string{"242.0.0.0/16"}},
+					"west": {ClusterID: "west", CIDRs: []string{"242.1.0.0/16"}},
+				}))
+
+				// The retries are no longer concurrent, the first one wins
+				Expect(client.Get(ctx, controllerClient.ObjectKeyFromObject(configMap), configMap)).To(Succeed())
+				Expect(cidrallocation.Set(ctx, client, configMap, pool, infos[0])).To(Succeed())
+
+				err = cidrallocation.Set(ctx, client, configMap, pool, infos[1])
+				Expect(apierrors.IsConflict(err)).To(BeTrue(), "Expected a conflict error, got %v", err)
+
+				clusters, err = cidrallocation.List(ctx, client, namespace, pool, nil)
+				Expect(err).To(Succeed())
+				Expect(clusters).To(HaveKeyWithValue("west", &cidr.ClusterInfo{ClusterID: "west", CIDRs: []string{"242.1.0.0/16"}}))
+			})
+		})
+	})
+
+	Context("Remove", func() {
+		It("should remove the cluster's allocations", func(ctx SpecContext) {
+			Expect(cidrallocation.Set(ctx, client, configMap, pool,
+				cidr.ClusterInfo{ClusterID: "east", CIDRs: []string{"242.0.0.0/16"}})).To(Succeed())
+
+			Expect(cidrallocation.Remove(ctx, client, namespace, configMap, pool, "east")).To(BeTrue())
+
+			clusters, err := cidrallocation.List(ctx, client, namespace, pool, configMap)
+			Expect(err).To(Succeed())
+			Expect(clusters).To(HaveLen(1))
+			Expect(clusters).To(HaveKey("west"))
+
+			Expect(cidrallocation.Remove(ctx, client, namespace, configMap, pool, "east")).To(BeFalse())
+		})
+	})
+
+	Context("Migrate", func() {
+		It("should copy the ConfigMap allocations to CIDRAllocations and keep them in the ConfigMap", func(ctx SpecContext) {
+			Expect(cidrallocation.Migrate(ctx, client, configMap, pool)).To(Succeed())
+
+			Expect(client.Get(ctx, controllerClient.ObjectKeyFromObject(configMap), configMap)).To(Succeed())
+			Expect(configMap.Data).To(HaveKey(cidr.ClusterInfoKey))
+
+			clusters, err := cidrallocation.List(ctx, client, namespace, pool, nil)
+			Expect(err).To(Succeed())
+			Expect(clusters).To(Equal(map[string]*cidr.ClusterInfo{
+				"east": {ClusterID: "east", CIDRs: []string{"242.0.0.0/16"}},
+				"west": {ClusterID: "west", CIDRs: []string{"242.1.0.0/16"}},
+			}))
+		})
+
+		It("should mirror the CIDRAllocations missing from the ConfigMap in it", func(ctx SpecContext) {
+			Expect(client.Create(ctx, &v1alpha1.CIDRAllocation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cidrallocation.Name(pool, "north"),
+					Namespace: namespace,
+					Labels:    map[string]string{v1alpha1.CIDRAllocationPoolLabel: "globalnet"},
+				},
+				Spec: v1alpha1.CIDRAllocationSpec{ClusterID: "north", Pool: pool, CIDRs: []string{"242.3.0.0/16"}},
+			})).To(Succeed())
+
+			Expect(cidrallocation.Migrate(ctx, client, configMap, pool)).To(Succeed())
+
+			Expect(client.Get(ctx, controllerClient.ObjectKeyFromObject(configMap), configMap)).To(Succeed())
+			Expect(cidr.ExtractClusterInfo(configMap)).To(Equal(map[string]*cidr.ClusterInfo{
+				"east":  {ClusterID: "east", CIDRs: []string{"242.0.0.0/16"}},
+				"west":  {ClusterID: "west", CIDRs: []string{"242.1.0.0/16"}},
+				"north": {ClusterID: "north", CIDRs: []string{"242.3.0.0/16"}},
+			}))
+
+			resourceVersion := configMap.ResourceVersion
+			Expect(cidrallocation.Migrate(ctx, client, configMap, pool)).To(Succeed())
+			Expect(client.Get(ctx, controllerClient.ObjectKeyFromObject(configMap), configMap)).To(Succeed())
+			Expect(configMap.ResourceVersion).To(Equal(resourceVersion))
+		})
+	})
+})
@@ -21,10 +21,13 @@ package clustersetip
 import (
 	"context"
 	"encoding/json"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/submariner-io/admiral/pkg/reporter"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
 	"github.com/submariner-io/submariner-operator/pkg/cidr"
+	"github.com/submariner-io/submariner-operator/pkg/cidrallocation"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/util/retry"
@@ -34,36 +37,86 @@ import (
 type Info struct {
 	Enabled bool
 	cidr.Info
+	// IPv6CIDR is the IPv6 range that clustersetIP CIDRs are allocated from in addition to the IPv4 range in Info.CIDR,
+	// if any.
+	IPv6CIDR string
+	// IPv6AllocationSize is the number of addresses allocated to each cluster from the IPv6 range.
+	IPv6AllocationSize uint
 }
 
 type Config struct {
 	ClusterID        string
 	ClustersetIPCIDR string
 	AllocationSize   uint
+	// IPv6AllocationSize is the number of addresses allocated from the IPv6 range, AllocationSize only applies to the IPv4
+	// one.
+	IPv6AllocationSize uint
+	// AllocationStrategy selects the free block the clustersetIP CIDRs are allocated from.
+	AllocationStrategy cidr.Strategy
+	// AllocationHint holds comma-separated CIDRs, at most one per IP family, used by the Aligned strategy.
+	AllocationHint string
 }
 
+// familyInfos returns the allocation info for each IP family that has a CIDR range configured.
+func (i *Info) familyInfos() []*cidr.Info {
+	var infos []*cidr.Info
+
+	if i.CIDR != "" || i.IPv6CIDR == "" {
+		infos = append(infos, &i.Info)
+	}
+
+	if i.IPv6CIDR != "" {
+		infos = append(infos, &cidr.Info{
+			CIDR:           i.IPv6CIDR,
+			AllocationSize: i.IPv6AllocationSize,
+			Clusters:       i.Clusters,
+		})
+	}
+
+	return infos
+}
+
+// AllocateClustersetIPCIDR allocates a clustersetIP CIDR from each configured IP family range and returns them
+// comma-separated.
 func AllocateClustersetIPCIDR(clustersetIPInfo *Info) (string, error) {
-	return cidr.Allocate(&clustersetIPInfo.Info) //nolint:wrapcheck // No need to wrap
+	var clustersetIPCIDRs []string
+
+	for _, info := range clustersetIPInfo.familyInfos() {
+		clustersetIPCIDR, err := cidr.Allocate(info)
+		if err != nil {
+			return "", err //nolint:wrapcheck // No need to wrap
+		}
+
+		clustersetIPCIDRs = append(clustersetIPCIDRs, clustersetIPCIDR)
+	}
+
+	return strings.Join(clustersetIPCIDRs, ","), nil
 }
 
 func ValidateClustersetIPConfiguration(clustersetIPInfo *Info, netconfig Config, status reporter.Interface) (string, error) {
 	status.Start("Validating ClustersetIP configuration")
 	defer status.End()
 
-	clustersetIPClusterSize := netconfig.AllocationSize
 	clustersetIPCIDR := netconfig.ClustersetIPCIDR
 
-	if clustersetIPClusterSize != 0 && clustersetIPClusterSize != clustersetIPInfo.AllocationSize {
-		clusterSize, err := cidr.GetValidAllocationSize(clustersetIPInfo.CIDR, clustersetIPClusterSize)
-		if err != nil {
-			return "", status.Error(err, "invalid cluster size")
-		}
+	var err error
+
+	if clustersetIPInfo.CIDR != "" {
+		clustersetIPInfo.AllocationSize, err = validClusterSize(clustersetIPInfo.CIDR, netconfig.AllocationSize,
+			clustersetIPInfo.AllocationSize)
+	}
 
-		clustersetIPInfo.AllocationSize = clusterSize
+	if err == nil && clustersetIPInfo.IPv6CIDR != "" {
+		clustersetIPInfo.IPv6AllocationSize, err = validClusterSize(clustersetIPInfo.IPv6CIDR, netconfig.IPv6AllocationSize,
+			clustersetIPInfo.IPv6AllocationSize)
+	}
+
+	if err != nil {
+		return "", status.Error(err, "invalid cluster size")
 	}
 
 	if clustersetIPCIDR != "" {
-		err := cidr.IsValid(clustersetIPCIDR)
+		err := validateCIDRs(clustersetIPCIDR)
 		if err != nil {
 			return "", errors.Wrap(err, "specified clustersetip-cidr is invalid")
 		}
@@ -72,6 +125,36 @@ func ValidateClustersetIPConfiguration(clustersetIPInfo *Info, netconfig Config,
 	return clustersetIPCIDR, nil
 }
 
+// validClusterSize returns the valid allocation size for the requested cluster size in the given range, or the current
+// allocation size if no cluster size is requested.
+func validClusterSize(cidrRange string, requested, current uint) (uint, error) {
+	if requested == 0 || requested == current {
+		return current, nil
+	}
+
+	return cidr.GetValidAllocationSize(cidrRange, requested) //nolint:wrapcheck // No need to wrap
+}
+
+// validateCIDRs checks that the given comma-separated CIDRs, at most one per IP family, are valid.
+func validateCIDRs(cidrs string) error {
+	ipv4CIDR, ipv6CIDR, err := cidr.SplitByFamily(cidrs)
+	if err != nil {
+		return err //nolint:wrapcheck // No need to wrap
+	}
+
+	for _, c := range []string{ipv4CIDR, ipv6CIDR} {
+		if c == "" {
+			continue
+		}
+
+		if err := cidr.IsValid(c); err != nil {
+			return err //nolint:wrapcheck // No need to wrap
+		}
+	}
+
+	return nil
+}
+
 func GetClustersetIPNetworks(ctx context.Context, client controllerClient.Client, brokerNamespace string) (*Info, *v1.ConfigMap, error) {
 	configMap, err := GetConfigMap(ctx, client, brokerNamespace)
 	if err != nil {
@@ -95,7 +178,14 @@ func GetClustersetIPNetworks(ctx context.Context, client controllerClient.Client
 		return nil, nil, errors.Wrap(err, "error reading ClustersetIPCidrRange")
 	}
 
-	clustersetIPInfo.Clusters, err = cidr.ExtractClusterInfo(configMap)
+	if cidrRange, ok := configMap.Data[clustersetIPCidrRangeV6]; ok {
+		err = json.Unmarshal([]byte(cidrRange), &clustersetIPInfo.IPv6CIDR)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error reading ClustersetIPCidrRangeV6")
+		}
+	}
+
+	clustersetIPInfo.Clusters, err = cidrallocation.List(ctx, client, brokerNamespace, v1alpha1.ClustersetIPPool, configMap)
 
 	return &clustersetIPInfo, configMap, err //nolint:wrapcheck // No need to wrap
 }
@@ -104,19 +194,42 @@ func assignClustersetIPs(clustersetIPInfo *Info, netconfig Config, status report
 	status.Start("Assigning ClustersetIP IPs")
 	defer status.End()
 
-	clustersetIPCIDR := netconfig.ClustersetIPCIDR
-	clusterID := netconfig.ClusterID
+	var clustersetIPCIDRs []string
+
+	for _, info := range clustersetIPInfo.familyInfos() {
+		info.Strategy = netconfig.AllocationStrategy
+		info.Hint = cidr.InFamilyOf(strings.Split(netconfig.AllocationHint, ","), info.CIDR)
+
+		clustersetIPCIDR, err := assignClustersetIPCIDR(info, netconfig.ClusterID,
+			cidr.InFamilyOf(strings.Split(netconfig.ClustersetIPCIDR, ","), info.CIDR), status)
+		if err != nil {
+			return "", err
+		}
+
+		clustersetIPCIDRs = append(clustersetIPCIDRs, clustersetIPCIDR)
+	}
+
+	return strings.Join(clustersetIPCIDRs, ","), nil
+}
+
+func assignClustersetIPCIDR(info *cidr.Info, clusterID, specifiedCIDR string, status reporter.Interface) (string, error) {
+	clustersetIPCIDR := specifiedCIDR
+	preConfiguredCIDR := ""
 	var err error
 
+	if clusterInfo := info.Clusters[clusterID]; clusterInfo != nil {
+		preConfiguredCIDR = cidr.InFamilyOf(clusterInfo.CIDRs, info.CIDR)
+	}
+
 	if clustersetIPCIDR == "" {
 		// ClustersetIPCIDR not specified by the user
-		if cidr.IsCIDRPreConfigured(clusterID, clustersetIPInfo.Clusters) {
+		if preConfiguredCIDR != "" {
 			// clustersetipCidr already configured on this cluster
-			clustersetIPCIDR = clustersetIPInfo.Clusters[clusterID].CIDRs[0]
+			clustersetIPCIDR = preConfiguredCIDR
 			status.Success("Using pre-configured clustersetip CIDR %s", clustersetIPCIDR)
 		} else {
 			// no clustersetipCidr configured on this cluster
-			clustersetIPCIDR, err = AllocateClustersetIPCIDR(clustersetIPInfo)
+			clustersetIPCIDR, err = cidr.Allocate(info)
 			if err != nil {
 				return "", status.Error(err, "unable to allocate clustersetip CIDR")
 			}
@@ -125,14 +238,14 @@ func assignClustersetIPs(clustersetIPInfo *Info, netconfig Config, status report
 		}
 	} else {
 		// ClustersetIP enabled, clustersetIPCIDR specified by user
-		if cidr.IsCIDRPreConfigured(clusterID, clustersetIPInfo.Clusters) {
+		if preConfiguredCIDR != "" {
 			// clustersetipCidr pre-configured on this cluster
-			clustersetIPCIDR = clustersetIPInfo.Clusters[clusterID].CIDRs[0]
+			clustersetIPCIDR = preConfiguredCIDR
 			status.Warning("A pre-configured clustersetip CIDR %s was detected - not using the specified CIDR %s",
-				clustersetIPCIDR, netconfig.ClustersetIPCIDR)
+				clustersetIPCIDR, specifiedCIDR)
 		} else {
 			// clustersetipCidr as specified by the user
-			err := cidr.CheckForOverlappingCIDRs(clustersetIPInfo.Clusters, netconfig.ClustersetIPCIDR, netconfig.ClusterID)
+			err := cidr.CheckForOverlappingCIDRs(info.Clusters, specifiedCIDR, clusterID)
 			if err != nil {
 				return "", status.Error(err, "error validating overlapping clustersetip CIDRs %s", clustersetIPCIDR)
 			}
@@ -155,8 +268,10 @@ func ValidateExistingClustersetIPNetworks(ctx context.Context, client controller
 	}
 
 	if clustersetIPInfo != nil {
-		if err = cidr.IsValid(clustersetIPInfo.CIDR); err != nil {
-			return errors.Wrap(err, "invalid ClustersetIPCidrRange")
+		for _, info := range clustersetIPInfo.familyInfos() {
+			if err = cidr.IsValid(info.CIDR); err != nil {
+				return errors.Wrap(err, "invalid ClustersetIPCidrRange")
+			}
 		}
 	}
 
@@ -171,6 +286,10 @@ func AllocateCIDRFromConfigMap(ctx context.Context, brokerAdminClient controller
 		config.AllocationSize = DefaultAllocationSize
 	}
 
+	if config.IPv6AllocationSize == 0 {
+		config.IPv6AllocationSize = DefaultIPv6AllocationSize
+	}
+
 	enabled := false
 	userClustersetIPCIDR := config.ClustersetIPCIDR
 
@@ -196,21 +315,21 @@ func AllocateCIDRFromConfigMap(ctx context.Context, brokerAdminClient controller
 		enabled = clustersetIPInfo.Enabled
 
 		if clustersetIPInfo.Clusters[config.ClusterID] == nil ||
-			clustersetIPInfo.Clusters[config.ClusterID].CIDRs[0] != config.ClustersetIPCIDR {
+			strings.Join(clustersetIPInfo.Clusters[config.ClusterID].CIDRs, ",") != config.ClustersetIPCIDR {
 			newClusterInfo := cidr.ClusterInfo{
 				ClusterID: config.ClusterID,
-				CIDRs:     []string{config.ClustersetIPCIDR},
+				CIDRs:     strings.Split(config.ClustersetIPCIDR, ","),
 			}
 
 			status.Start("Updating the ClustersetIP information on the Broker")
 
-			err = updateConfigMap(ctx, brokerAdminClient, clustersetIPConfigMap, newClusterInfo)
+			err = cidrallocation.Set(ctx, brokerAdminClient, clustersetIPConfigMap, v1alpha1.ClustersetIPPool, newClusterInfo)
 			if apierrors.IsConflict(err) {
-				status.Warning("Conflict occurred updating the ClustersetIP ConfigMap - retrying")
+				status.Warning("Conflict occurred recording the ClustersetIP allocation - retrying")
 				// Conflict with allocation, retry with user given CIDR to try reallocation
 				config.ClustersetIPCIDR = userClustersetIPCIDR
 			} else {
-				return status.Error(err, "error updating the ClustersetIP ConfigMap")
+				return status.Error(err, "error recording the ClustersetIP allocation")
 			}
 
 			return err
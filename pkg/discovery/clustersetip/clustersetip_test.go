@@ -107,6 +107,45 @@ var _ = Describe("AllocateCIDRFromConfigMap", func() {
 	})
 })
 
+var _ = Describe("AllocateCIDRFromConfigMap with dual-stack ranges", func() {
+	var client controllerClient.Client
+
+	BeforeEach(func(ctx SpecContext) {
+		client = fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+		Expect(clustersetip.CreateConfigMap(ctx, client, true, "168.254.0.0/16,fd00:243::/48",
+			8192, namespace)).To(Succeed())
+	})
+
+	It("should allocate a CIDR per IP family", func(ctx SpecContext) {
+		netconfig := &clustersetip.Config{
+			ClusterID: "east",
+		}
+
+		Expect(clustersetip.AllocateCIDRFromConfigMap(ctx, client, namespace,
+			netconfig, reporter.Klog())).To(BeTrue())
+		Expect(netconfig.ClustersetIPCIDR).To(Equal("168.254.0.0/20,fd00:243::/112"))
+
+		clustersetipInfo, _, err := clustersetip.GetClustersetIPNetworks(ctx, client, namespace)
+		Expect(err).To(Succeed())
+		Expect(clustersetipInfo.Clusters).To(HaveKeyWithValue(netconfig.ClusterID, &cidr.ClusterInfo{
+			CIDRs:     []string{"168.254.0.0/20", "fd00:243::/112"},
+			ClusterID: netconfig.ClusterID,
+		}))
+	})
+
+	It("should allocate the IPv6 CIDR with the IPv6 allocation size", func(ctx SpecContext) {
+		netconfig := &clustersetip.Config{
+			ClusterID:          "east",
+			AllocationSize:     1024,
+			IPv6AllocationSize: 4096,
+		}
+
+		Expect(clustersetip.AllocateCIDRFromConfigMap(ctx, client, namespace,
+			netconfig, reporter.Klog())).To(BeTrue())
+		Expect(netconfig.ClustersetIPCIDR).To(Equal("168.254.0.0/22,fd00:243::/116"))
+	})
+})
+
 var _ = Describe("ValidateExistingClustersetIPNetworks", func() {
 	var client controllerClient.Client
 
@@ -24,21 +24,26 @@ import (
 	"strconv"
 
 	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
 	"github.com/submariner-io/submariner-operator/pkg/cidr"
+	"github.com/submariner-io/submariner-operator/pkg/cidrallocation"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
-	clustersetIPConfigMapName = "submariner-clustersetip-info"
+	ConfigMapName             = "submariner-clustersetip-info"
 	clustersetIPEnabledKey    = "clustersetIPEnabled"
 	clustersetIPCidrRange     = "clustersetIPCidrRange"
+	clustersetIPCidrRangeV6   = "clustersetIPCidrRangeV6"
 	clustersetIPClusterSize   = "clustersetIPClusterSize"
 	DefaultCIDR               = "243.0.0.0/8"
-	DefaultAllocationSize     = 4096 // i.e., x.x.x.x/20 subnet mask
+	DefaultAllocationSize     = 4096  // i.e., x.x.x.x/20 subnet mask
+	DefaultIPv6AllocationSize = 65536 // i.e., a /112 prefix
 )
 
 func CreateConfigMap(ctx context.Context, client controllerClient.Client, clustersetIPEnabled bool,
@@ -61,7 +66,13 @@ func CreateConfigMap(ctx context.Context, client controllerClient.Client, cluste
 func NewClustersetIPConfigMap(clustersetIPEnabled bool, defaultClusteretIPCidrRange string,
 	defaultClustersetIPClusterSize uint, namespace string,
 ) (*corev1.ConfigMap, error) {
-	cidrRange, err := json.Marshal(defaultClusteretIPCidrRange)
+	// The IPv4 range is kept under the original key so that existing clusters can still read it.
+	ipv4Range, ipv6Range, err := cidr.SplitByFamily(defaultClusteretIPCidrRange)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid clustersetIP CIDR range")
+	}
+
+	cidrRange, err := json.Marshal(ipv4Range)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error marshalling clustersetIP CIDR range")
 	}
@@ -72,9 +83,18 @@ func NewClustersetIPConfigMap(clustersetIPEnabled bool, defaultClusteretIPCidrRa
 		clustersetIPClusterSize: strconv.FormatUint(uint64(defaultClustersetIPClusterSize), 10),
 	}
 
+	if ipv6Range != "" {
+		cidrRange, err = json.Marshal(ipv6Range)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error marshalling clustersetIP IPv6 CIDR range")
+		}
+
+		data[clustersetIPCidrRangeV6] = string(cidrRange)
+	}
+
 	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      clustersetIPConfigMapName,
+			Name:      ConfigMapName,
 			Namespace: namespace,
 		},
 		Data: data,
@@ -83,28 +103,36 @@ func NewClustersetIPConfigMap(clustersetIPEnabled bool, defaultClusteretIPCidrRa
 	return cm, nil
 }
 
-func updateConfigMap(ctx context.Context, client controllerClient.Client, configMap *corev1.ConfigMap, newCluster cidr.ClusterInfo,
-) error {
-	err := cidr.AddClusterInfoData(configMap, newCluster)
-	if err != nil {
-		return errors.Wrapf(err, "error adding ClusterInfo")
-	}
+// RemoveClusterAllocation releases the CIDRs allocated to the given cluster, if any, and returns whether it had any.
+func RemoveClusterAllocation(ctx context.Context, client controllerClient.Client, namespace, clusterID string) (bool, error) {
+	removed := false
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := GetConfigMap(ctx, client, namespace)
+		if apierrors.IsNotFound(err) {
+			configMap = nil
+		} else if err != nil {
+			return err
+		}
+
+		removed, err = cidrallocation.Remove(ctx, client, namespace, configMap, v1alpha1.ClustersetIPPool, clusterID)
 
-	err = client.Update(ctx, configMap)
+		return err //nolint:wrapcheck // Wrapped below
+	})
 
-	return errors.Wrapf(err, "error updating clustersetip ConfigMap")
+	return removed, errors.Wrapf(err, "error removing the clustersetip allocation of cluster %q", clusterID)
 }
 
 //nolint:wrapcheck // No need to wrap here
 func GetConfigMap(ctx context.Context, client controllerClient.Client, namespace string) (*corev1.ConfigMap, error) {
 	cm := &corev1.ConfigMap{}
-	return cm, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: clustersetIPConfigMapName}, cm)
+	return cm, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ConfigMapName}, cm)
 }
 
 //nolint:wrapcheck // No need to wrap here
 func DeleteConfigMap(ctx context.Context, client controllerClient.Client, namespace string) error {
 	return client.Delete(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
-		Name:      clustersetIPConfigMapName,
+		Name:      ConfigMapName,
 		Namespace: namespace,
 	}})
 }
@@ -0,0 +1,218 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploymentinfo
+
+import (
+	"context"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/resource"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("deployment-info")
+
+const (
+	clusterVersionName = "version"
+	infrastructureName = "cluster"
+)
+
+// providerIDPrefixes maps the node providerID schemes set by the cloud providers to the corresponding CloudProvider.
+var providerIDPrefixes = map[string]v1alpha1.CloudProvider{
+	"aws://":       v1alpha1.AWS,
+	"gce://":       v1alpha1.GCP,
+	"azure://":     v1alpha1.Azure,
+	"openstack://": v1alpha1.Openstack,
+	"kind://":      v1alpha1.Kind,
+	"ibm://":       v1alpha1.IBMCloud,
+	"kubevirt://":  v1alpha1.KubeVirt,
+}
+
+// nodeLabels maps well-known node labels set by the managed Kubernetes services to the corresponding KubernetesType.
+var nodeLabels = map[string]v1alpha1.KubernetesType{
+	"eks.amazonaws.com/nodegroup":   v1alpha1.EKS,
+	"cloud.google.com/gke-nodepool": v1alpha1.GKE,
+	"kubernetes.azure.com/cluster":  v1alpha1.AKS,
+}
+
+// platformTypes maps the OpenShift Infrastructure platform types to the corresponding CloudProvider.
+var platformTypes = map[configv1.PlatformType]v1alpha1.CloudProvider{
+	configv1.AWSPlatformType:       v1alpha1.AWS,
+	configv1.GCPPlatformType:       v1alpha1.GCP,
+	configv1.AzurePlatformType:     v1alpha1.Azure,
+	configv1.OpenStackPlatformType: v1alpha1.Openstack,
+	configv1.IBMCloudPlatformType:  v1alpha1.IBMCloud,
+	configv1.KubevirtPlatformType:  v1alpha1.KubeVirt,
+}
+
+// managedCloudProviders maps the managed Kubernetes services to the cloud provider they run on.
+var managedCloudProviders = map[v1alpha1.KubernetesType]v1alpha1.CloudProvider{
+	v1alpha1.EKS: v1alpha1.AWS,
+	v1alpha1.GKE: v1alpha1.GCP,
+	v1alpha1.AKS: v1alpha1.Azure,
+}
+
+// NewServerVersionClient returns a client used to retrieve the apiserver version or nil if no REST config is available.
+func NewServerVersionClient(restConfig *rest.Config) (discovery.ServerVersionInterface, error) {
+	if restConfig == nil {
+		return nil, nil //nolint:nilnil // Intentional as the version is optional.
+	}
+
+	client, err := discovery.NewDiscoveryClientForConfig(restConfig)
+
+	return client, errors.Wrap(err, "error creating the discovery client")
+}
+
+// Discover detects the Kubernetes distribution and the cloud provider of the cluster. The OpenShift ClusterVersion and
+// Infrastructure resources take precedence over the apiserver version, the well-known node labels and the node
+// providerIDs. The serverVersion client is optional and failing to retrieve the apiserver version isn't fatal, the
+// Kubernetes version is left empty in that case.
+func Discover(ctx context.Context, client controllerClient.Reader, serverVersion discovery.ServerVersionInterface,
+) (*v1alpha1.DeploymentInfo, error) {
+	info := &v1alpha1.DeploymentInfo{KubernetesType: v1alpha1.DefaultKubernetesType}
+
+	if serverVersion != nil {
+		version, err := serverVersion.ServerVersion()
+		if err == nil {
+			info.KubernetesVersion = version.GitVersion
+			info.KubernetesType = kubernetesTypeFromVersion(version.GitVersion)
+		} else {
+			log.Error(err, "Error retrieving the apiserver version, the Kubernetes version won't be reported")
+		}
+	}
+
+	isOpenShift, err := discoverClusterVersion(ctx, client, info)
+	if err != nil {
+		return nil, err
+	}
+
+	platformType, err := getOCPPlatformType(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	info.CloudProvider = platformTypes[platformType]
+
+	detectType := !isOpenShift && info.KubernetesType == v1alpha1.DefaultKubernetesType
+	if detectType || info.CloudProvider == "" {
+		err = discoverFromNodes(ctx, client, info, detectType)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if info.CloudProvider == "" {
+		info.CloudProvider = managedCloudProviders[info.KubernetesType]
+	}
+
+	return info, nil
+}
+
+func kubernetesTypeFromVersion(gitVersion string) v1alpha1.KubernetesType {
+	switch {
+	case strings.Contains(gitVersion, "-eks-"):
+		return v1alpha1.EKS
+	case strings.Contains(gitVersion, "-gke."):
+		return v1alpha1.GKE
+	}
+
+	return v1alpha1.DefaultKubernetesType
+}
+
+func discoverClusterVersion(ctx context.Context, client controllerClient.Reader, info *v1alpha1.DeploymentInfo) (bool, error) {
+	clusterVersion := &configv1.ClusterVersion{}
+
+	err := client.Get(ctx, types.NamespacedName{Name: clusterVersionName}, clusterVersion)
+	if resource.IsNotFoundErr(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, errors.Wrap(err, "error retrieving the OpenShift ClusterVersion resource")
+	}
+
+	info.KubernetesType = v1alpha1.OCP
+	info.KubernetesTypeVersion = clusterVersion.Status.Desired.Version
+
+	return true, nil
+}
+
+func getOCPPlatformType(ctx context.Context, client controllerClient.Reader) (configv1.PlatformType, error) {
+	clusterInfra := &configv1.Infrastructure{}
+
+	err := client.Get(ctx, types.NamespacedName{Name: infrastructureName}, clusterInfra)
+	if resource.IsNotFoundErr(err) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", errors.Wrap(err, "error retrieving cluster Infrastructure resource")
+	}
+
+	if clusterInfra.Status.PlatformStatus == nil {
+		return clusterInfra.Status.Platform, nil //nolint:staticcheck //Purposely using deprecated field for backwards compatibility
+	}
+
+	return clusterInfra.Status.PlatformStatus.Type, nil
+}
+
+func discoverFromNodes(ctx context.Context, client controllerClient.Reader, info *v1alpha1.DeploymentInfo, detectType bool) error {
+	nodes := &corev1.NodeList{}
+
+	err := client.List(ctx, nodes)
+	if err != nil {
+		return errors.Wrap(err, "error listing the nodes")
+	}
+
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+
+		if detectType && info.KubernetesType == v1alpha1.DefaultKubernetesType {
+			for label, kubernetesType := range nodeLabels {
+				if _, ok := node.Labels[label]; ok {
+					info.KubernetesType = kubernetesType
+				}
+			}
+		}
+
+		if info.CloudProvider == "" {
+			info.CloudProvider = cloudProviderFromProviderID(node.Spec.ProviderID)
+		}
+	}
+
+	return nil
+}
+
+func cloudProviderFromProviderID(providerID string) v1alpha1.CloudProvider {
+	for prefix, cloudProvider := range providerIDPrefixes {
+		if strings.HasPrefix(providerID, prefix) {
+			return cloudProvider
+		}
+	}
+
+	return ""
+}
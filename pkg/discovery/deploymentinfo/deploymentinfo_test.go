@@ -0,0 +1,213 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deploymentinfo_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/discovery/deploymentinfo"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	fakek8s "k8s.io/client-go/testing"
+	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Discover", func() {
+	var (
+		initObjs      []controllerClient.Object
+		serverVersion *fakediscovery.FakeDiscovery
+		info          *v1alpha1.DeploymentInfo
+		err           error
+	)
+
+	BeforeEach(func() {
+		initObjs = nil
+		serverVersion = &fakediscovery.FakeDiscovery{
+			Fake:               &fakek8s.Fake{},
+			FakedServerVersion: &version.Info{GitVersion: "v1.30.2"},
+		}
+	})
+
+	JustBeforeEach(func(ctx SpecContext) {
+		client := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(initObjs...).Build()
+		info, err = deploymentinfo.Discover(ctx, client, serverVersion)
+	})
+
+	When("no distribution or cloud provider is detected", func() {
+		It("should return the default Kubernetes type and the apiserver version", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*info).To(Equal(v1alpha1.DeploymentInfo{
+				KubernetesType:    v1alpha1.K8s,
+				KubernetesVersion: "v1.30.2",
+			}))
+		})
+	})
+
+	When("the apiserver version is from EKS", func() {
+		BeforeEach(func() {
+			serverVersion.FakedServerVersion.GitVersion = "v1.29.4-eks-036c24b"
+		})
+
+		It("should return EKS on AWS", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.KubernetesType).To(BeEquivalentTo(v1alpha1.EKS))
+			Expect(info.KubernetesVersion).To(Equal("v1.29.4-eks-036c24b"))
+			Expect(info.CloudProvider).To(BeEquivalentTo(v1alpha1.AWS))
+		})
+	})
+
+	When("the apiserver version is from GKE", func() {
+		BeforeEach(func() {
+			serverVersion.FakedServerVersion.GitVersion = "v1.30.3-gke.1969001"
+			initObjs = append(initObjs, newNode(nil, "gce://project/us-east1-b/node-1"))
+		})
+
+		It("should return GKE on GCP", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.KubernetesType).To(BeEquivalentTo(v1alpha1.GKE))
+			Expect(info.CloudProvider).To(BeEquivalentTo(v1alpha1.GCP))
+		})
+	})
+
+	When("the nodes have the AKS label", func() {
+		BeforeEach(func() {
+			initObjs = append(initObjs, newNode(map[string]string{"kubernetes.azure.com/cluster": "MC_rg_cluster"}, ""))
+		})
+
+		It("should return AKS on Azure", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.KubernetesType).To(BeEquivalentTo(v1alpha1.AKS))
+			Expect(info.CloudProvider).To(BeEquivalentTo(v1alpha1.Azure))
+		})
+	})
+
+	When("the nodes have a kind providerID", func() {
+		BeforeEach(func() {
+			initObjs = append(initObjs, newNode(nil, "kind://docker/cluster1/cluster1-worker"))
+		})
+
+		It("should return the kind cloud provider", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.KubernetesType).To(Equal(v1alpha1.K8s))
+			Expect(info.CloudProvider).To(BeEquivalentTo(v1alpha1.Kind))
+		})
+	})
+
+	When("the OpenShift ClusterVersion and Infrastructure exist", func() {
+		BeforeEach(func() {
+			initObjs = append(initObjs, newClusterVersion("4.16.3"), newInfrastructure(configv1.IBMCloudPlatformType),
+				newNode(nil, "aws:///us-east-1a/i-0123"))
+		})
+
+		It("should return OCP with the OpenShift version and the platform cloud provider", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*info).To(Equal(v1alpha1.DeploymentInfo{
+				KubernetesType:        v1alpha1.OCP,
+				KubernetesTypeVersion: "4.16.3",
+				KubernetesVersion:     "v1.30.2",
+				CloudProvider:         v1alpha1.IBMCloud,
+			}))
+		})
+	})
+
+	When("the OpenShift platform doesn't map to a cloud provider", func() {
+		BeforeEach(func() {
+			initObjs = append(initObjs, newClusterVersion("4.16.3"), newInfrastructure(configv1.BareMetalPlatformType),
+				newNode(map[string]string{"eks.amazonaws.com/nodegroup": "ng"}, "openstack:///8d6fd6b7"))
+		})
+
+		It("should return OCP with the cloud provider from the node providerID", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.KubernetesType).To(BeEquivalentTo(v1alpha1.OCP))
+			Expect(info.CloudProvider).To(BeEquivalentTo(v1alpha1.Openstack))
+		})
+	})
+
+	When("no server version client is provided", func() {
+		JustBeforeEach(func(ctx SpecContext) {
+			client := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(initObjs...).Build()
+			info, err = deploymentinfo.Discover(ctx, client, nil)
+		})
+
+		It("should not set the Kubernetes version", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.KubernetesType).To(Equal(v1alpha1.K8s))
+			Expect(info.KubernetesVersion).To(BeEmpty())
+		})
+	})
+
+	When("retrieving the apiserver version fails", func() {
+		BeforeEach(func() {
+			serverVersion.AddReactor("get", "version", func(_ fakek8s.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New("fake error")
+			})
+		})
+
+		It("should not set the Kubernetes version", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.KubernetesType).To(Equal(v1alpha1.K8s))
+			Expect(info.KubernetesVersion).To(BeEmpty())
+		})
+	})
+})
+
+func newNode(labels map[string]string, providerID string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-1",
+			Labels: labels,
+		},
+		Spec: corev1.NodeSpec{
+			ProviderID: providerID,
+		},
+	}
+}
+
+func newClusterVersion(desiredVersion string) *configv1.ClusterVersion {
+	return &configv1.ClusterVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "version",
+		},
+		Status: configv1.ClusterVersionStatus{
+			Desired: configv1.Release{Version: desiredVersion},
+		},
+	}
+}
+
+func newInfrastructure(platformType configv1.PlatformType) *configv1.Infrastructure {
+	return &configv1.Infrastructure{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+		},
+		Status: configv1.InfrastructureStatus{
+			PlatformStatus: &configv1.PlatformStatus{
+				Type: platformType,
+			},
+		},
+	}
+}
@@ -24,27 +24,36 @@ import (
 	"strconv"
 
 	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
 	"github.com/submariner-io/submariner-operator/pkg/cidr"
+	"github.com/submariner-io/submariner-operator/pkg/cidrallocation"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
-	globalCIDRConfigMapName     = "submariner-globalnet-info"
-	globalnetEnabledKey         = "globalnetEnabled"
-	globalnetCidrRange          = "globalnetCidrRange"
-	globalnetClusterSize        = "globalnetClusterSize"
-	DefaultGlobalnetCIDR        = "242.0.0.0/8"
-	DefaultGlobalnetClusterSize = 65536 // i.e., x.x.x.x/16 subnet mask
+	GlobalCIDRConfigMapName         = "submariner-globalnet-info"
+	globalnetEnabledKey             = "globalnetEnabled"
+	globalnetCidrRange              = "globalnetCidrRange"
+	globalnetCidrRangeV6            = "globalnetCidrRangeV6"
+	globalnetClusterSize            = "globalnetClusterSize"
+	globalnetClusterSizeV6          = "globalnetClusterSizeV6"
+	DefaultGlobalnetCIDR            = "242.0.0.0/8"
+	DefaultGlobalnetClusterSize     = 65536 // i.e., x.x.x.x/16 subnet mask
+	DefaultGlobalnetIPv6ClusterSize = 65536 // i.e., a /112 prefix
 )
 
+// CreateConfigMap creates the globalnet ConfigMap, if it doesn't exist. The IPv6 cluster size only applies to the IPv6
+// range, if any; it defaults to DefaultGlobalnetIPv6ClusterSize.
 func CreateConfigMap(ctx context.Context, client controllerClient.Client, globalnetEnabled bool, defaultGlobalCidrRange string,
-	defaultGlobalClusterSize uint, namespace string,
+	defaultGlobalClusterSize, defaultGlobalIPv6ClusterSize uint, namespace string,
 ) error {
-	gnConfigMap, err := NewGlobalnetConfigMap(globalnetEnabled, defaultGlobalCidrRange, defaultGlobalClusterSize, namespace)
+	gnConfigMap, err := NewGlobalnetConfigMap(globalnetEnabled, defaultGlobalCidrRange, defaultGlobalClusterSize,
+		defaultGlobalIPv6ClusterSize, namespace)
 	if err != nil {
 		return errors.Wrap(err, "error creating config map")
 	}
@@ -58,24 +67,45 @@ func CreateConfigMap(ctx context.Context, client controllerClient.Client, global
 }
 
 func NewGlobalnetConfigMap(globalnetEnabled bool, defaultGlobalCidrRange string,
-	defaultGlobalClusterSize uint, namespace string,
+	defaultGlobalClusterSize, defaultGlobalIPv6ClusterSize uint, namespace string,
 ) (*corev1.ConfigMap, error) {
 	labels := map[string]string{
 		"component": "submariner-globalnet",
 	}
 
-	cidrRange, err := json.Marshal(defaultGlobalCidrRange)
-	if err != nil {
-		return nil, errors.Wrapf(err, "error marshalling CIDR range")
-	}
-
 	var data map[string]string
 	if globalnetEnabled {
+		// The IPv4 range is kept under the original key so that existing clusters can still read it.
+		ipv4Range, ipv6Range, err := cidr.SplitByFamily(defaultGlobalCidrRange)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid CIDR range")
+		}
+
+		cidrRange, err := json.Marshal(ipv4Range)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error marshalling CIDR range")
+		}
+
 		data = map[string]string{
 			globalnetEnabledKey:  "true",
 			globalnetCidrRange:   string(cidrRange),
 			globalnetClusterSize: strconv.FormatUint(uint64(defaultGlobalClusterSize), 10),
 		}
+
+		if ipv6Range != "" {
+			cidrRange, err = json.Marshal(ipv6Range)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error marshalling IPv6 CIDR range")
+			}
+
+			data[globalnetCidrRangeV6] = string(cidrRange)
+
+			if defaultGlobalIPv6ClusterSize == 0 {
+				defaultGlobalIPv6ClusterSize = DefaultGlobalnetIPv6ClusterSize
+			}
+
+			data[globalnetClusterSizeV6] = strconv.FormatUint(uint64(defaultGlobalIPv6ClusterSize), 10)
+		}
 	} else {
 		data = map[string]string{
 			globalnetEnabledKey: "false",
@@ -84,7 +114,7 @@ func NewGlobalnetConfigMap(globalnetEnabled bool, defaultGlobalCidrRange string,
 
 	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      globalCIDRConfigMapName,
+			Name:      GlobalCIDRConfigMapName,
 			Namespace: namespace,
 			Labels:    labels,
 		},
@@ -94,28 +124,36 @@ func NewGlobalnetConfigMap(globalnetEnabled bool, defaultGlobalCidrRange string,
 	return cm, nil
 }
 
-func updateConfigMap(ctx context.Context, client controllerClient.Client, configMap *corev1.ConfigMap, newCluster cidr.ClusterInfo,
-) error {
-	err := cidr.AddClusterInfoData(configMap, newCluster)
-	if err != nil {
-		return errors.Wrapf(err, "error adding ClusterInfo")
-	}
+// RemoveClusterAllocation releases the CIDRs allocated to the given cluster, if any, and returns whether it had any.
+func RemoveClusterAllocation(ctx context.Context, client controllerClient.Client, namespace, clusterID string) (bool, error) {
+	removed := false
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := GetConfigMap(ctx, client, namespace)
+		if apierrors.IsNotFound(err) {
+			configMap = nil
+		} else if err != nil {
+			return err
+		}
+
+		removed, err = cidrallocation.Remove(ctx, client, namespace, configMap, v1alpha1.GlobalnetPool, clusterID)
 
-	err = client.Update(ctx, configMap)
+		return err //nolint:wrapcheck // Wrapped below
+	})
 
-	return errors.Wrapf(err, "error updating ConfigMap")
+	return removed, errors.Wrapf(err, "error removing the Globalnet allocation of cluster %q", clusterID)
 }
 
 //nolint:wrapcheck // No need to wrap here
 func GetConfigMap(ctx context.Context, client controllerClient.Client, namespace string) (*corev1.ConfigMap, error) {
 	cm := &corev1.ConfigMap{}
-	return cm, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: globalCIDRConfigMapName}, cm)
+	return cm, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: GlobalCIDRConfigMapName}, cm)
 }
 
 //nolint:wrapcheck // No need to wrap here
 func DeleteConfigMap(ctx context.Context, client controllerClient.Client, namespace string) error {
 	return client.Delete(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
-		Name:      globalCIDRConfigMapName,
+		Name:      GlobalCIDRConfigMapName,
 		Namespace: namespace,
 	}})
 }
@@ -21,10 +21,14 @@ package globalnet
 import (
 	"context"
 	"encoding/json"
+	"slices"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/submariner-io/admiral/pkg/reporter"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
 	"github.com/submariner-io/submariner-operator/pkg/cidr"
+	"github.com/submariner-io/submariner-operator/pkg/cidrallocation"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/util/retry"
@@ -34,26 +38,57 @@ import (
 type Info struct {
 	Enabled bool
 	cidr.Info
+	// IPv6CIDR is the IPv6 range that global CIDRs are allocated from in addition to the IPv4 range in Info.CIDR, if any.
+	IPv6CIDR string
+	// IPv6AllocationSize is the number of addresses allocated to each cluster from the IPv6 range.
+	IPv6AllocationSize uint
 }
 
 type Config struct {
 	ClusterID   string
 	GlobalCIDR  string
 	ClusterSize uint
+	// IPv6ClusterSize is the number of addresses allocated from the IPv6 range, ClusterSize only applies to the IPv4 one.
+	IPv6ClusterSize uint
+	// AllocationStrategy selects the free block the global CIDRs are allocated from.
+	AllocationStrategy cidr.Strategy
+	// AllocationHint holds comma-separated CIDRs, at most one per IP family, used by the Aligned strategy.
+	AllocationHint string
 }
 
-func AllocateGlobalCIDR(globalnetInfo *Info) (string, error) {
-	return cidr.Allocate(&globalnetInfo.Info) //nolint:wrapcheck // No need to wrap
+// familyInfos returns the allocation info for each IP family that has a CIDR range configured.
+func (i *Info) familyInfos() []*cidr.Info {
+	var infos []*cidr.Info
+
+	if i.CIDR != "" || i.IPv6CIDR == "" {
+		infos = append(infos, &i.Info)
+	}
+
+	if i.IPv6CIDR != "" {
+		infos = append(infos, &cidr.Info{
+			CIDR:           i.IPv6CIDR,
+			AllocationSize: i.IPv6AllocationSize,
+			Clusters:       i.Clusters,
+		})
+	}
+
+	return infos
 }
 
-func isCIDRPreConfigured(clusterID string, globalNetworks map[string]*cidr.ClusterInfo) bool {
-	// GlobalCIDR is not pre-configured
-	if globalNetworks[clusterID] == nil || globalNetworks[clusterID].CIDRs == nil || len(globalNetworks[clusterID].CIDRs) == 0 {
-		return false
+// AllocateGlobalCIDR allocates a global CIDR from each configured IP family range and returns them comma-separated.
+func AllocateGlobalCIDR(globalnetInfo *Info) (string, error) {
+	var globalCIDRs []string
+
+	for _, info := range globalnetInfo.familyInfos() {
+		globalCIDR, err := cidr.Allocate(info)
+		if err != nil {
+			return "", err //nolint:wrapcheck // No need to wrap
+		}
+
+		globalCIDRs = append(globalCIDRs, globalCIDR)
 	}
 
-	// GlobalCIDR is pre-configured
-	return true
+	return strings.Join(globalCIDRs, ","), nil
 }
 
 func ValidateGlobalnetConfiguration(globalnetInfo *Info, netconfig Config, status reporter.Interface) (string, error) {
@@ -61,25 +96,35 @@ func ValidateGlobalnetConfiguration(globalnetInfo *Info, netconfig Config, statu
 	defer status.End()
 
 	globalnetClusterSize := netconfig.ClusterSize
+	globalnetIPv6ClusterSize := netconfig.IPv6ClusterSize
 	globalnetCIDR := netconfig.GlobalCIDR
 
-	if globalnetInfo.Enabled && globalnetClusterSize != 0 && globalnetClusterSize != globalnetInfo.AllocationSize {
-		clusterSize, err := cidr.GetValidAllocationSize(globalnetInfo.CIDR, globalnetClusterSize)
+	if globalnetInfo.Enabled {
+		var err error
+
+		if globalnetInfo.CIDR != "" {
+			globalnetInfo.AllocationSize, err = validClusterSize(globalnetInfo.CIDR, globalnetClusterSize,
+				globalnetInfo.AllocationSize)
+		}
+
+		if err == nil && globalnetInfo.IPv6CIDR != "" {
+			globalnetInfo.IPv6AllocationSize, err = validClusterSize(globalnetInfo.IPv6CIDR, globalnetIPv6ClusterSize,
+				globalnetInfo.IPv6AllocationSize)
+		}
+
 		if err != nil {
 			return "", status.Error(err, "invalid cluster size")
 		}
-
-		globalnetInfo.AllocationSize = clusterSize
 	}
 
-	if globalnetCIDR != "" && globalnetClusterSize != 0 {
+	if globalnetCIDR != "" && (globalnetClusterSize != 0 || globalnetIPv6ClusterSize != 0) {
 		status.Failure("Only one of cluster size and global CIDR can be specified")
 
 		return "", errors.New("only one of cluster size and global CIDR can be specified")
 	}
 
 	if globalnetCIDR != "" {
-		err := cidr.IsValid(globalnetCIDR)
+		err := validateCIDRs(globalnetCIDR)
 		if err != nil {
 			return "", errors.Wrap(err, "specified globalnet-cidr is invalid")
 		}
@@ -90,16 +135,47 @@ func ValidateGlobalnetConfiguration(globalnetInfo *Info, netconfig Config, statu
 			status.Warning("Globalnet is not enabled on the Broker - ignoring the specified global CIDR")
 
 			globalnetCIDR = ""
-		} else if globalnetClusterSize != 0 {
+		} else if globalnetClusterSize != 0 || globalnetIPv6ClusterSize != 0 {
 			status.Warning("Globalnet is not enabled on the Broker - ignoring the specified cluster size")
 
 			globalnetInfo.AllocationSize = 0
+			globalnetInfo.IPv6AllocationSize = 0
 		}
 	}
 
 	return globalnetCIDR, nil
 }
 
+// validClusterSize returns the valid allocation size for the requested cluster size in the given range, or the current
+// allocation size if no cluster size is requested.
+func validClusterSize(cidrRange string, requested, current uint) (uint, error) {
+	if requested == 0 || requested == current {
+		return current, nil
+	}
+
+	return cidr.GetValidAllocationSize(cidrRange, requested) //nolint:wrapcheck // No need to wrap
+}
+
+// validateCIDRs checks that the given comma-separated CIDRs, at most one per IP family, are valid.
+func validateCIDRs(cidrs string) error {
+	ipv4CIDR, ipv6CIDR, err := cidr.SplitByFamily(cidrs)
+	if err != nil {
+		return err //nolint:wrapcheck // No need to wrap
+	}
+
+	for _, c := range []string{ipv4CIDR, ipv6CIDR} {
+		if c == "" {
+			continue
+		}
+
+		if err := cidr.IsValid(c); err != nil {
+			return err //nolint:wrapcheck // No need to wrap
+		}
+	}
+
+	return nil
+}
+
 func GetGlobalNetworks(ctx context.Context, client controllerClient.Client, brokerNamespace string) (*Info, *v1.ConfigMap, error) {
 	configMap, err := GetConfigMap(ctx, client, brokerNamespace)
 	if err != nil {
@@ -123,30 +199,71 @@ func GetGlobalNetworks(ctx context.Context, client controllerClient.Client, brok
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "error reading GlobalnetCidrRange")
 		}
+
+		if cidrRange, ok := configMap.Data[globalnetCidrRangeV6]; ok {
+			err = json.Unmarshal([]byte(cidrRange), &globalnetInfo.IPv6CIDR)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "error reading GlobalnetCidrRangeV6")
+			}
+
+			globalnetInfo.IPv6AllocationSize = DefaultGlobalnetIPv6ClusterSize
+		}
+
+		if clusterSize, ok := configMap.Data[globalnetClusterSizeV6]; ok {
+			err = json.Unmarshal([]byte(clusterSize), &globalnetInfo.IPv6AllocationSize)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "error reading GlobalnetClusterSizeV6")
+			}
+		}
 	}
 
-	globalnetInfo.Clusters, err = cidr.ExtractClusterInfo(configMap)
+	globalnetInfo.Clusters, err = cidrallocation.List(ctx, client, brokerNamespace, v1alpha1.GlobalnetPool, configMap)
 
 	return &globalnetInfo, configMap, err //nolint:wrapcheck // No need to wrap
 }
 
+// AssignGlobalnetIPs assigns a global CIDR from each configured IP family range and returns them comma-separated. A
+// CIDR of the same family that's pre-configured on the cluster or specified by the user takes precedence.
 func AssignGlobalnetIPs(globalnetInfo *Info, netconfig Config, status reporter.Interface) (string, error) {
 	status.Start("Assigning Globalnet IPs")
 	defer status.End()
 
-	globalnetCIDR := netconfig.GlobalCIDR
-	clusterID := netconfig.ClusterID
+	var globalnetCIDRs []string
+
+	for _, info := range globalnetInfo.familyInfos() {
+		info.Strategy = netconfig.AllocationStrategy
+		info.Hint = cidr.InFamilyOf(strings.Split(netconfig.AllocationHint, ","), info.CIDR)
+
+		globalnetCIDR, err := assignGlobalCIDR(info, netconfig.ClusterID,
+			cidr.InFamilyOf(strings.Split(netconfig.GlobalCIDR, ","), info.CIDR), status)
+		if err != nil {
+			return "", err
+		}
+
+		globalnetCIDRs = append(globalnetCIDRs, globalnetCIDR)
+	}
+
+	return strings.Join(globalnetCIDRs, ","), nil
+}
+
+func assignGlobalCIDR(info *cidr.Info, clusterID, specifiedCIDR string, status reporter.Interface) (string, error) {
+	globalnetCIDR := specifiedCIDR
+	preConfiguredCIDR := ""
 	var err error
 
+	if clusterInfo := info.Clusters[clusterID]; clusterInfo != nil {
+		preConfiguredCIDR = cidr.InFamilyOf(clusterInfo.CIDRs, info.CIDR)
+	}
+
 	if globalnetCIDR == "" {
 		// Globalnet enabled, GlobalCIDR not specified by the user
-		if isCIDRPreConfigured(clusterID, globalnetInfo.Clusters) {
+		if preConfiguredCIDR != "" {
 			// globalCidr already configured on this cluster
-			globalnetCIDR = globalnetInfo.Clusters[clusterID].CIDRs[0]
+			globalnetCIDR = preConfiguredCIDR
 			status.Success("Using pre-configured global CIDR %s", globalnetCIDR)
 		} else {
 			// no globalCidr configured on this cluster
-			globalnetCIDR, err = AllocateGlobalCIDR(globalnetInfo)
+			globalnetCIDR, err = cidr.Allocate(info)
 			if err != nil {
 				return "", status.Error(err, "unable to allocate global CIDR")
 			}
@@ -155,14 +272,14 @@ func AssignGlobalnetIPs(globalnetInfo *Info, netconfig Config, status reporter.I
 		}
 	} else {
 		// Globalnet enabled, globalnetCIDR specified by user
-		if cidr.IsCIDRPreConfigured(clusterID, globalnetInfo.Clusters) {
+		if preConfiguredCIDR != "" {
 			// globalCidr pre-configured on this cluster
-			globalnetCIDR = globalnetInfo.Clusters[clusterID].CIDRs[0]
+			globalnetCIDR = preConfiguredCIDR
 			status.Warning("A pre-configured global CIDR %s was detected - not using the specified CIDR %s",
-				globalnetCIDR, netconfig.GlobalCIDR)
+				globalnetCIDR, specifiedCIDR)
 		} else {
 			// globalCidr as specified by the user
-			err := cidr.CheckForOverlappingCIDRs(globalnetInfo.Clusters, netconfig.GlobalCIDR, netconfig.ClusterID)
+			err := cidr.CheckForOverlappingCIDRs(info.Clusters, specifiedCIDR, clusterID)
 			if err != nil {
 				return "", status.Error(err, "error validating overlapping global CIDRs %s", globalnetCIDR)
 			}
@@ -185,8 +302,10 @@ func ValidateExistingGlobalNetworks(ctx context.Context, client controllerClient
 	}
 
 	if globalnetInfo != nil && globalnetInfo.Enabled {
-		if err = cidr.IsValid(globalnetInfo.CIDR); err != nil {
-			return errors.Wrap(err, "invalid GlobalnetCidrRange")
+		for _, info := range globalnetInfo.familyInfos() {
+			if err = cidr.IsValid(info.CIDR); err != nil {
+				return errors.Wrap(err, "invalid GlobalnetCidrRange")
+			}
 		}
 	}
 
@@ -196,6 +315,8 @@ func ValidateExistingGlobalNetworks(ctx context.Context, client controllerClient
 func AllocateAndUpdateGlobalCIDRConfigMap(ctx context.Context, brokerAdminClient controllerClient.Client, brokerNamespace string,
 	netconfig *Config, status reporter.Interface,
 ) error {
+	userGlobalCIDR := netconfig.GlobalCIDR
+
 	retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		status.Start("Retrieving Globalnet information from the Broker")
 		defer status.End()
@@ -216,20 +337,30 @@ func AllocateAndUpdateGlobalCIDRConfigMap(ctx context.Context, brokerAdminClient
 				return status.Error(err, "error assigning Globalnet IPs")
 			}
 
-			if globalnetInfo.Clusters[netconfig.ClusterID] == nil ||
-				globalnetInfo.Clusters[netconfig.ClusterID].CIDRs[0] != netconfig.GlobalCIDR {
+			var allocatedCIDRs []string
+			if clusterInfo := globalnetInfo.Clusters[netconfig.ClusterID]; clusterInfo != nil {
+				allocatedCIDRs = clusterInfo.CIDRs
+			}
+
+			// The blocks the global CIDRs were expanded with are kept, unless they overlap with another cluster
+			globalCIDRs := withExpansions(strings.Split(netconfig.GlobalCIDR, ","), allocatedCIDRs, globalnetInfo.Clusters,
+				netconfig.ClusterID)
+
+			if strings.Join(allocatedCIDRs, ",") != strings.Join(globalCIDRs, ",") {
 				newClusterInfo := cidr.ClusterInfo{
 					ClusterID: netconfig.ClusterID,
-					CIDRs:     []string{netconfig.GlobalCIDR},
+					CIDRs:     globalCIDRs,
 				}
 
 				status.Start("Updating the Globalnet information on the Broker")
 
-				err = updateConfigMap(ctx, brokerAdminClient, globalnetConfigMap, newClusterInfo)
+				err = cidrallocation.Set(ctx, brokerAdminClient, globalnetConfigMap, v1alpha1.GlobalnetPool, newClusterInfo)
 				if apierrors.IsConflict(err) {
-					status.Warning("Conflict occurred updating the Globalnet ConfigMap - retrying")
+					status.Warning("Conflict occurred recording the Globalnet allocation - retrying")
+					// Conflict with allocation, retry with user given CIDR to try reallocation
+					netconfig.GlobalCIDR = userGlobalCIDR
 				} else {
-					return status.Error(err, "error updating the Globalnet ConfigMap")
+					return status.Error(err, "error recording the Globalnet allocation")
 				}
 
 				return err
@@ -241,3 +372,72 @@ func AllocateAndUpdateGlobalCIDRConfigMap(ctx context.Context, brokerAdminClient
 
 	return retryErr //nolint:wrapcheck // No need to wrap here
 }
+
+// ExpandGlobalCIDRs allocates blocks of the default cluster size from the broker's Globalnet ranges to the cluster until
+// it has the given number of blocks per IP family in addition to its global CIDRs, records them on the broker and returns
+// all its global CIDRs. The global CIDRs come first; blocks previously allocated to the cluster are kept.
+func ExpandGlobalCIDRs(ctx context.Context, brokerClient controllerClient.Client, brokerNamespace, clusterID, globalCIDR string,
+	expansions uint,
+) ([]string, error) {
+	var globalCIDRs []string
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		globalnetInfo, globalnetConfigMap, err := GetGlobalNetworks(ctx, brokerClient, brokerNamespace)
+		if err != nil {
+			return err
+		}
+
+		if !globalnetInfo.Enabled {
+			return errors.New("globalnet is not enabled on the broker")
+		}
+
+		var allocatedCIDRs []string
+		if clusterInfo := globalnetInfo.Clusters[clusterID]; clusterInfo != nil {
+			allocatedCIDRs = clusterInfo.CIDRs
+		}
+
+		globalCIDRs = withExpansions(strings.Split(globalCIDR, ","), allocatedCIDRs, globalnetInfo.Clusters, clusterID)
+
+		for _, info := range globalnetInfo.familyInfos() {
+			for n := uint(len(cidr.AllInFamilyOf(globalCIDRs, info.CIDR))); n < expansions+1; n++ {
+				// The blocks already expanding the cluster mustn't be allocated again
+				info.Clusters[clusterID] = &cidr.ClusterInfo{ClusterID: clusterID, CIDRs: globalCIDRs}
+
+				block, err := cidr.Allocate(info)
+				if err != nil {
+					return errors.Wrapf(err, "unable to expand the global CIDRs of cluster %q", clusterID)
+				}
+
+				globalCIDRs = append(globalCIDRs, block)
+			}
+		}
+
+		if strings.Join(allocatedCIDRs, ",") == strings.Join(globalCIDRs, ",") {
+			return nil
+		}
+
+		return cidrallocation.Set(ctx, brokerClient, globalnetConfigMap, v1alpha1.GlobalnetPool, //nolint:wrapcheck // Conflicts are retried
+			cidr.ClusterInfo{ClusterID: clusterID, CIDRs: globalCIDRs})
+	})
+
+	return globalCIDRs, err //nolint:wrapcheck // Errors are already wrapped
+}
+
+// withExpansions returns the given global CIDRs followed by the allocated CIDRs which expand them, i.e. which aren't the
+// first of their IP family. Expansions overlapping with the CIDRs of another cluster, which could have been recorded by
+// an operator without the concurrent allocation checks, are dropped so that they're allocated again.
+func withExpansions(globalCIDRs, allocatedCIDRs []string, clusters map[string]*cidr.ClusterInfo, clusterID string) []string {
+	result := append([]string{}, globalCIDRs...)
+
+	for i, c := range allocatedCIDRs {
+		if cidr.InFamilyOf(allocatedCIDRs[:i], c) == "" || slices.Contains(globalCIDRs, c) {
+			continue
+		}
+
+		if cidr.CheckForOverlappingCIDRs(clusters, c, clusterID) == nil {
+			result = append(result, c)
+		}
+	}
+
+	return result
+}
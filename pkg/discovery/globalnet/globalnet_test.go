@@ -19,14 +19,21 @@ limitations under the License.
 package globalnet_test
 
 import (
+	"context"
+	"sync"
+	"sync/atomic"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/submariner-io/admiral/pkg/reporter"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
 	"github.com/submariner-io/submariner-operator/pkg/cidr"
+	"github.com/submariner-io/submariner-operator/pkg/cidrallocation"
 	"github.com/submariner-io/submariner-operator/pkg/discovery/globalnet"
 	"k8s.io/client-go/kubernetes/scheme"
 	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 )
 
 const namespace = "test-ns"
@@ -37,7 +44,7 @@ var _ = Describe("AllocateAndUpdateGlobalCIDRConfigMap", func() {
 	BeforeEach(func(ctx SpecContext) {
 		client = fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
 		Expect(globalnet.CreateConfigMap(ctx, client, true, "168.254.0.0/16",
-			8192, namespace)).To(Succeed())
+			8192, 0, namespace)).To(Succeed())
 	})
 
 	When("the globalnet CIDR is not specified", func() {
@@ -93,6 +100,20 @@ var _ = Describe("AllocateAndUpdateGlobalCIDRConfigMap", func() {
 		})
 	})
 
+	When("the Aligned allocation strategy is specified", func() {
+		It("should allocate the CIDR at the hint", func(ctx SpecContext) {
+			netconfig := &globalnet.Config{
+				ClusterID:          "east",
+				AllocationStrategy: cidr.Aligned,
+				AllocationHint:     "168.254.64.0/19",
+			}
+
+			Expect(globalnet.AllocateAndUpdateGlobalCIDRConfigMap(ctx, client, namespace,
+				netconfig, reporter.Klog())).To(Succeed())
+			Expect(netconfig.GlobalCIDR).To(Equal("168.254.64.0/19"))
+		})
+	})
+
 	When("the globalnet cluster size is specified", func() {
 		It("should allocate a CIDR", func(ctx SpecContext) {
 			netconfig := &globalnet.Config{
@@ -107,6 +128,200 @@ var _ = Describe("AllocateAndUpdateGlobalCIDRConfigMap", func() {
 	})
 })
 
+var _ = Describe("AllocateAndUpdateGlobalCIDRConfigMap with dual-stack ranges", func() {
+	var client controllerClient.Client
+
+	BeforeEach(func(ctx SpecContext) {
+		client = fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+		Expect(globalnet.CreateConfigMap(ctx, client, true, "168.254.0.0/16,fd00:242::/48",
+			8192, 4096, namespace)).To(Succeed())
+	})
+
+	When("the globalnet CIDR is not specified", func() {
+		const expGlobalCIDR = "168.254.0.0/19,fd00:242::/116"
+
+		It("should allocate one per IP family", func(ctx SpecContext) {
+			netconfig := &globalnet.Config{
+				ClusterID: "east",
+			}
+
+			Expect(globalnet.AllocateAndUpdateGlobalCIDRConfigMap(ctx, client, namespace,
+				netconfig, reporter.Klog())).To(Succeed())
+			Expect(netconfig.GlobalCIDR).To(Equal(expGlobalCIDR))
+
+			globalnetInfo, _, err := globalnet.GetGlobalNetworks(ctx, client, namespace)
+			Expect(err).To(Succeed())
+			Expect(globalnetInfo.IPv6CIDR).To(Equal("fd00:242::/48"))
+			Expect(globalnetInfo.Clusters).To(HaveKeyWithValue(netconfig.ClusterID, &cidr.ClusterInfo{
+				CIDRs:     []string{"168.254.0.0/19", "fd00:242::/116"},
+				ClusterID: netconfig.ClusterID,
+			}))
+
+			netconfig = &globalnet.Config{
+				ClusterID: "west",
+			}
+
+			Expect(globalnet.AllocateAndUpdateGlobalCIDRConfigMap(ctx, client, namespace,
+				netconfig, reporter.Klog())).To(Succeed())
+			Expect(netconfig.GlobalCIDR).To(Equal("168.254.32.0/19,fd00:242::1000/116"))
+		})
+	})
+
+	When("the IPv6 globalnet cluster size is specified", func() {
+		It("should only apply to the IPv6 CIDR", func(ctx SpecContext) {
+			netconfig := &globalnet.Config{
+				ClusterID:       "east",
+				IPv6ClusterSize: 1024,
+			}
+
+			Expect(globalnet.AllocateAndUpdateGlobalCIDRConfigMap(ctx, client, namespace,
+				netconfig, reporter.Klog())).To(Succeed())
+			Expect(netconfig.GlobalCIDR).To(Equal("168.254.0.0/19,fd00:242::/118"))
+		})
+	})
+
+	When("only the IPv4 globalnet CIDR is specified", func() {
+		It("should allocate the IPv6 one", func(ctx SpecContext) {
+			netconfig := &globalnet.Config{
+				ClusterID:  "east",
+				GlobalCIDR: "168.254.64.0/19",
+			}
+
+			Expect(globalnet.AllocateAndUpdateGlobalCIDRConfigMap(ctx, client, namespace,
+				netconfig, reporter.Klog())).To(Succeed())
+			Expect(netconfig.GlobalCIDR).To(Equal("168.254.64.0/19,fd00:242::/116"))
+		})
+	})
+})
+
+var _ = Describe("ExpandGlobalCIDRs", func() {
+	var client controllerClient.Client
+
+	BeforeEach(func(ctx SpecContext) {
+		client = fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+		Expect(globalnet.CreateConfigMap(ctx, client, true, "168.254.0.0/16",
+			8192, 0, namespace)).To(Succeed())
+
+		for _, clusterID := range []string{"east", "west"} {
+			Expect(globalnet.AllocateAndUpdateGlobalCIDRConfigMap(ctx, client, namespace,
+				&globalnet.Config{ClusterID: clusterID}, reporter.Klog())).To(Succeed())
+		}
+	})
+
+	It("should allocate additional non-overlapping blocks to the cluster", func(ctx SpecContext) {
+		expCIDRs := []string{"168.254.0.0/19", "168.254.64.0/19", "168.254.96.0/19"}
+
+		globalCIDRs, err := globalnet.ExpandGlobalCIDRs(ctx, client, namespace, "east", "168.254.0.0/19", 2)
+		Expect(err).To(Succeed())
+		Expect(globalCIDRs).To(Equal(expCIDRs))
+
+		globalnetInfo, _, err := globalnet.GetGlobalNetworks(ctx, client, namespace)
+		Expect(err).To(Succeed())
+		Expect(globalnetInfo.Clusters["east"].CIDRs).To(Equal(expCIDRs))
+
+		By("Expanding again")
+
+		globalCIDRs, err = globalnet.ExpandGlobalCIDRs(ctx, client, namespace, "east", "168.254.0.0/19", 1)
+		Expect(err).To(Succeed())
+		Expect(globalCIDRs).To(Equal(expCIDRs))
+
+		By("Re-joining the cluster")
+
+		netconfig := &globalnet.Config{ClusterID: "east"}
+		Expect(globalnet.AllocateAndUpdateGlobalCIDRConfigMap(ctx, client, namespace,
+			netconfig, reporter.Klog())).To(Succeed())
+		Expect(netconfig.GlobalCIDR).To(Equal("168.254.0.0/19"))
+
+		globalnetInfo, _, err = globalnet.GetGlobalNetworks(ctx, client, namespace)
+		Expect(err).To(Succeed())
+		Expect(globalnetInfo.Clusters["east"].CIDRs).To(Equal(expCIDRs))
+	})
+
+	When("the Globalnet range is exhausted", func() {
+		It("should return an error", func(ctx SpecContext) {
+			_, err := globalnet.ExpandGlobalCIDRs(ctx, client, namespace, "east", "168.254.0.0/19", 7)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("clusters expand concurrently", func() {
+		It("should allocate them non-overlapping blocks", func(ctx SpecContext) {
+			// Hold the first CIDRAllocation updates until both clusters picked their block
+			var updating sync.WaitGroup
+
+			updating.Add(2)
+
+			var updates atomic.Int32
+
+			racingClient := interceptor.NewClient(client.(controllerClient.WithWatch), interceptor.Funcs{
+				Update: func(ctx context.Context, c controllerClient.WithWatch, obj controllerClient.Object,
+					opts ...controllerClient.UpdateOption,
+				) error {
+					if _, ok := obj.(*v1alpha1.CIDRAllocation); ok && updates.Add(1) <= 2 {
+						updating.Done()
+						updating.Wait()
+					}
+
+					return c.Update(ctx, obj, opts...)
+				},
+			})
+
+			globalCIDRs := map[string]string{"east": "168.254.0.0/19", "west": "168.254.32.0/19"}
+
+			var done sync.WaitGroup
+
+			for clusterID, globalCIDR := range globalCIDRs {
+				done.Add(1)
+
+				go func() {
+					defer GinkgoRecover()
+					defer done.Done()
+
+					_, err := globalnet.ExpandGlobalCIDRs(ctx, racingClient, namespace, clusterID, globalCIDR, 1)
+					Expect(err).To(Succeed())
+				}()
+			}
+
+			done.Wait()
+
+			globalnetInfo, _, err := globalnet.GetGlobalNetworks(ctx, client, namespace)
+			Expect(err).To(Succeed())
+
+			for clusterID := range globalCIDRs {
+				Expect(globalnetInfo.Clusters[clusterID].CIDRs).To(HaveLen(2))
+
+				for _, c := range globalnetInfo.Clusters[clusterID].CIDRs {
+					Expect(cidr.CheckForOverlappingCIDRs(globalnetInfo.Clusters, c, clusterID)).To(Succeed())
+				}
+			}
+		})
+	})
+
+	When("an expansion of the cluster overlaps with another cluster", func() {
+		BeforeEach(func(ctx SpecContext) {
+			for clusterID, cidrs := range map[string][]string{
+				"east": {"168.254.0.0/19", "168.254.64.0/19"},
+				"west": {"168.254.32.0/19", "168.254.64.0/19"},
+			} {
+				allocation := &v1alpha1.CIDRAllocation{}
+				Expect(client.Get(ctx, controllerClient.ObjectKey{
+					Namespace: namespace,
+					Name:      cidrallocation.Name(v1alpha1.GlobalnetPool, clusterID),
+				}, allocation)).To(Succeed())
+
+				allocation.Spec.CIDRs = cidrs
+				Expect(client.Update(ctx, allocation)).To(Succeed())
+			}
+		})
+
+		It("should replace it", func(ctx SpecContext) {
+			globalCIDRs, err := globalnet.ExpandGlobalCIDRs(ctx, client, namespace, "east", "168.254.0.0/19", 1)
+			Expect(err).To(Succeed())
+			Expect(globalCIDRs).To(Equal([]string{"168.254.0.0/19", "168.254.96.0/19"}))
+		})
+	})
+})
+
 var _ = Describe("ValidateExistingGlobalNetworks", func() {
 	var client controllerClient.Client
 
@@ -117,7 +332,7 @@ var _ = Describe("ValidateExistingGlobalNetworks", func() {
 	When("the existing globalnet config is valid", func() {
 		It("should succeed", func(ctx SpecContext) {
 			Expect(globalnet.CreateConfigMap(ctx, client, true, globalnet.DefaultGlobalnetCIDR,
-				globalnet.DefaultGlobalnetClusterSize, namespace)).To(Succeed())
+				globalnet.DefaultGlobalnetClusterSize, 0, namespace)).To(Succeed())
 
 			Expect(globalnet.ValidateExistingGlobalNetworks(ctx, client, namespace)).To(Succeed())
 		})
@@ -132,7 +347,7 @@ var _ = Describe("ValidateExistingGlobalNetworks", func() {
 	When("the existing globalnet CIDR is invalid", func() {
 		It("should return an error", func(ctx SpecContext) {
 			Expect(globalnet.CreateConfigMap(ctx, client, true, "169.254.0.0/16",
-				globalnet.DefaultGlobalnetClusterSize, namespace)).To(Succeed())
+				globalnet.DefaultGlobalnetClusterSize, 0, namespace)).To(Succeed())
 
 			Expect(globalnet.ValidateExistingGlobalNetworks(ctx, client, namespace)).ToNot(Succeed())
 		})
@@ -0,0 +1,153 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner/pkg/cni"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// Antrea is the name of the Antrea network plugin, recorded in the DetectedPluginSetting.
+const Antrea = "antrea"
+
+const antreaConfigName = "antrea-config"
+
+type antreaAgentConfig struct {
+	ServiceCIDR   string `json:"serviceCIDR"`
+	ServiceCIDRv6 string `json:"serviceCIDRv6"`
+	// All the other fields are ignored by Unmarshal
+}
+
+type antreaControllerConfig struct {
+	NodeIPAM struct {
+		ClusterCIDRs   []string `json:"clusterCIDRs"`
+		ServiceCIDR    string   `json:"serviceCIDR"`
+		ServiceCIDRv6  string   `json:"serviceCIDRv6"`
+		EnableNodeIPAM bool     `json:"enableNodeIPAM"`
+	} `json:"nodeIPAM"`
+	// All the other fields are ignored by Unmarshal
+}
+
+func discoverAntreaNetwork(ctx context.Context, client controllerClient.Client) (*ClusterNetwork, error) {
+	antreaPod, err := FindPod(ctx, client, "app=antrea,component=antrea-agent")
+	if err != nil || antreaPod == nil {
+		return nil, err
+	}
+
+	clusterNetwork := &ClusterNetwork{
+		NetworkPlugin:  cni.Generic,
+		PluginSettings: map[string]string{DetectedPluginSetting: Antrea},
+	}
+
+	agentConfig, controllerConfig, err := getAntreaConfig(ctx, client, antreaPod)
+	if err != nil {
+		return nil, err
+	}
+
+	// With NodeIPAM, the Antrea controller allocates the node pod CIDRs instead of the kube-controller-manager
+	if controllerConfig.NodeIPAM.EnableNodeIPAM {
+		clusterNetwork.PodCIDRs = controllerConfig.NodeIPAM.ClusterCIDRs
+	}
+
+	if len(clusterNetwork.PodCIDRs) == 0 {
+		podIPRange, err := findPodIPRange(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+
+		if podIPRange != "" {
+			clusterNetwork.PodCIDRs = strings.Split(podIPRange, ",")
+		}
+	}
+
+	clusterNetwork.ServiceCIDRs = nonEmpty(agentConfig.ServiceCIDR, agentConfig.ServiceCIDRv6)
+	if len(clusterNetwork.ServiceCIDRs) == 0 {
+		clusterNetwork.ServiceCIDRs = nonEmpty(controllerConfig.NodeIPAM.ServiceCIDR, controllerConfig.NodeIPAM.ServiceCIDRv6)
+	}
+
+	if len(clusterNetwork.ServiceCIDRs) == 0 {
+		clusterIPRange, err := findClusterIPRange(ctx, client)
+		if err == nil && clusterIPRange != "" {
+			clusterNetwork.ServiceCIDRs = strings.Split(clusterIPRange, ",")
+		}
+	}
+
+	return clusterNetwork, nil
+}
+
+// getAntreaConfig returns the agent and controller configurations from the Antrea ConfigMap mounted by the given agent
+// pod. Missing or malformed configurations are returned empty so the generic discovery mechanisms are used instead.
+func getAntreaConfig(ctx context.Context, client controllerClient.Client, antreaPod *corev1.Pod,
+) (*antreaAgentConfig, *antreaControllerConfig, error) {
+	agentConfig := &antreaAgentConfig{}
+	controllerConfig := &antreaControllerConfig{}
+
+	cm := &corev1.ConfigMap{}
+
+	err := client.Get(ctx, types.NamespacedName{Namespace: antreaPod.Namespace, Name: findAntreaConfigMapName(antreaPod)}, cm)
+	if apierrors.IsNotFound(err) {
+		return agentConfig, controllerConfig, nil
+	}
+
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "error retrieving the Antrea ConfigMap")
+	}
+
+	if err := yaml.Unmarshal([]byte(cm.Data["antrea-agent.conf"]), agentConfig); err != nil {
+		agentConfig = &antreaAgentConfig{}
+	}
+
+	if err := yaml.Unmarshal([]byte(cm.Data["antrea-controller.conf"]), controllerConfig); err != nil {
+		controllerConfig = &antreaControllerConfig{}
+	}
+
+	return agentConfig, controllerConfig, nil
+}
+
+// findAntreaConfigMapName returns the name of the Antrea ConfigMap mounted by the given pod; older Antrea versions
+// suffix it with a hash of its contents.
+func findAntreaConfigMapName(pod *corev1.Pod) string {
+	for i := range pod.Spec.Volumes {
+		if cm := pod.Spec.Volumes[i].ConfigMap; cm != nil && strings.HasPrefix(cm.Name, antreaConfigName) {
+			return cm.Name
+		}
+	}
+
+	return antreaConfigName
+}
+
+func nonEmpty(values ...string) []string {
+	var result []string
+
+	for _, value := range values {
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
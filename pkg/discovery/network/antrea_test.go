@@ -0,0 +1,107 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/submariner-operator/pkg/discovery/network"
+	"github.com/submariner-io/submariner/pkg/cni"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Antrea Network", func() {
+	var (
+		initObjs     []client.Object
+		antreaConfig *corev1.ConfigMap
+		clusterNet   *network.ClusterNetwork
+	)
+
+	BeforeEach(func() {
+		antreaConfig = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "antrea-config",
+				Namespace: "kube-system",
+			},
+			Data: map[string]string{
+				"antrea-agent.conf": "# The CIDR of the services\nserviceCIDR: " + testServiceCIDR + "\n",
+			},
+		}
+
+		initObjs = []client.Object{fakeAntreaAgentPod("antrea-config"), fakeKubeControllerManagerPod()}
+	})
+
+	JustBeforeEach(func(ctx SpecContext) {
+		clusterNet = testDiscoverNetworkSuccess(ctx, append(initObjs, antreaConfig)...)
+		Expect(clusterNet).NotTo(BeNil())
+		Expect(clusterNet.NetworkPlugin).To(Equal(cni.Generic))
+		Expect(clusterNet.PluginSettings).To(HaveKeyWithValue(network.DetectedPluginSetting, network.Antrea))
+	})
+
+	When("NodeIPAM isn't enabled", func() {
+		It("should return the pod CIDRs of the kube-controller-manager and the agent service CIDR", func() {
+			Expect(clusterNet.PodCIDRs).To(Equal([]string{testPodCIDR}))
+			Expect(clusterNet.ServiceCIDRs).To(Equal([]string{testServiceCIDR}))
+		})
+	})
+
+	When("NodeIPAM is enabled", func() {
+		BeforeEach(func() {
+			antreaConfig.Name = "antrea-config-7f8d9c"
+			antreaConfig.Data = map[string]string{
+				"antrea-controller.conf": "nodeIPAM:\n  enableNodeIPAM: true\n  clusterCIDRs: [10.10.0.0/16, fd00:10::/48]\n" +
+					"  serviceCIDR: 100.90.0.0/16\n  serviceCIDRv6: fd00:90::/112\n",
+			}
+
+			initObjs = []client.Object{fakeAntreaAgentPod(antreaConfig.Name)}
+		})
+
+		It("should return the NodeIPAM pod and service CIDRs", func() {
+			Expect(clusterNet.PodCIDRs).To(Equal([]string{"10.10.0.0/16", "fd00:10::/48"}))
+			Expect(clusterNet.ServiceCIDRs).To(Equal([]string{"100.90.0.0/16", "fd00:90::/112"}))
+		})
+	})
+
+	When("the Antrea configuration is malformed", func() {
+		BeforeEach(func() {
+			antreaConfig.Data["antrea-agent.conf"] = "serviceCIDR: [\n"
+			initObjs = append(initObjs, fakeKubeAPIServerPod())
+		})
+
+		It("should fall back to the generic discovery", func() {
+			Expect(clusterNet.PodCIDRs).To(Equal([]string{testPodCIDR}))
+			Expect(clusterNet.ServiceCIDRs).To(Equal([]string{testServiceCIDR}))
+		})
+	})
+})
+
+func fakeAntreaAgentPod(configMapName string) *corev1.Pod {
+	pod := fakePodWithNamespace("kube-system", "antrea-agent-xyz", "antrea-agent", []string{"antrea-agent"}, nil)
+	pod.Labels = map[string]string{"app": "antrea", "component": "antrea-agent"}
+	pod.Spec.Volumes = []corev1.Volume{{
+		Name: "antrea-config",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: configMapName}},
+		},
+	}}
+
+	return pod
+}
@@ -0,0 +1,216 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner/pkg/cni"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// AWSVPCCNI is the name of the Amazon VPC CNI network plugin, recorded in the DetectedPluginSetting.
+	AWSVPCCNI = "aws-vpc-cni"
+
+	// CustomNetworkingSetting is the PluginSettings key recording whether the Amazon VPC CNI custom networking is
+	// enabled, in which case the pods use the subnets of the ENIConfigs instead of those of the nodes.
+	CustomNetworkingSetting = "customNetworking"
+
+	awsNodeName            = "aws-node"
+	awsCustomNetworkingEnv = "AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG"
+
+	// VPC CIDR blocks are at most /16 for IPv4 and /56 for IPv6, and aligned on their size: the blocks of these sizes
+	// containing the pod addresses contain the whole VPC CIDR blocks.
+	vpcIPv4BlockBits = 16
+	vpcIPv6BlockBits = 56
+)
+
+var eniConfigGVK = schema.GroupVersionKind{Group: "crd.k8s.amazonaws.com", Version: "v1alpha1", Kind: "ENIConfigList"}
+
+//nolint:nilnil // Intentional as the purpose is to discover.
+func discoverAWSVPCCNINetwork(ctx context.Context, client controllerClient.Client) (*ClusterNetwork, error) {
+	awsNode, err := findDaemonSet(ctx, client, awsNodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	// The amazon-vpc-cni ConfigMap and the aws-node DaemonSet are often left behind when the VPC CNI is replaced
+	if awsNode == nil || !isScheduled(awsNode) {
+		return nil, nil
+	}
+
+	eniConfigs, err := listResources(ctx, client, eniConfigGVK)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterNetwork := &ClusterNetwork{
+		NetworkPlugin:  cni.Generic,
+		PluginSettings: map[string]string{DetectedPluginSetting: AWSVPCCNI, CustomNetworkingSetting: "false"},
+	}
+
+	var addresses []string
+
+	// The pod subnets are only known to AWS, the pod CIDRs are approximated from the addresses in use
+	if len(eniConfigs) > 0 || awsCustomNetworkingEnabled(awsNode) {
+		addresses, err = podAddresses(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+
+		clusterNetwork.PluginSettings[CustomNetworkingSetting] = "true"
+		clusterNetwork.PluginSettings[PodCIDRSourceSetting] = "podAddresses"
+		clusterNetwork.PluginSettings[LimitationsSetting] = fmt.Sprintf("The pods use the subnets of the ENIConfigs (%s);"+
+			" the pod CIDRs are the largest VPC CIDR blocks containing the addresses of the running pods, they may include"+
+			" other subnets and miss subnets without pods", strings.Join(eniConfigSubnets(eniConfigs), ", "))
+	} else {
+		addresses, err = nodeAddresses(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+
+		clusterNetwork.PluginSettings[PodCIDRSourceSetting] = "nodeAddresses"
+		clusterNetwork.PluginSettings[LimitationsSetting] = "The pods use the VPC subnets of the nodes; the pod CIDRs are" +
+			" the largest VPC CIDR blocks containing the node addresses, they may include other subnets of the VPC"
+	}
+
+	clusterNetwork.PodCIDRs = enclosingBlocks(addresses, vpcIPv4BlockBits, vpcIPv6BlockBits)
+
+	clusterIPRange, err := findClusterIPRange(ctx, client)
+	if err == nil && clusterIPRange != "" {
+		clusterNetwork.ServiceCIDRs = strings.Split(clusterIPRange, ",")
+	}
+
+	return clusterNetwork, nil
+}
+
+func awsCustomNetworkingEnabled(awsNode *appsv1.DaemonSet) bool {
+	for i := range awsNode.Spec.Template.Spec.Containers {
+		for _, envVar := range awsNode.Spec.Template.Spec.Containers[i].Env {
+			if envVar.Name == awsCustomNetworkingEnv {
+				return strings.EqualFold(envVar.Value, "true")
+			}
+		}
+	}
+
+	return false
+}
+
+func eniConfigSubnets(eniConfigs []unstructured.Unstructured) []string {
+	subnets := make([]string, 0, len(eniConfigs))
+
+	for i := range eniConfigs {
+		if subnet, _, _ := unstructured.NestedString(eniConfigs[i].Object, "spec", "subnet"); subnet != "" {
+			subnets = append(subnets, subnet)
+		}
+	}
+
+	return subnets
+}
+
+func nodeAddresses(ctx context.Context, client controllerClient.Client) ([]string, error) {
+	nodes := &corev1.NodeList{}
+
+	err := client.List(ctx, nodes)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error listing nodes")
+	}
+
+	var addresses []string
+
+	for i := range nodes.Items {
+		for _, address := range nodes.Items[i].Status.Addresses {
+			if address.Type == corev1.NodeInternalIP {
+				addresses = append(addresses, address.Address)
+			}
+		}
+	}
+
+	return addresses, nil
+}
+
+func podAddresses(ctx context.Context, client controllerClient.Client) ([]string, error) {
+	pods := &corev1.PodList{}
+
+	err := client.List(ctx, pods)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error listing Pods")
+	}
+
+	var addresses []string
+
+	for i := range pods.Items {
+		if pods.Items[i].Spec.HostNetwork {
+			continue
+		}
+
+		for _, podIP := range pods.Items[i].Status.PodIPs {
+			addresses = append(addresses, podIP.IP)
+		}
+	}
+
+	return addresses, nil
+}
+
+// enclosingBlocks returns the distinct blocks, of the given sizes per family, which contain the given addresses; IPv4
+// blocks first, each family sorted. Invalid addresses are ignored.
+func enclosingBlocks(addresses []string, ipv4Bits, ipv6Bits int) []string {
+	var blocks []netip.Prefix
+
+	for _, address := range addresses {
+		addr, err := netip.ParseAddr(address)
+		if err != nil {
+			continue
+		}
+
+		bits := ipv6Bits
+		if addr.Unmap().Is4() {
+			addr = addr.Unmap()
+			bits = ipv4Bits
+		}
+
+		block, err := addr.Prefix(bits)
+		if err == nil {
+			blocks = append(blocks, block)
+		}
+	}
+
+	slices.SortFunc(blocks, func(a, b netip.Prefix) int {
+		return a.Addr().Compare(b.Addr())
+	})
+
+	blocks = slices.Compact(blocks)
+
+	cidrs := make([]string, len(blocks))
+	for i := range blocks {
+		cidrs[i] = blocks[i].String()
+	}
+
+	return cidrs
+}
@@ -0,0 +1,159 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/submariner-operator/pkg/discovery/network"
+	"github.com/submariner-io/submariner/pkg/cni"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Amazon VPC CNI Network", func() {
+	var (
+		awsNode    *appsv1.DaemonSet
+		initObjs   []client.Object
+		clusterNet *network.ClusterNetwork
+	)
+
+	BeforeEach(func() {
+		awsNode = &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "aws-node",
+				Namespace: "kube-system",
+			},
+			Spec: appsv1.DaemonSetSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "aws-node"}},
+					},
+				},
+			},
+			Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 3},
+		}
+
+		initObjs = []client.Object{
+			fakeNodeWithAddresses("node1", "192.168.12.34", "2600:1f14:abc:de00::1"),
+			fakeNodeWithAddresses("node2", "192.168.100.7"),
+			fakeNodeWithAddresses("node3", "10.0.1.5"),
+			fakePodWithIPs("app1", false, "100.64.3.4"),
+			fakePodWithIPs("app2", true, "192.168.12.34"),
+			fakeKubeAPIServerPod(),
+		}
+	})
+
+	JustBeforeEach(func(ctx SpecContext) {
+		clusterNet = testDiscoverNetworkSuccess(ctx, append(initObjs, awsNode)...)
+		Expect(clusterNet).NotTo(BeNil())
+		Expect(clusterNet.ServiceCIDRs).To(Equal([]string{testServiceCIDR}))
+	})
+
+	assertDetected := func() {
+		Expect(clusterNet.NetworkPlugin).To(Equal(cni.Generic))
+		Expect(clusterNet.PluginSettings).To(HaveKeyWithValue(network.DetectedPluginSetting, network.AWSVPCCNI))
+		Expect(clusterNet.PluginSettings).To(HaveKey(network.LimitationsSetting))
+	}
+
+	When("custom networking isn't enabled", func() {
+		It("should return the VPC blocks of the node addresses", func() {
+			assertDetected()
+			Expect(clusterNet.PodCIDRs).To(Equal([]string{"10.0.0.0/16", "192.168.0.0/16", "2600:1f14:abc:de00::/56"}))
+			Expect(clusterNet.PluginSettings).To(HaveKeyWithValue(network.CustomNetworkingSetting, "false"))
+			Expect(clusterNet.PluginSettings).To(HaveKeyWithValue(network.PodCIDRSourceSetting, "nodeAddresses"))
+		})
+	})
+
+	When("custom networking is enabled", func() {
+		BeforeEach(func() {
+			awsNode.Spec.Template.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG", Value: "true"}}
+		})
+
+		It("should return the VPC blocks of the pod addresses", func() {
+			assertDetected()
+			Expect(clusterNet.PodCIDRs).To(Equal([]string{"100.64.0.0/16"}))
+			Expect(clusterNet.PluginSettings).To(HaveKeyWithValue(network.CustomNetworkingSetting, "true"))
+			Expect(clusterNet.PluginSettings).To(HaveKeyWithValue(network.PodCIDRSourceSetting, "podAddresses"))
+		})
+	})
+
+	When("the aws-node DaemonSet isn't scheduled on any node and Calico is deployed", func() {
+		BeforeEach(func() {
+			awsNode.Status.DesiredNumberScheduled = 0
+			initObjs = append(initObjs, &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "calico-node",
+					Namespace: "kube-system",
+				},
+			})
+		})
+
+		It("should detect Calico", func() {
+			Expect(clusterNet.NetworkPlugin).To(Equal(cni.Calico))
+			Expect(clusterNet.PluginSettings).ToNot(HaveKey(network.DetectedPluginSetting))
+		})
+	})
+
+	When("ENIConfigs exist", func() {
+		BeforeEach(func() {
+			eniConfig := &unstructured.Unstructured{Object: map[string]interface{}{
+				"spec": map[string]interface{}{"subnet": "subnet-0123456789abcdef0"},
+			}}
+			eniConfig.SetAPIVersion("crd.k8s.amazonaws.com/v1alpha1")
+			eniConfig.SetKind("ENIConfig")
+			eniConfig.SetName("us-west-2a")
+
+			initObjs = append(initObjs, eniConfig)
+		})
+
+		It("should assume custom networking and reference the ENIConfig subnets", func() {
+			assertDetected()
+			Expect(clusterNet.PodCIDRs).To(Equal([]string{"100.64.0.0/16"}))
+			Expect(clusterNet.PluginSettings).To(HaveKeyWithValue(network.CustomNetworkingSetting, "true"))
+			Expect(clusterNet.PluginSettings[network.LimitationsSetting]).To(ContainSubstring("subnet-0123456789abcdef0"))
+		})
+	})
+})
+
+func fakeNodeWithAddresses(name string, addresses ...string) *corev1.Node {
+	node := fakeNode(name, "")
+
+	for _, address := range addresses {
+		node.Status.Addresses = append(node.Status.Addresses, corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: address})
+	}
+
+	node.Status.Addresses = append(node.Status.Addresses, corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: "54.1.2.3"})
+
+	return node
+}
+
+func fakePodWithIPs(name string, hostNetwork bool, ips ...string) *corev1.Pod {
+	pod := fakePodWithName(name, name, nil, nil)
+	pod.Spec.HostNetwork = hostNetwork
+
+	for _, ip := range ips {
+		pod.Status.PodIPs = append(pod.Status.PodIPs, corev1.PodIP{IP: ip})
+	}
+
+	return pod
+}
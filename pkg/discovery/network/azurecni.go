@@ -0,0 +1,125 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/submariner-io/submariner/pkg/cni"
+	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// AzureCNI is the name of the Azure CNI network plugin, recorded in the DetectedPluginSetting.
+const AzureCNI = "azure-cni"
+
+const azureIPMasqAgentConfigPrefix = "azure-ip-masq-agent-config"
+
+// azureCNIPrefixes are the prefixes of the names of the DaemonSets and ConfigMaps deployed with Azure CNI: its installer,
+// its Azure Container Networking Service (CNS) and their configurations.
+var azureCNIPrefixes = []string{"azure-cni", "azure-vnet", "azure-cns"}
+
+type ipMasqAgentConfig struct {
+	NonMasqueradeCIDRs []string `json:"nonMasqueradeCIDRs"`
+	// All the other fields are ignored by Unmarshal
+}
+
+//nolint:nilnil // Intentional as the purpose is to discover.
+func discoverAzureCNINetwork(ctx context.Context, client controllerClient.Client) (*ClusterNetwork, error) {
+	azureDaemonSet, err := findDaemonSet(ctx, client, azureCNIPrefixes...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a DaemonSet (older AKS versions install the CNI on the nodes), the Azure CNI ConfigMaps identify it
+	if azureDaemonSet != nil && !isScheduled(azureDaemonSet) {
+		return nil, nil
+	}
+
+	if azureDaemonSet == nil {
+		configMaps, err := findConfigMaps(ctx, client, azureCNIPrefixes...)
+		if err != nil || len(configMaps) == 0 {
+			return nil, err
+		}
+	}
+
+	clusterNetwork := &ClusterNetwork{
+		NetworkPlugin:  cni.Generic,
+		PluginSettings: map[string]string{DetectedPluginSetting: AzureCNI},
+	}
+
+	// The pod subnets are only known to Azure, but AKS configures its ip-masq-agent to not masquerade the traffic to the
+	// VNet address space (or to the pod CIDR in overlay mode)
+	podCIDRs, err := findAzureNonMasqueradeCIDRs(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(podCIDRs) > 0 {
+		clusterNetwork.PodCIDRs = podCIDRs
+		clusterNetwork.PluginSettings[PodCIDRSourceSetting] = "ipMasqAgentConfig"
+		clusterNetwork.PluginSettings[LimitationsSetting] = "The pod CIDRs are the non-masquerade CIDRs of the AKS" +
+			" ip-masq-agent, they cover the whole VNet address space rather than only the pod subnets"
+	} else {
+		clusterNetwork.PluginSettings[LimitationsSetting] = "The pods use the VNet subnets, which are only known to Azure," +
+			" and no AKS ip-masq-agent configuration was found; the pod CIDRs must be configured explicitly"
+	}
+
+	clusterIPRange, err := findClusterIPRange(ctx, client)
+	if err == nil && clusterIPRange != "" {
+		clusterNetwork.ServiceCIDRs = strings.Split(clusterIPRange, ",")
+	}
+
+	return clusterNetwork, nil
+}
+
+func findAzureNonMasqueradeCIDRs(ctx context.Context, client controllerClient.Client) ([]string, error) {
+	configMaps, err := findConfigMaps(ctx, client, azureIPMasqAgentConfigPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var cidrs []string
+
+	for i := range configMaps {
+		keys := make([]string, 0, len(configMaps[i].Data))
+		for key := range configMaps[i].Data {
+			keys = append(keys, key)
+		}
+
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			config := &ipMasqAgentConfig{}
+			if err := yaml.Unmarshal([]byte(configMaps[i].Data[key]), config); err != nil {
+				continue
+			}
+
+			for _, cidr := range config.NonMasqueradeCIDRs {
+				if !slices.Contains(cidrs, cidr) {
+					cidrs = append(cidrs, cidr)
+				}
+			}
+		}
+	}
+
+	return cidrs, nil
+}
@@ -0,0 +1,118 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/submariner-operator/pkg/discovery/network"
+	"github.com/submariner-io/submariner/pkg/cni"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Azure CNI Network", func() {
+	var (
+		initObjs   []client.Object
+		clusterNet *network.ClusterNetwork
+	)
+
+	BeforeEach(func() {
+		initObjs = []client.Object{
+			&appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "azure-cns",
+					Namespace: "kube-system",
+				},
+				Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 2},
+			},
+			fakeKubeAPIServerPod(),
+		}
+	})
+
+	JustBeforeEach(func(ctx SpecContext) {
+		clusterNet = testDiscoverNetworkSuccess(ctx, initObjs...)
+		Expect(clusterNet).NotTo(BeNil())
+		Expect(clusterNet.NetworkPlugin).To(Equal(cni.Generic))
+		Expect(clusterNet.ServiceCIDRs).To(Equal([]string{testServiceCIDR}))
+	})
+
+	assertDetected := func() {
+		Expect(clusterNet.PluginSettings).To(HaveKeyWithValue(network.DetectedPluginSetting, network.AzureCNI))
+		Expect(clusterNet.PluginSettings).To(HaveKey(network.LimitationsSetting))
+	}
+
+	When("the AKS ip-masq-agent is configured", func() {
+		BeforeEach(func() {
+			initObjs = append(initObjs, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "azure-ip-masq-agent-config-reconciled",
+					Namespace: "kube-system",
+				},
+				Data: map[string]string{
+					"ip-masq-agent-reconciled": "nonMasqueradeCIDRs:\n  - 10.224.0.0/12\nmasqLinkLocal: true\n",
+				},
+			})
+		})
+
+		It("should return the non-masquerade CIDRs", func() {
+			assertDetected()
+			Expect(clusterNet.PodCIDRs).To(Equal([]string{"10.224.0.0/12"}))
+			Expect(clusterNet.PluginSettings).To(HaveKeyWithValue(network.PodCIDRSourceSetting, "ipMasqAgentConfig"))
+		})
+	})
+
+	When("only the Azure CNI ConfigMap exists", func() {
+		BeforeEach(func() {
+			initObjs = []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "azure-vnet-config",
+						Namespace: "kube-system",
+					},
+				},
+				fakeKubeAPIServerPod(),
+				fakeKubeControllerManagerPod(),
+			}
+		})
+
+		It("should fall back to the generic discovery for the pod CIDRs", func() {
+			assertDetected()
+			Expect(clusterNet.PodCIDRs).To(Equal([]string{testPodCIDR}))
+			Expect(clusterNet.PluginSettings).ToNot(HaveKey(network.PodCIDRSourceSetting))
+		})
+	})
+
+	When("Cilium is deployed with Azure CNI", func() {
+		BeforeEach(func() {
+			initObjs = append(initObjs, &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cilium",
+					Namespace: "kube-system",
+				},
+			})
+		})
+
+		It("should detect Cilium", func() {
+			Expect(clusterNet.PluginSettings).To(HaveKeyWithValue(network.DetectedPluginSetting, network.Cilium))
+		})
+	})
+})
@@ -0,0 +1,190 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner/pkg/cni"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Cilium is the name of the Cilium network plugin, recorded in the DetectedPluginSetting.
+	Cilium = "cilium"
+
+	// KubeProxyReplacementSetting is the PluginSettings key recording whether the network plugin replaces kube-proxy:
+	// "true", "false" or, for older Cilium versions, "partial".
+	KubeProxyReplacementSetting = "kubeProxyReplacement"
+
+	ciliumName       = "cilium"
+	ciliumConfigName = "cilium-config"
+)
+
+var (
+	ciliumNodeGVK      = schema.GroupVersionKind{Group: "cilium.io", Version: "v2", Kind: "CiliumNodeList"}
+	ciliumPodIPPoolGVK = schema.GroupVersionKind{Group: "cilium.io", Version: "v2alpha1", Kind: "CiliumPodIPPoolList"}
+)
+
+//nolint:nilnil // Intentional as the purpose is to discover.
+func discoverCiliumNetwork(ctx context.Context, client controllerClient.Client) (*ClusterNetwork, error) {
+	namespace, found, err := findCiliumNamespace(ctx, client)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	clusterNetwork := &ClusterNetwork{
+		NetworkPlugin:  cni.Generic,
+		PluginSettings: map[string]string{DetectedPluginSetting: Cilium, KubeProxyReplacementSetting: "false"},
+	}
+
+	ciliumConfig := &corev1.ConfigMap{}
+
+	err = client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ciliumConfigName}, ciliumConfig)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, errors.Wrap(err, "error retrieving the Cilium ConfigMap")
+	}
+
+	for _, key := range []string{"cluster-pool-ipv4-cidr", "cluster-pool-ipv6-cidr"} {
+		clusterNetwork.PodCIDRs = append(clusterNetwork.PodCIDRs, strings.Fields(ciliumConfig.Data[key])...)
+	}
+
+	if mode := kubeProxyReplacementMode(ciliumConfig.Data["kube-proxy-replacement"]); mode != "" {
+		clusterNetwork.PluginSettings[KubeProxyReplacementSetting] = mode
+	}
+
+	if len(clusterNetwork.PodCIDRs) == 0 {
+		clusterNetwork.PodCIDRs, err = findCiliumPodIPPoolCIDRs(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(clusterNetwork.PodCIDRs) == 0 {
+		clusterNetwork.PodCIDRs, err = findCiliumNodeCIDRs(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(clusterNetwork.PodCIDRs) == 0 {
+		podIPRange, err := findPodIPRange(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+
+		if podIPRange != "" {
+			clusterNetwork.PodCIDRs = strings.Split(podIPRange, ",")
+		}
+	}
+
+	clusterIPRange, err := findClusterIPRange(ctx, client)
+	if err == nil && clusterIPRange != "" {
+		clusterNetwork.ServiceCIDRs = strings.Split(clusterIPRange, ",")
+	}
+
+	return clusterNetwork, nil
+}
+
+// findCiliumNamespace returns the namespace of the cilium DaemonSet or, failing that, of the cilium-config ConfigMap.
+func findCiliumNamespace(ctx context.Context, client controllerClient.Client) (string, bool, error) {
+	dsList := &appsv1.DaemonSetList{}
+
+	err := client.List(ctx, dsList)
+	if err != nil {
+		return "", false, errors.Wrap(err, "error listing DaemonSets")
+	}
+
+	for i := range dsList.Items {
+		if dsList.Items[i].Name == ciliumName {
+			return dsList.Items[i].Namespace, true, nil
+		}
+	}
+
+	cmList := &corev1.ConfigMapList{}
+
+	err = client.List(ctx, cmList)
+	if err != nil {
+		return "", false, errors.Wrap(err, "error listing ConfigMaps")
+	}
+
+	for i := range cmList.Items {
+		if cmList.Items[i].Name == ciliumConfigName {
+			return cmList.Items[i].Namespace, true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+// kubeProxyReplacementMode normalizes the kube-proxy-replacement setting: recent Cilium versions use "true" and "false",
+// older ones "strict", "partial", "probe" and "disabled". In probe mode, the kube-proxy replacement features supported
+// by the kernel are enabled, alongside kube-proxy, as in partial mode.
+func kubeProxyReplacementMode(value string) string {
+	switch strings.ToLower(value) {
+	case "true", "strict":
+		return "true"
+	case "partial", "probe":
+		return "partial"
+	case "false", "disabled":
+		return "false"
+	}
+
+	return ""
+}
+
+// findCiliumPodIPPoolCIDRs returns the CIDRs of the CiliumPodIPPools, used in multi-pool IPAM mode.
+func findCiliumPodIPPoolCIDRs(ctx context.Context, client controllerClient.Client) ([]string, error) {
+	pools, err := listResources(ctx, client, ciliumPodIPPoolGVK)
+	if err != nil {
+		return nil, err
+	}
+
+	var cidrs []string
+
+	for i := range pools {
+		for _, family := range []string{"ipv4", "ipv6"} {
+			familyCIDRs, _, _ := unstructured.NestedStringSlice(pools[i].Object, "spec", family, "cidrs")
+			cidrs = append(cidrs, familyCIDRs...)
+		}
+	}
+
+	return cidrs, nil
+}
+
+// findCiliumNodeCIDRs returns the pod CIDRs of the CiliumNode if the cluster has a single node. As with the node
+// PodCIDR, the CIDRs of a node don't cover the pods of the other nodes.
+func findCiliumNodeCIDRs(ctx context.Context, client controllerClient.Client) ([]string, error) {
+	nodes, err := listResources(ctx, client, ciliumNodeGVK)
+	if err != nil || len(nodes) != 1 {
+		return nil, err
+	}
+
+	cidrs, _, _ := unstructured.NestedStringSlice(nodes[0].Object, "spec", "ipam", "podCIDRs")
+
+	return cidrs, nil
+}
@@ -0,0 +1,161 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/submariner-operator/pkg/discovery/network"
+	"github.com/submariner-io/submariner/pkg/cni"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Cilium Network", func() {
+	var (
+		initObjs     []client.Object
+		ciliumConfig *corev1.ConfigMap
+		clusterNet   *network.ClusterNetwork
+	)
+
+	BeforeEach(func() {
+		ciliumConfig = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cilium-config",
+				Namespace: "kube-system",
+			},
+			Data: map[string]string{
+				"cluster-pool-ipv4-cidr": testPodCIDR,
+			},
+		}
+
+		initObjs = []client.Object{
+			&appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cilium",
+					Namespace: "kube-system",
+				},
+			},
+			fakeKubeAPIServerPod(),
+		}
+	})
+
+	JustBeforeEach(func(ctx SpecContext) {
+		clusterNet = testDiscoverNetworkSuccess(ctx, append(initObjs, ciliumConfig)...)
+		Expect(clusterNet).NotTo(BeNil())
+		Expect(clusterNet.NetworkPlugin).To(Equal(cni.Generic))
+		Expect(clusterNet.PluginSettings).To(HaveKeyWithValue(network.DetectedPluginSetting, network.Cilium))
+		Expect(clusterNet.ServiceCIDRs).To(Equal([]string{testServiceCIDR}))
+	})
+
+	When("the cluster pool CIDRs are configured", func() {
+		BeforeEach(func() {
+			ciliumConfig.Data["cluster-pool-ipv6-cidr"] = "fd00::/104"
+		})
+
+		It("should return the pod CIDRs of both families", func() {
+			Expect(clusterNet.PodCIDRs).To(Equal([]string{testPodCIDR, "fd00::/104"}))
+		})
+	})
+
+	When("only the cilium-config ConfigMap exists", func() {
+		BeforeEach(func() {
+			initObjs = initObjs[1:]
+		})
+
+		It("should return the pod CIDRs from the ConfigMap", func() {
+			Expect(clusterNet.PodCIDRs).To(Equal([]string{testPodCIDR}))
+		})
+	})
+
+	When("CiliumPodIPPools are defined", func() {
+		BeforeEach(func() {
+			delete(ciliumConfig.Data, "cluster-pool-ipv4-cidr")
+
+			initObjs = append(initObjs, newCiliumResource("v2alpha1", "CiliumPodIPPool", "default", map[string]interface{}{
+				"ipv4": map[string]interface{}{"cidrs": []interface{}{"10.10.0.0/16", "10.20.0.0/16"}},
+			}))
+		})
+
+		It("should return the CIDRs of the pools", func() {
+			Expect(clusterNet.PodCIDRs).To(Equal([]string{"10.10.0.0/16", "10.20.0.0/16"}))
+		})
+	})
+
+	When("the single CiliumNode has pod CIDRs", func() {
+		BeforeEach(func() {
+			delete(ciliumConfig.Data, "cluster-pool-ipv4-cidr")
+
+			initObjs = append(initObjs, newCiliumResource("v2", "CiliumNode", "node1", map[string]interface{}{
+				"ipam": map[string]interface{}{"podCIDRs": []interface{}{"10.0.1.0/24"}},
+			}))
+		})
+
+		It("should return the CIDRs of the node", func() {
+			Expect(clusterNet.PodCIDRs).To(Equal([]string{"10.0.1.0/24"}))
+		})
+	})
+
+	When("no CIDRs are configured and there are several CiliumNodes", func() {
+		BeforeEach(func() {
+			delete(ciliumConfig.Data, "cluster-pool-ipv4-cidr")
+
+			initObjs = append(initObjs, fakeKubeControllerManagerPod(),
+				newCiliumResource("v2", "CiliumNode", "node1", map[string]interface{}{
+					"ipam": map[string]interface{}{"podCIDRs": []interface{}{"10.0.1.0/24"}},
+				}),
+				newCiliumResource("v2", "CiliumNode", "node2", map[string]interface{}{
+					"ipam": map[string]interface{}{"podCIDRs": []interface{}{"10.0.2.0/24"}},
+				}))
+		})
+
+		It("should return the cluster CIDR from the Kubernetes components", func() {
+			Expect(clusterNet.PodCIDRs).To(Equal([]string{testPodCIDR}))
+		})
+	})
+
+	DescribeTable("kube-proxy replacement detection", func(ctx SpecContext, value, expected string) {
+		if value != "" {
+			ciliumConfig.Data["kube-proxy-replacement"] = value
+		}
+
+		clusterNet := testDiscoverNetworkSuccess(ctx, append(initObjs, ciliumConfig)...)
+		Expect(clusterNet.PluginSettings).To(HaveKeyWithValue(network.KubeProxyReplacementSetting, expected))
+	},
+		Entry("not configured", "", "false"),
+		Entry("true", "true", "true"),
+		Entry("strict", "strict", "true"),
+		Entry("partial", "partial", "partial"),
+		Entry("probe", "probe", "partial"),
+		Entry("false", "false", "false"),
+		Entry("disabled", "disabled", "false"),
+	)
+})
+
+func newCiliumResource(version, kind, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetAPIVersion("cilium.io/" + version)
+	obj.SetKind(kind)
+	obj.SetName(name)
+
+	return obj
+}
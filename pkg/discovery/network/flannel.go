@@ -122,7 +122,7 @@ func extractCIDRsFromFlannelConfigMap(ctx context.Context, client controllerClie
 	}
 
 	if clusterIPRange != "" {
-		clusterNetwork.ServiceCIDRs = []string{clusterIPRange}
+		clusterNetwork.ServiceCIDRs = strings.Split(clusterIPRange, ",")
 	}
 
 	return clusterNetwork, nil
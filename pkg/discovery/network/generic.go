@@ -23,15 +23,30 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/submariner-io/submariner/pkg/cni"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const defaultServiceCIDRName = "kubernetes"
+
+// serviceCIDRGVKs are the versions of the ServiceCIDR API, most recent first.
+var serviceCIDRGVKs = []schema.GroupVersionKind{
+	{Group: "networking.k8s.io", Version: "v1", Kind: "ServiceCIDRList"},
+	{Group: "networking.k8s.io", Version: "v1beta1", Kind: "ServiceCIDRList"},
+}
+
 //nolint:nilnil // Intentional as the purpose is to discover.
 func discoverGenericNetwork(ctx context.Context, client controllerClient.Client) (*ClusterNetwork, error) {
 	clusterNetwork, err := discoverNetwork(ctx, client)
@@ -57,7 +72,7 @@ func discoverNetwork(ctx context.Context, client controllerClient.Client) (*Clus
 	}
 
 	if podIPRange != "" {
-		clusterNetwork.PodCIDRs = []string{podIPRange}
+		clusterNetwork.PodCIDRs = strings.Split(podIPRange, ",")
 	}
 
 	clusterIPRange, err := findClusterIPRange(ctx, client)
@@ -66,7 +81,7 @@ func discoverNetwork(ctx context.Context, client controllerClient.Client) (*Clus
 	}
 
 	if clusterIPRange != "" {
-		clusterNetwork.ServiceCIDRs = []string{clusterIPRange}
+		clusterNetwork.ServiceCIDRs = strings.Split(clusterIPRange, ",")
 	}
 
 	if len(clusterNetwork.PodCIDRs) > 0 || len(clusterNetwork.ServiceCIDRs) > 0 {
@@ -77,6 +92,10 @@ func discoverNetwork(ctx context.Context, client controllerClient.Client) (*Clus
 }
 
 func findClusterIPRange(ctx context.Context, client controllerClient.Client) (string, error) {
+	if clusterIPRange := findClusterIPRangeFromServiceCIDRs(ctx, client); clusterIPRange != "" {
+		return clusterIPRange, nil
+	}
+
 	clusterIPRange, err := findClusterIPRangeFromApiserver(ctx, client)
 	if err != nil || clusterIPRange != "" {
 		return clusterIPRange, err
@@ -95,6 +114,55 @@ func findClusterIPRange(ctx context.Context, client controllerClient.Client) (st
 	return "", nil
 }
 
+// findClusterIPRangeFromServiceCIDRs returns the ranges of the ServiceCIDR resources, the default one (configured from the
+// apiserver flags) first, or an empty string if the API isn't available. Each API version is tried in turn; as the ranges
+// can be discovered otherwise, errors listing the resources are only logged.
+func findClusterIPRangeFromServiceCIDRs(ctx context.Context, client controllerClient.Client) string {
+	for _, gvk := range serviceCIDRGVKs {
+		serviceCIDRs, err := listResources(ctx, client, gvk)
+		if err != nil {
+			if !apierrors.IsForbidden(err) {
+				log.Error(err, "Error listing the ServiceCIDRs", "version", gvk.Version)
+			}
+
+			continue
+		}
+
+		slices.SortStableFunc(serviceCIDRs, func(a, b unstructured.Unstructured) int {
+			return serviceCIDRRank(&a) - serviceCIDRRank(&b)
+		})
+
+		var cidrs []string
+
+		for i := range serviceCIDRs {
+			if serviceCIDRs[i].GetDeletionTimestamp() != nil {
+				continue
+			}
+
+			specCIDRs, _, _ := unstructured.NestedStringSlice(serviceCIDRs[i].Object, "spec", "cidrs")
+			for _, cidr := range specCIDRs {
+				if !slices.Contains(cidrs, cidr) {
+					cidrs = append(cidrs, cidr)
+				}
+			}
+		}
+
+		if len(cidrs) > 0 {
+			return strings.Join(cidrs, ",")
+		}
+	}
+
+	return ""
+}
+
+func serviceCIDRRank(serviceCIDR *unstructured.Unstructured) int {
+	if serviceCIDR.GetName() == defaultServiceCIDRName {
+		return 0
+	}
+
+	return 1
+}
+
 func findClusterIPRangeFromApiserver(ctx context.Context, client controllerClient.Client) (string, error) {
 	return FindPodCommandParameter(ctx, client, "component=kube-apiserver", "--service-cluster-ip-range")
 }
@@ -209,3 +277,82 @@ func parseToPodCidr(nodes []corev1.Node) (string, error) {
 
 	return "", nil
 }
+
+// listResources lists the resources of the given kind, returning none if the kind isn't installed.
+func listResources(ctx context.Context, client controllerClient.Client, gvk schema.GroupVersionKind,
+) ([]unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk)
+
+	err := client.List(ctx, list)
+	if meta.IsNoMatchError(err) || apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "error listing the %s resources", strings.TrimSuffix(gvk.Kind, "List"))
+	}
+
+	return list.Items, nil
+}
+
+// findDaemonSet returns a DaemonSet whose name has one of the given prefixes, preferring those scheduled on nodes.
+//
+//nolint:nilnil // Intentional as the purpose is to find.
+func findDaemonSet(ctx context.Context, client controllerClient.Client, namePrefixes ...string) (*appsv1.DaemonSet, error) {
+	dsList := &appsv1.DaemonSetList{}
+
+	err := client.List(ctx, dsList)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing DaemonSets")
+	}
+
+	var found *appsv1.DaemonSet
+
+	for i := range dsList.Items {
+		if !hasAnyPrefix(dsList.Items[i].Name, namePrefixes) {
+			continue
+		}
+
+		if isScheduled(&dsList.Items[i]) {
+			return &dsList.Items[i], nil
+		}
+
+		if found == nil {
+			found = &dsList.Items[i]
+		}
+	}
+
+	return found, nil
+}
+
+// isScheduled returns whether the given DaemonSet runs on any node; replaced network plugins are often left installed
+// with a node selector matching no node.
+func isScheduled(daemonSet *appsv1.DaemonSet) bool {
+	return daemonSet.Status.DesiredNumberScheduled > 0
+}
+
+func findConfigMaps(ctx context.Context, client controllerClient.Client, namePrefixes ...string) ([]corev1.ConfigMap, error) {
+	cmList := &corev1.ConfigMapList{}
+
+	err := client.List(ctx, cmList)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing ConfigMaps")
+	}
+
+	var configMaps []corev1.ConfigMap
+
+	for i := range cmList.Items {
+		if hasAnyPrefix(cmList.Items[i].Name, namePrefixes) {
+			configMaps = append(configMaps, cmList.Items[i])
+		}
+	}
+
+	return configMaps, nil
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	return slices.ContainsFunc(prefixes, func(prefix string) bool {
+		return strings.HasPrefix(s, prefix)
+	})
+}
@@ -20,6 +20,7 @@ package network_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -30,7 +31,12 @@ import (
 	"github.com/submariner-io/submariner-operator/pkg/names"
 	"github.com/submariner-io/submariner/pkg/cni"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/scheme"
 	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
 	fakeClient "sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -150,6 +156,25 @@ var _ = Describe("Generic Network", func() {
 		})
 	})
 
+	When("There is a kube-controller pod with dual-stack parameters", func() {
+		BeforeEach(func(ctx SpecContext) {
+			clusterNet = testDiscoverGenericWith(
+				ctx,
+				fakePodWithArg("kube-controller-manager", []string{"kube-controller-manager"},
+					[]string{
+						"--cluster-cidr=" + testPodCIDR + ",fd00:10:244::/56",
+						"--service-cluster-ip-range=" + testServiceCIDR + ",fd00:10:96::/112",
+					}),
+			)
+			Expect(clusterNet).NotTo(BeNil())
+		})
+
+		It("Should return the ClusterNetwork structure with all the CIDRs", func() {
+			Expect(clusterNet.PodCIDRs).To(Equal([]string{testPodCIDR, "fd00:10:244::/56"}))
+			Expect(clusterNet.ServiceCIDRs).To(Equal([]string{testServiceCIDR, "fd00:10:96::/112"}))
+		})
+	})
+
 	When("There is a kube-proxy pod but no kube-controller", func() {
 		BeforeEach(func(ctx SpecContext) {
 			clusterNet = testDiscoverGenericWith(
@@ -329,6 +354,106 @@ var _ = Describe("Generic Network", func() {
 		})
 	})
 
+	When("ServiceCIDR resources exist", func() {
+		BeforeEach(func(ctx SpecContext) {
+			clusterNet = testDiscoverGenericWith(
+				ctx,
+				fakeKubeAPIServerPod(),
+				fakeServiceCIDR("extra", "10.200.0.0/16"),
+				fakeServiceCIDR("kubernetes", "10.96.0.0/16", "fd00:10:96::/112"),
+			)
+			Expect(clusterNet).NotTo(BeNil())
+		})
+
+		It("should return all their ranges, the default ones first, over the apiserver ones", func() {
+			Expect(clusterNet.ServiceCIDRs).To(Equal([]string{"10.96.0.0/16", "fd00:10:96::/112", "10.200.0.0/16"}))
+		})
+	})
+
+	When("listing the ServiceCIDR resources fails", func() {
+		BeforeEach(func(ctx SpecContext) {
+			client := fake.NewReactingClient(newTestClient(fakeKubeAPIServerPod(), fakeServiceCIDR("kubernetes", "10.96.0.0/16"))).
+				AddReactor(fake.List, &unstructured.UnstructuredList{}, func(obj interface{}) (bool, error) {
+					if obj.(*unstructured.UnstructuredList).GetKind() == "ServiceCIDRList" {
+						return true, errors.New("mock error")
+					}
+
+					return false, nil
+				})
+
+			var err error
+
+			clusterNet, err = network.Discover(ctx, client, "")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should fall back to the apiserver service CIDR", func() {
+			Expect(clusterNet.ServiceCIDRs).To(Equal([]string{testServiceCIDR}))
+		})
+	})
+
+	When("v1 ServiceCIDR resources exist", func() {
+		BeforeEach(func(ctx SpecContext) {
+			serviceCIDR := &unstructured.Unstructured{Object: map[string]interface{}{
+				"spec": map[string]interface{}{"cidrs": []interface{}{"10.96.0.0/16"}},
+			}}
+			serviceCIDR.SetAPIVersion("networking.k8s.io/v1")
+			serviceCIDR.SetKind("ServiceCIDR")
+			serviceCIDR.SetName("kubernetes")
+
+			clusterNet = testDiscoverGenericWith(ctx, serviceCIDR, fakeServiceCIDR("kubernetes", "10.97.0.0/16"))
+			Expect(clusterNet).NotTo(BeNil())
+		})
+
+		It("should prefer them to the v1beta1 ones", func() {
+			Expect(clusterNet.ServiceCIDRs).To(Equal([]string{"10.96.0.0/16"}))
+		})
+	})
+
+	When("only v1beta1 ServiceCIDR resources are available", func() {
+		var v1ListErr error
+
+		JustBeforeEach(func(ctx SpecContext) {
+			client := fake.NewReactingClient(newTestClient(fakeKubeAPIServerPod(), fakeServiceCIDR("kubernetes", "10.96.0.0/16"))).
+				AddReactor(fake.List, &unstructured.UnstructuredList{}, func(obj interface{}) (bool, error) {
+					list := obj.(*unstructured.UnstructuredList)
+					if list.GetKind() == "ServiceCIDRList" && list.GroupVersionKind().Version == "v1" {
+						return true, v1ListErr
+					}
+
+					return false, nil
+				})
+
+			var err error
+
+			clusterNet, err = network.Discover(ctx, client, "")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("and the v1 version isn't known", func() {
+			BeforeEach(func() {
+				v1ListErr = &meta.NoKindMatchError{
+					GroupKind:        schema.GroupKind{Group: "networking.k8s.io", Kind: "ServiceCIDRList"},
+					SearchedVersions: []string{"v1"},
+				}
+			})
+
+			It("should return the v1beta1 ranges", func() {
+				Expect(clusterNet.ServiceCIDRs).To(Equal([]string{"10.96.0.0/16"}))
+			})
+		})
+
+		Context("and listing the v1 version fails", func() {
+			BeforeEach(func() {
+				v1ListErr = apierrors.NewServiceUnavailable("v1 isn't served")
+			})
+
+			It("should return the v1beta1 ranges", func() {
+				Expect(clusterNet.ServiceCIDRs).To(Equal([]string{"10.96.0.0/16"}))
+			})
+		})
+	})
+
 	When("the Submariner resource exists", func() {
 		const globalCIDR = "242.112.0.0/24"
 		const clustersetIPCIDR = "243.110.0.0/20"
@@ -357,6 +482,17 @@ var _ = Describe("Generic Network", func() {
 	})
 })
 
+func fakeServiceCIDR(name string, cidrs ...string) *networkingv1beta1.ServiceCIDR {
+	return &networkingv1beta1.ServiceCIDR{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: networkingv1beta1.ServiceCIDRSpec{
+			CIDRs: cidrs,
+		},
+	}
+}
+
 func testDiscoverGenericWith(ctx context.Context, objects ...controllerClient.Object) *network.ClusterNetwork {
 	client := newTestClient(objects...)
 	clusterNet, err := network.Discover(ctx, client, "")
@@ -0,0 +1,69 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"context"
+	"strings"
+
+	"github.com/submariner-io/submariner/pkg/cni"
+	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// KubeRouter is the name of the kube-router network plugin, recorded in the DetectedPluginSetting.
+const KubeRouter = "kube-router"
+
+const kubeRouterSelector = "k8s-app=kube-router"
+
+func discoverKubeRouterNetwork(ctx context.Context, client controllerClient.Client) (*ClusterNetwork, error) {
+	kubeRouterPod, err := FindPod(ctx, client, kubeRouterSelector)
+	if err != nil || kubeRouterPod == nil {
+		return nil, err
+	}
+
+	clusterNetwork := &ClusterNetwork{
+		NetworkPlugin:  cni.Generic,
+		PluginSettings: map[string]string{DetectedPluginSetting: KubeRouter},
+	}
+
+	// Without --cluster-cidr, kube-router relies on the node pod CIDRs allocated by the kube-controller-manager
+	podIPRange, err := FindPodCommandParameter(ctx, client, kubeRouterSelector, "--cluster-cidr")
+	if err == nil && podIPRange == "" {
+		podIPRange, err = findPodIPRange(ctx, client)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if podIPRange != "" {
+		clusterNetwork.PodCIDRs = strings.Split(podIPRange, ",")
+	}
+
+	clusterIPRange, err := FindPodCommandParameter(ctx, client, kubeRouterSelector, "--service-cluster-ip-range")
+	if err == nil && clusterIPRange == "" {
+		clusterIPRange, err = findClusterIPRange(ctx, client)
+	}
+
+	if err == nil && clusterIPRange != "" {
+		clusterNetwork.ServiceCIDRs = strings.Split(clusterIPRange, ",")
+	}
+
+	return clusterNetwork, nil
+}
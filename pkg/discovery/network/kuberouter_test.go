@@ -0,0 +1,57 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/submariner-operator/pkg/discovery/network"
+	"github.com/submariner-io/submariner/pkg/cni"
+)
+
+var _ = Describe("kube-router Network", func() {
+	When("the CIDRs are passed as arguments", func() {
+		It("should return them", func(ctx SpecContext) {
+			clusterNet := testDiscoverNetworkSuccess(ctx, fakePodWithArg("kube-router", nil, []string{
+				"--run-router=true",
+				"--cluster-cidr=" + testPodCIDR + ",fd00:10:244::/56",
+				"--service-cluster-ip-range=" + testServiceCIDR,
+			}))
+
+			Expect(clusterNet).NotTo(BeNil())
+			Expect(clusterNet.NetworkPlugin).To(Equal(cni.Generic))
+			Expect(clusterNet.PluginSettings).To(HaveKeyWithValue(network.DetectedPluginSetting, network.KubeRouter))
+			Expect(clusterNet.PodCIDRs).To(Equal([]string{testPodCIDR, "fd00:10:244::/56"}))
+			Expect(clusterNet.ServiceCIDRs).To(Equal([]string{testServiceCIDR}))
+		})
+	})
+
+	When("the CIDRs aren't passed as arguments", func() {
+		It("should return the CIDRs of the kube-controller-manager", func(ctx SpecContext) {
+			clusterNet := testDiscoverNetworkSuccess(ctx, fakePodWithArg("kube-router", []string{"/usr/local/bin/kube-router"},
+				[]string{"--run-router=true"}), fakeKubeControllerManagerPod())
+
+			Expect(clusterNet).NotTo(BeNil())
+			Expect(clusterNet.NetworkPlugin).To(Equal(cni.Generic))
+			Expect(clusterNet.PluginSettings).To(HaveKeyWithValue(network.DetectedPluginSetting, network.KubeRouter))
+			Expect(clusterNet.PodCIDRs).To(Equal([]string{testPodCIDR}))
+			Expect(clusterNet.ServiceCIDRs).To(Equal([]string{testServiceCIDR}))
+		})
+	})
+})
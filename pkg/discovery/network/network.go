@@ -28,6 +28,23 @@ import (
 	"github.com/submariner-io/submariner-operator/pkg/names"
 	"k8s.io/apimachinery/pkg/types"
 	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("network-discovery")
+
+// DetectedPluginSetting is the PluginSettings key recording the network plugin detected when the components have no
+// specific support for it: the NetworkPlugin is then generic, as it's passed to the components which only handle the
+// plugins known to submariner (cni.GetNetworkPlugins).
+const DetectedPluginSetting = "detectedPlugin"
+
+// PluginSettings keys set by the discoverers of the network plugins whose pod CIDRs are managed outside the cluster (by
+// the cloud provider), and which can only be approximated.
+const (
+	// PodCIDRSourceSetting records what the pod CIDRs were derived from.
+	PodCIDRSourceSetting = "podCIDRSource"
+	// LimitationsSetting describes how the discovered pod CIDRs may differ from the actual ones.
+	LimitationsSetting = "limitations"
 )
 
 type ClusterNetwork struct {
@@ -44,6 +61,11 @@ func (cn *ClusterNetwork) Show() {
 		fmt.Println("    No network details discovered")
 	} else {
 		fmt.Printf("        Network plugin:  %s\n", cn.NetworkPlugin)
+
+		if detected := cn.PluginSettings[DetectedPluginSetting]; detected != "" {
+			fmt.Printf("        Detected plugin: %s\n", detected)
+		}
+
 		fmt.Printf("        Service CIDRs:   %v\n", cn.ServiceCIDRs)
 		fmt.Printf("        Cluster CIDRs:   %v\n", cn.PodCIDRs)
 
@@ -60,6 +82,7 @@ func (cn *ClusterNetwork) Show() {
 func (cn *ClusterNetwork) Log(logger logr.Logger) {
 	logger.Info("Discovered K8s network details",
 		"plugin", cn.NetworkPlugin,
+		"detectedPlugin", cn.PluginSettings[DetectedPluginSetting],
 		"clusterCIDRs", cn.PodCIDRs,
 		"serviceCIDRs", cn.ServiceCIDRs)
 }
@@ -103,12 +126,19 @@ func Discover(ctx context.Context, client controllerClient.Client, operatorNames
 
 type pluginDiscoveryFn func(context.Context, controllerClient.Client) (*ClusterNetwork, error)
 
+// discoverFunctions are tried in order. The cloud provider plugins come after Cilium, which replaces them (as a CNI or
+// chained) on EKS and AKS, but before Calico, which often only enforces the network policies alongside them.
 var discoverFunctions = []pluginDiscoveryFn{
 	discoverOpenShift4Network,
 	discoverOvnKubernetesNetwork,
 	discoverWeaveNetwork,
 	discoverCanalFlannelNetwork,
+	discoverCiliumNetwork,
+	discoverAWSVPCCNINetwork,
+	discoverAzureCNINetwork,
 	discoverCalicoNetwork,
+	discoverAntreaNetwork,
+	discoverKubeRouterNetwork,
 	discoverFlannelNetwork,
 	discoverKindNetwork,
 }
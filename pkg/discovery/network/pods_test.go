@@ -74,6 +74,7 @@ const (
 	testParameter1 = "--parameter1"
 	testValue1     = "value1"
 	testComponent3 = "test-component3"
+	testComponent4 = "test-component4"
 )
 
 var _ = Describe("findPodCommandParameter", func() {
@@ -86,6 +87,7 @@ var _ = Describe("findPodCommandParameter", func() {
 				[]string{"component1", testParameter1 + "=" + testValue1}, nil),
 			fakePodWithName(testThirdPod, testComponent3,
 				[]string{"sh", "-c", "component1 " + testParameter1 + "=" + testValue1}, nil),
+			fakePodWithArg(testComponent4, nil, []string{testParameter1 + "=" + testValue1}),
 		).Build()
 	})
 
@@ -120,6 +122,14 @@ var _ = Describe("findPodCommandParameter", func() {
 			Expect(param).To(Equal(testValue1))
 		})
 	})
+
+	When("A pod is found, and the parameter is an argument without a command", func() {
+		It("Should return the parameter value", func(ctx SpecContext) {
+			param, err := network.FindPodCommandParameter(ctx, client, componentLabel(testComponent4), testParameter1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(param).To(Equal(testValue1))
+		})
+	})
 })
 
 func componentLabel(component string) string {
@@ -20,6 +20,7 @@ package network
 
 import (
 	"context"
+	"strings"
 
 	"github.com/submariner-io/submariner/pkg/cni"
 	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -47,7 +48,7 @@ func discoverWeaveNetwork(ctx context.Context, client controllerClient.Client) (
 
 	clusterIPRange, err := findClusterIPRange(ctx, client)
 	if err == nil && clusterIPRange != "" {
-		clusterNetwork.ServiceCIDRs = []string{clusterIPRange}
+		clusterNetwork.ServiceCIDRs = strings.Split(clusterIPRange, ",")
 	}
 
 	return clusterNetwork, nil
@@ -52,6 +52,55 @@ func GetImagePath(repo, version, image, component string, imageOverrides map[str
 	return logIfChanged(repo, version, image, component, path, "Calculated path")
 }
 
+// PinDigest replaces the tag (or digest) of the given image path with the given digest, if any.
+func PinDigest(path, digest string) string {
+	if digest == "" {
+		return path
+	}
+
+	return trimTagAndDigest(path) + "@" + digest
+}
+
+// ApplyRegistryMirrors rewrites the given image path using the mirror whose source is the longest prefix of the path,
+// matching whole path components only; the path is returned unchanged if no mirror matches.
+func ApplyRegistryMirrors(path string, mirrors []apis.RegistryMirror) string {
+	var match *apis.RegistryMirror
+
+	for i := range mirrors {
+		source := strings.TrimSuffix(mirrors[i].Source, "/")
+		if source == "" || !strings.HasPrefix(path, source) {
+			continue
+		}
+
+		if rest := path[len(source):]; rest != "" && !strings.ContainsAny(rest[:1], "/:@") {
+			continue
+		}
+
+		if match == nil || len(source) > len(strings.TrimSuffix(match.Source, "/")) {
+			match = &mirrors[i]
+		}
+	}
+
+	if match == nil {
+		return path
+	}
+
+	return strings.TrimSuffix(match.Mirror, "/") + path[len(strings.TrimSuffix(match.Source, "/")):]
+}
+
+func trimTagAndDigest(path string) string {
+	if i := strings.Index(path, "@"); i != -1 {
+		path = path[:i]
+	}
+
+	// The tag separator is the last colon after the last slash, a colon before it separates the registry port
+	if i := strings.LastIndex(path, ":"); i > strings.LastIndex(path, "/") {
+		path = path[:i]
+	}
+
+	return path
+}
+
 type imageParameters struct {
 	repo      string
 	version   string
@@ -0,0 +1,78 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package images_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apis "github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/images"
+)
+
+const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+var _ = Describe("PinDigest", func() {
+	It("should replace the tag with the digest", func() {
+		Expect(images.PinDigest("quay.io/submariner/submariner-gateway:0.20.0", digest)).To(
+			Equal("quay.io/submariner/submariner-gateway@" + digest))
+	})
+
+	It("should keep the registry port", func() {
+		Expect(images.PinDigest("localhost:5000/submariner-gateway:local", digest)).To(
+			Equal("localhost:5000/submariner-gateway@" + digest))
+		Expect(images.PinDigest("localhost:5000/submariner-gateway", digest)).To(
+			Equal("localhost:5000/submariner-gateway@" + digest))
+	})
+
+	It("should replace an existing digest", func() {
+		Expect(images.PinDigest("quay.io/submariner/submariner-gateway:0.20.0@sha256:abc", digest)).To(
+			Equal("quay.io/submariner/submariner-gateway@" + digest))
+	})
+
+	It("should leave the path unchanged without a digest", func() {
+		Expect(images.PinDigest("quay.io/submariner/submariner-gateway:0.20.0", "")).To(
+			Equal("quay.io/submariner/submariner-gateway:0.20.0"))
+	})
+})
+
+var _ = Describe("ApplyRegistryMirrors", func() {
+	mirrors := []apis.RegistryMirror{
+		{Source: "quay.io", Mirror: "registry.corp/quay"},
+		{Source: "quay.io/submariner", Mirror: "registry.corp/mirror/"},
+		{Source: "quay.io/submariner/submariner-gateway", Mirror: "registry.corp/gateway"},
+	}
+
+	It("should use the longest matching source", func() {
+		Expect(images.ApplyRegistryMirrors("quay.io/submariner/submariner-route-agent:0.20.0", mirrors)).To(
+			Equal("registry.corp/mirror/submariner-route-agent:0.20.0"))
+		Expect(images.ApplyRegistryMirrors("quay.io/submariner/submariner-gateway@"+digest, mirrors)).To(
+			Equal("registry.corp/gateway@" + digest))
+		Expect(images.ApplyRegistryMirrors("quay.io/other/image:1", mirrors)).To(Equal("registry.corp/quay/other/image:1"))
+	})
+
+	It("should only match whole path components", func() {
+		Expect(images.ApplyRegistryMirrors("quay.io/submariner-other/image:1", mirrors[1:])).To(
+			Equal("quay.io/submariner-other/image:1"))
+	})
+
+	It("should leave the path unchanged if no mirror matches", func() {
+		Expect(images.ApplyRegistryMirrors("registry.k8s.io/pause:3.9", mirrors)).To(Equal("registry.k8s.io/pause:3.9"))
+		Expect(images.ApplyRegistryMirrors("registry.k8s.io/pause:3.9", nil)).To(Equal("registry.k8s.io/pause:3.9"))
+	})
+})
@@ -0,0 +1,101 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podtemplate
+
+import (
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ApplyOverrides merges the overrides configured for the given component, if any, into its pod template. The node
+// selector, labels and annotations are merged, with the template's existing labels taking precedence so the selectors
+// keep matching; the resources, tolerations, affinities and priority class replace the template's when set.
+func ApplyOverrides(template *corev1.PodTemplateSpec, components map[string]v1alpha1.ComponentSpec, component string) {
+	overrides, ok := components[component]
+	if !ok {
+		return
+	}
+
+	if overrides.Resources != nil {
+		for i := range template.Spec.InitContainers {
+			template.Spec.InitContainers[i].Resources = *overrides.Resources.DeepCopy()
+		}
+
+		for i := range template.Spec.Containers {
+			template.Spec.Containers[i].Resources = *overrides.Resources.DeepCopy()
+		}
+	}
+
+	template.Spec.NodeSelector = merge(template.Spec.NodeSelector, overrides.NodeSelector, true)
+
+	if len(overrides.Tolerations) > 0 {
+		template.Spec.Tolerations = overrides.Tolerations
+	}
+
+	if overrides.Affinity != nil {
+		mergeAffinity(&template.Spec, overrides.Affinity)
+	}
+
+	if overrides.PriorityClassName != "" {
+		template.Spec.PriorityClassName = overrides.PriorityClassName
+	}
+
+	template.Labels = merge(template.Labels, overrides.Labels, false)
+	template.Annotations = merge(template.Annotations, overrides.Annotations, true)
+}
+
+func mergeAffinity(podSpec *corev1.PodSpec, affinity *corev1.Affinity) {
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+
+	if affinity.NodeAffinity != nil {
+		podSpec.Affinity.NodeAffinity = affinity.NodeAffinity
+	}
+
+	if affinity.PodAffinity != nil {
+		podSpec.Affinity.PodAffinity = affinity.PodAffinity
+	}
+
+	if affinity.PodAntiAffinity != nil {
+		podSpec.Affinity.PodAntiAffinity = affinity.PodAntiAffinity
+	}
+}
+
+// merge returns to with the entries from from added; existing entries in to are only replaced if override is true. The
+// original to map is left untouched since the templates may share their label maps with the selectors.
+func merge(to, from map[string]string, override bool) map[string]string {
+	if len(from) == 0 {
+		return to
+	}
+
+	merged := make(map[string]string, len(to)+len(from))
+
+	for k, v := range to {
+		merged[k] = v
+	}
+
+	for k, v := range from {
+		if _, exists := merged[k]; !exists || override {
+			merged[k] = v
+		}
+	}
+
+	return merged
+}
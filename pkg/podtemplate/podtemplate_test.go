@@ -0,0 +1,130 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podtemplate_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/podtemplate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const component = "submariner-gateway"
+
+var _ = Describe("ApplyOverrides", func() {
+	var (
+		template   *corev1.PodTemplateSpec
+		components map[string]v1alpha1.ComponentSpec
+		labels     map[string]string
+	)
+
+	BeforeEach(func() {
+		labels = map[string]string{"app": component}
+
+		template = &corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: labels},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: component + "-init"}},
+				Containers:     []corev1.Container{{Name: component}},
+				NodeSelector:   map[string]string{"submariner.io/gateway": "true"},
+				Tolerations:    []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+				Affinity: &corev1.Affinity{
+					PodAntiAffinity: &corev1.PodAntiAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{TopologyKey: "kubernetes.io/hostname"}},
+					},
+				},
+			},
+		}
+
+		components = map[string]v1alpha1.ComponentSpec{}
+	})
+
+	JustBeforeEach(func() {
+		podtemplate.ApplyOverrides(template, components, component)
+	})
+
+	When("no overrides are configured for the component", func() {
+		BeforeEach(func() {
+			components["submariner-routeagent"] = v1alpha1.ComponentSpec{PriorityClassName: "high"}
+		})
+
+		It("should leave the template unchanged", func() {
+			Expect(template.Spec.PriorityClassName).To(BeEmpty())
+			Expect(template.Spec.Tolerations).To(HaveLen(1))
+		})
+	})
+
+	When("overrides are configured for the component", func() {
+		resources := corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+		}
+		tolerations := []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "gateway"}}
+		nodeAffinity := &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+				MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key: "pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"gateways"},
+				}},
+			}}},
+		}
+
+		BeforeEach(func() {
+			components[component] = v1alpha1.ComponentSpec{
+				Resources:         &resources,
+				NodeSelector:      map[string]string{"pool": "gateways"},
+				Tolerations:       tolerations,
+				Affinity:          &corev1.Affinity{NodeAffinity: nodeAffinity},
+				PriorityClassName: "system-node-critical",
+				Labels:            map[string]string{"team": "networking", "app": "other"},
+				Annotations:       map[string]string{"example.com/audit": "true"},
+			}
+		})
+
+		It("should set the resources on all the containers", func() {
+			Expect(template.Spec.InitContainers[0].Resources).To(Equal(resources))
+			Expect(template.Spec.Containers[0].Resources).To(Equal(resources))
+		})
+
+		It("should merge the node selector", func() {
+			Expect(template.Spec.NodeSelector).To(Equal(map[string]string{"submariner.io/gateway": "true", "pool": "gateways"}))
+		})
+
+		It("should replace the tolerations and priority class", func() {
+			Expect(template.Spec.Tolerations).To(Equal(tolerations))
+			Expect(template.Spec.PriorityClassName).To(Equal("system-node-critical"))
+		})
+
+		It("should only replace the configured affinity types", func() {
+			Expect(template.Spec.Affinity.NodeAffinity).To(Equal(nodeAffinity))
+			Expect(template.Spec.Affinity.PodAntiAffinity).ToNot(BeNil())
+		})
+
+		It("should add the labels without replacing the existing ones", func() {
+			Expect(template.Labels).To(Equal(map[string]string{"app": component, "team": "networking"}))
+			Expect(labels).To(Equal(map[string]string{"app": component}))
+		})
+
+		It("should add the annotations", func() {
+			Expect(template.Annotations).To(HaveKeyWithValue("example.com/audit", "true"))
+		})
+	})
+})